@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxUploadSize is the 10 MB attachment limit with room for the multipart
+// envelope around the file.
+const maxUploadSize = 10<<20 + 64<<10
+
+type AttachmentController struct {
+	usecase domain.AttachmentUsecaseInterface
+}
+
+func NewAttachmentController(usecase domain.AttachmentUsecaseInterface) *AttachmentController {
+	return &AttachmentController{usecase: usecase}
+}
+
+// workspace is the usecase limited to the active workspace of the request.
+func (ac *AttachmentController) workspace(ctx *gin.Context) (domain.AttachmentUsecaseInterface, bool) {
+
+	usecase, err := ac.usecase.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return usecase, true
+}
+
+func (ac *AttachmentController) UploadAttachment(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxUploadSize)
+	header, err := ctx.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "attachment exceeds the 10 MB limit"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "multipart field \"file\" is required"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	taskid := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := ac.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	attachment, err := usecase.UploadAttachment(taskid, header.Filename, header.Header.Get("Content-Type"), file, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, attachment)
+}
+
+func (ac *AttachmentController) GetAttachments(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	taskid := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := ac.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	attachments, err := usecase.GetAttachments(taskid, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, attachments)
+}
+
+// DownloadAttachment streams the file; http.ServeContent takes care of
+// Range and conditional requests.
+func (ac *AttachmentController) DownloadAttachment(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	taskid := ctx.Param("id")
+	id := ctx.Param("attachmentid")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := ac.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := usecase.OpenAttachment(taskid, id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	ctx.Header("Content-Type", attachment.ContentType)
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	ctx.Header("ETag", `"`+attachment.SHA256+`"`)
+	http.ServeContent(ctx.Writer, ctx.Request, attachment.Filename, attachment.CreatedAt, content)
+}
+
+func (ac *AttachmentController) RemoveAttachment(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	taskid := ctx.Param("id")
+	id := ctx.Param("attachmentid")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := ac.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.RemoveAttachment(taskid, id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "attachment removed"})
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type nopSeekCloser struct {
+	*strings.Reader
+}
+
+func (nopSeekCloser) Close() error { return nil }
+
+func setupAttachmentRouter(usecase domain.AttachmentUsecaseInterface) *gin.Engine {
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	attachmentController := NewAttachmentController(usecase)
+	router.POST("/tasks/:id/attachments", attachmentController.UploadAttachment)
+	router.GET("/tasks/:id/attachments/:attachmentid", attachmentController.DownloadAttachment)
+	return router
+}
+
+func TestAttachmentController_Upload(t *testing.T) {
+	mockAttachmentUsecase := new(mocks.AttachmentUsecaseInterface)
+	mockAttachmentUsecase.On("InWorkspace", "").Return(mockAttachmentUsecase, nil).Maybe()
+	router := setupAttachmentRouter(mockAttachmentUsecase)
+
+	t.Run("successful upload", func(t *testing.T) {
+		mockAttachmentUsecase.On("UploadAttachment", "taskID", "shot.png", "image/png", mock.Anything, "userID").
+			Return(&domain.Attachment{Filename: "shot.png"}, nil).Once()
+
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		header := make(map[string][]string)
+		header["Content-Disposition"] = []string{`form-data; name="file"; filename="shot.png"`}
+		header["Content-Type"] = []string{"image/png"}
+		part, _ := writer.CreatePart(header)
+		part.Write([]byte("\x89PNG\r\n\x1a\n"))
+		writer.Close()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tasks/taskID/attachments", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockAttachmentUsecase.AssertExpectations(t)
+	})
+
+	t.Run("body over the limit", func(t *testing.T) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, _ := writer.CreateFormFile("file", "big.txt")
+		part.Write(bytes.Repeat([]byte("a"), maxUploadSize))
+		writer.Close()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tasks/taskID/attachments", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "attachment exceeds the 10 MB limit")
+	})
+
+	t.Run("missing file field", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tasks/taskID/attachments", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAttachmentController_DownloadRange(t *testing.T) {
+	mockAttachmentUsecase := new(mocks.AttachmentUsecaseInterface)
+	mockAttachmentUsecase.On("InWorkspace", "").Return(mockAttachmentUsecase, nil).Maybe()
+	router := setupAttachmentRouter(mockAttachmentUsecase)
+
+	attachment := &domain.Attachment{Filename: "notes.txt", ContentType: "text/plain", Size: 10, SHA256: "abc", CreatedAt: time.Now()}
+	mockAttachmentUsecase.On("OpenAttachment", "taskID", "attachmentID", "userID").
+		Return(attachment, nopSeekCloser{strings.NewReader("0123456789")}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks/taskID/attachments/attachmentID", nil)
+	req.Header.Set("Range", "bytes=2-5")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "2345", w.Body.String())
+	assert.Equal(t, "bytes 2-5/10", w.Header().Get("Content-Range"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `filename=notes.txt`)
+}
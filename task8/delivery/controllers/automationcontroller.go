@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"net/http"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AutomationController struct {
+	usecase domain.AutomationUsecaseInterface
+}
+
+func NewAutomationController(usecase domain.AutomationUsecaseInterface) *AutomationController {
+	return &AutomationController{usecase: usecase}
+}
+
+// workspace is the usecase limited to the active workspace of the request.
+func (au *AutomationController) workspace(ctx *gin.Context) (domain.AutomationUsecaseInterface, bool) {
+
+	usecase, err := au.usecase.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return usecase, true
+}
+
+func (au *AutomationController) CreateAutomation(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var newautomation domain.Automation
+
+	if err := ctx.ShouldBindJSON(&newautomation); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := au.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.CreateAutomation(&newautomation, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, newautomation)
+}
+
+func (au *AutomationController) GetAutomations(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := au.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	automations, err := usecase.GetAutomations(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, automations)
+}
+
+func (au *AutomationController) UpdateAutomation(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var updatedautomation domain.Automation
+
+	if err := ctx.ShouldBindJSON(&updatedautomation); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := au.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.UpdateAutomation(id, &updatedautomation, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, updatedautomation)
+}
+
+func (au *AutomationController) RemoveAutomation(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := au.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.RemoveAutomation(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "automation removed"})
+}
+
+// GetRuns returns the execution log of an automation, newest first.
+func (au *AutomationController) GetRuns(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := au.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	runs, err := usecase.GetRuns(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, runs)
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAutomationRouter(usecase *mocks.AutomationUsecaseInterface) *gin.Engine {
+	usecase.On("InWorkspace", mock.Anything).Return(usecase, nil).Maybe()
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	automationController := NewAutomationController(usecase)
+	router.POST("/automations", automationController.CreateAutomation)
+	router.GET("/automations/:id/runs", automationController.GetRuns)
+	return router
+}
+
+func TestAutomationController_CreateAutomation(t *testing.T) {
+	mockAutomationUsecase := new(mocks.AutomationUsecaseInterface)
+	router := setupAutomationRouter(mockAutomationUsecase)
+
+	t.Run("success", func(t *testing.T) {
+		mockAutomationUsecase.On("CreateAutomation", mock.MatchedBy(func(automation *domain.Automation) bool {
+			return automation.Trigger == domain.EventTaskCompleted && len(automation.Actions) == 2 &&
+				automation.Actions[1].Type == domain.ActionCreateTask && automation.Actions[1].DueOffset == "+1d"
+		}), "userID").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/automations", strings.NewReader(`{"name":"Archive","trigger":"task.completed","actions":[{"type":"add_tag","value":"archived"},{"type":"create_task","title":"Announce {{title}}","due_offset":"+1d"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("invalid automation", func(t *testing.T) {
+		mockAutomationUsecase.On("CreateAutomation", mock.Anything, "userID").Return(errors.New("unknown trigger task.moved")).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/automations", strings.NewReader(`{"name":"Archive","trigger":"task.moved"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error":"unknown trigger task.moved"}`, w.Body.String())
+	})
+	mockAutomationUsecase.AssertExpectations(t)
+}
+
+func TestAutomationController_GetRuns(t *testing.T) {
+	mockAutomationUsecase := new(mocks.AutomationUsecaseInterface)
+	router := setupAutomationRouter(mockAutomationUsecase)
+
+	mockAutomationUsecase.On("GetRuns", "automationID", "userID").Return(&[]domain.AutomationRun{{Event: domain.EventTaskOverdue, Status: domain.RunSkipped, Error: "loop protection"}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/automations/automationID/runs", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"skipped"`)
+	mockAutomationUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,486 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+	"task8/domain"
+	"task8/infrastructure"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+	"github.com/gin-gonic/gin"
+)
+
+// DAVPrefix is where the CalDAV tree is served. Every user has a principal
+// at /dav/<user id>/ and a calendar home below it holding one calendar for
+// the tasks outside any project and one per project.
+const DAVPrefix = "/dav"
+
+// personalCalendar is the calendar of the tasks that belong to no project.
+// Project calendars are named by the project ID, which cannot clash with it.
+const personalCalendar = "tasks"
+
+type davContextKey int
+
+const (
+	davUserKey davContextKey = iota
+	davIfMatchKey
+)
+
+type CalDAVController struct {
+	tasks    domain.TaskUsecaseInterface
+	projects domain.ProjectUsecaseInterface
+}
+
+func NewCalDAVController(tasks domain.TaskUsecaseInterface, projects domain.ProjectUsecaseInterface) *CalDAVController {
+	return &CalDAVController{tasks: tasks, projects: projects}
+}
+
+// Serve answers every CalDAV request of the logged in user, including the
+// /.well-known/caldav redirect to their principal.
+func (dc *CalDAVController) Serve(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	// The calendars show the tasks and projects of the active workspace only.
+	tasks, err := dc.tasks.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	projects, err := dc.projects.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	handler := &caldav.Handler{Backend: &caldavBackend{tasks: tasks, projects: projects}, Prefix: DAVPrefix}
+
+	c := context.WithValue(ctx.Request.Context(), davUserKey, ctx.GetString("user_id"))
+	// The handler does not pass If-Match on to deletes, so it goes along
+	// in the context.
+	c = context.WithValue(c, davIfMatchKey, webdav.ConditionalMatch(ctx.GetHeader("If-Match")))
+	handler.ServeHTTP(ctx.Writer, ctx.Request.WithContext(c))
+
+}
+
+// caldavBackend maps the tasks of a user onto calendars of VTODO objects.
+// Objects are named by the name a client created them with, or else by the
+// task ID, and their ETag is a hash of the rendered object.
+type caldavBackend struct {
+	tasks    domain.TaskUsecaseInterface
+	projects domain.ProjectUsecaseInterface
+}
+
+// davObject is a task along with the calendar object it renders to.
+type davObject struct {
+	task   domain.Task
+	object caldav.CalendarObject
+}
+
+func davUser(ctx context.Context) string {
+	userid, _ := ctx.Value(davUserKey).(string)
+	return userid
+}
+
+func (b *caldavBackend) CurrentUserPrincipal(ctx context.Context) (string, error) {
+	return DAVPrefix + "/" + davUser(ctx) + "/", nil
+}
+
+func (b *caldavBackend) CalendarHomeSetPath(ctx context.Context) (string, error) {
+	return DAVPrefix + "/" + davUser(ctx) + "/calendars/", nil
+}
+
+func (b *caldavBackend) CreateCalendar(ctx context.Context, calendar *caldav.Calendar) error {
+	return webdav.NewHTTPError(http.StatusForbidden, errors.New("calendars follow the projects and cannot be created"))
+}
+
+func (b *caldavBackend) ListCalendars(ctx context.Context) ([]caldav.Calendar, error) {
+
+	userid := davUser(ctx)
+	projects, err := b.projects.GetProjects(userid)
+	if err != nil {
+		return nil, err
+	}
+	calendars := []caldav.Calendar{b.calendar(ctx, personalCalendar, "Tasks", "Tasks outside any project")}
+	for _, project := range *projects {
+		calendars = append(calendars, b.calendar(ctx, project.ID.Hex(), project.Name, project.Description))
+	}
+	return calendars, nil
+}
+
+func (b *caldavBackend) GetCalendar(ctx context.Context, p string) (*caldav.Calendar, error) {
+
+	name, object, err := b.split(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if object != "" {
+		return nil, webdav.NewHTTPError(http.StatusNotFound, errors.New("not a calendar"))
+	}
+	if name == personalCalendar {
+		calendar := b.calendar(ctx, personalCalendar, "Tasks", "Tasks outside any project")
+		return &calendar, nil
+	}
+	project, err := b.projects.GetProject(name, davUser(ctx))
+	if err != nil {
+		return nil, webdav.NewHTTPError(http.StatusNotFound, err)
+	}
+	calendar := b.calendar(ctx, name, project.Name, project.Description)
+	return &calendar, nil
+}
+
+func (b *caldavBackend) GetCalendarObject(ctx context.Context, p string, req *caldav.CalendarCompRequest) (*caldav.CalendarObject, error) {
+
+	calendar, name, err := b.split(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	found, err := b.find(ctx, calendar, name)
+	if err != nil {
+		return nil, err
+	}
+	return &found.object, nil
+}
+
+func (b *caldavBackend) ListCalendarObjects(ctx context.Context, p string, req *caldav.CalendarCompRequest) ([]caldav.CalendarObject, error) {
+
+	calendar, _, err := b.split(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := b.objects(ctx, calendar)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]caldav.CalendarObject, len(objects))
+	for i := range objects {
+		list[i] = objects[i].object
+	}
+	return list, nil
+}
+
+// QueryCalendarObjects applies the query with caldav.Filter, except for a
+// time range on VTODO, which it only knows for events: a task matches when
+// it is due in the range or has no due date.
+func (b *caldavBackend) QueryCalendarObjects(ctx context.Context, p string, query *caldav.CalendarQuery) ([]caldav.CalendarObject, error) {
+
+	calendar, _, err := b.split(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := b.objects(ctx, calendar)
+	if err != nil {
+		return nil, err
+	}
+	filter, start, end := todoTimeRange(query)
+	list := []caldav.CalendarObject{}
+	for _, found := range objects {
+		due := found.task.DueDate
+		if due.IsZero() || start.IsZero() || (!due.Before(start) && (end.IsZero() || due.Before(end))) {
+			list = append(list, found.object)
+		}
+	}
+	return caldav.Filter(&filter, list)
+}
+
+// PutCalendarObject creates or updates the task behind p from its VTODO.
+// If-None-Match: * only creates and If-Match only updates that version.
+func (b *caldavBackend) PutCalendarObject(ctx context.Context, p string, calendar *ical.Calendar, opts *caldav.PutCalendarObjectOptions) (*caldav.CalendarObject, error) {
+
+	calendarName, name, err := b.split(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, webdav.NewHTTPError(http.StatusMethodNotAllowed, errors.New("only calendar objects can be written"))
+	}
+	kind, uid, err := caldav.ValidateCalendarObject(calendar)
+	if err != nil {
+		return nil, webdav.NewHTTPError(http.StatusBadRequest, err)
+	}
+	if kind != ical.CompToDo {
+		return nil, caldav.NewPreconditionError(caldav.PreconditionSupportedCalendarComponent)
+	}
+	objects, err := b.objects(ctx, calendarName)
+	if err != nil {
+		return nil, err
+	}
+	var existing *davObject
+	for i := range objects {
+		if davName(&objects[i].task) == name {
+			existing = &objects[i]
+		} else if infrastructure.TaskUID(&objects[i].task) == uid {
+			return nil, caldav.NewPreconditionError(caldav.PreconditionNoUIDConflict)
+		}
+	}
+	if err := checkPreconditions(existing, opts.IfNoneMatch, opts.IfMatch); err != nil {
+		return nil, err
+	}
+
+	userid := davUser(ctx)
+	var todo *ical.Component
+	for _, child := range calendar.Children {
+		if child.Name == ical.CompToDo {
+			todo = child
+		}
+	}
+	if existing != nil {
+		if infrastructure.TaskUID(&existing.task) != uid {
+			return nil, caldav.NewPreconditionError(caldav.PreconditionNoUIDConflict)
+		}
+		task := existing.task
+		if err := applyTodo(&task, todo); err != nil {
+			return nil, webdav.NewHTTPError(http.StatusBadRequest, err)
+		}
+		if err := b.tasks.UpdateTask(task.ID.Hex(), &task, userid); err != nil {
+			return nil, webdav.NewHTTPError(http.StatusBadRequest, err)
+		}
+	} else {
+		task := domain.Task{Status: "todo", CalendarUID: uid, CalendarName: name}
+		if err := applyTodo(&task, todo); err != nil {
+			return nil, webdav.NewHTTPError(http.StatusBadRequest, err)
+		}
+		if calendarName != personalCalendar {
+			if task.ProjectID, err = parseObjectID(calendarName); err != nil {
+				return nil, webdav.NewHTTPError(http.StatusNotFound, err)
+			}
+		}
+		if err := b.tasks.CreateTask(&task, userid); err != nil {
+			return nil, webdav.NewHTTPError(http.StatusBadRequest, err)
+		}
+	}
+
+	found, err := b.find(ctx, calendarName, name)
+	if err != nil {
+		return nil, err
+	}
+	return &found.object, nil
+}
+
+func (b *caldavBackend) DeleteCalendarObject(ctx context.Context, p string) error {
+
+	calendar, name, err := b.split(ctx, p)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return webdav.NewHTTPError(http.StatusForbidden, errors.New("calendars follow the projects and cannot be deleted"))
+	}
+	found, err := b.find(ctx, calendar, name)
+	if err != nil {
+		return err
+	}
+	ifMatch, _ := ctx.Value(davIfMatchKey).(webdav.ConditionalMatch)
+	if err := checkPreconditions(found, "", ifMatch); err != nil {
+		return err
+	}
+	if err := b.tasks.RemoveTask(found.task.ID.Hex(), davUser(ctx)); err != nil {
+		return webdav.NewHTTPError(http.StatusBadRequest, err)
+	}
+	return nil
+}
+
+func (b *caldavBackend) calendar(ctx context.Context, name string, displayName string, description string) caldav.Calendar {
+	home, _ := b.CalendarHomeSetPath(ctx)
+	return caldav.Calendar{
+		Path:                  home + name + "/",
+		Name:                  displayName,
+		Description:           description,
+		SupportedComponentSet: []string{ical.CompToDo},
+	}
+}
+
+// split takes a path below the user's calendar home apart into the calendar
+// and, for an object, its name without the .ics extension.
+func (b *caldavBackend) split(ctx context.Context, p string) (string, string, error) {
+
+	home, _ := b.CalendarHomeSetPath(ctx)
+	rest, ok := strings.CutPrefix(path.Clean(p)+"/", home)
+	parts := strings.Split(strings.TrimSuffix(rest, "/"), "/")
+	if !ok || parts[0] == "" || len(parts) > 2 {
+		return "", "", webdav.NewHTTPError(http.StatusNotFound, errors.New("not found"))
+	}
+	if len(parts) == 1 {
+		return parts[0], "", nil
+	}
+	name, ok := strings.CutSuffix(parts[1], ".ics")
+	if !ok || name == "" {
+		return "", "", webdav.NewHTTPError(http.StatusNotFound, errors.New("calendar objects end in .ics"))
+	}
+	return parts[0], name, nil
+}
+
+// objects renders every task of a calendar.
+func (b *caldavBackend) objects(ctx context.Context, calendar string) ([]davObject, error) {
+
+	userid := davUser(ctx)
+	var tasks *[]domain.Task
+	var err error
+	if calendar == personalCalendar {
+		tasks, err = b.tasks.GetTasks(userid)
+	} else {
+		tasks, err = b.tasks.GetProjectTasks(calendar, userid)
+	}
+	if err != nil {
+		return nil, webdav.NewHTTPError(http.StatusNotFound, err)
+	}
+	home, _ := b.CalendarHomeSetPath(ctx)
+	objects := []davObject{}
+	for _, task := range *tasks {
+		if calendar == personalCalendar && !task.ProjectID.IsZero() {
+			continue
+		}
+		object, err := renderTodo(&task, home+calendar+"/")
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, davObject{task: task, object: *object})
+	}
+	return objects, nil
+}
+
+func (b *caldavBackend) find(ctx context.Context, calendar string, name string) (*davObject, error) {
+
+	objects, err := b.objects(ctx, calendar)
+	if err != nil {
+		return nil, err
+	}
+	for i := range objects {
+		if davName(&objects[i].task) == name {
+			return &objects[i], nil
+		}
+	}
+	return nil, webdav.NewHTTPError(http.StatusNotFound, errors.New("task not found"))
+}
+
+func davName(task *domain.Task) string {
+	if task.CalendarName != "" {
+		return task.CalendarName
+	}
+	return task.ID.Hex()
+}
+
+// renderTodo renders a task as a calendar object. The DTSTAMP is the
+// creation time, so that the ETag only changes with the task.
+func renderTodo(task *domain.Task, calendarPath string) (*caldav.CalendarObject, error) {
+
+	calendar := infrastructure.NewCalendarObject(time.UTC, task.CreatedAt)
+	calendar.AddTodo(task)
+	text := calendar.String()
+	data, err := ical.NewDecoder(strings.NewReader(text)).Decode()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(text))
+	return &caldav.CalendarObject{
+		Path:          calendarPath + davName(task) + ".ics",
+		ContentLength: int64(len(text)),
+		ETag:          hex.EncodeToString(sum[:16]),
+		Data:          data,
+	}, nil
+}
+
+// checkPreconditions answers 412 when If-None-Match or If-Match rule out
+// writing over existing, which is nil for a new object.
+func checkPreconditions(existing *davObject, ifNoneMatch webdav.ConditionalMatch, ifMatch webdav.ConditionalMatch) error {
+
+	failed := webdav.NewHTTPError(http.StatusPreconditionFailed, errors.New("the calendar object has changed"))
+	if ifNoneMatch.IsWildcard() && existing != nil {
+		return failed
+	}
+	if !ifMatch.IsSet() {
+		return nil
+	}
+	if existing == nil {
+		return failed
+	}
+	if ifMatch.IsWildcard() {
+		return nil
+	}
+	etag, err := ifMatch.ETag()
+	if err != nil || etag != existing.object.ETag {
+		return failed
+	}
+	return nil
+}
+
+// applyTodo copies what a VTODO says onto a task. Only the fields a task
+// has are read; the rest of the component is dropped.
+func applyTodo(task *domain.Task, todo *ical.Component) error {
+
+	title, err := todo.Props.Text(ical.PropSummary)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(title) == "" {
+		return errors.New("a task needs a SUMMARY")
+	}
+	description, err := todo.Props.Text(ical.PropDescription)
+	if err != nil {
+		return err
+	}
+	if description == "" {
+		description = title
+	}
+	due, err := todo.Props.DateTime(ical.PropDue, time.UTC)
+	if err != nil {
+		return err
+	}
+	// A DUE without a time of day is read at midnight UTC, which is how
+	// date-only due dates are stored.
+	dateOnly := false
+	if prop := todo.Props.Get(ical.PropDue); prop != nil {
+		dateOnly = prop.ValueType() == ical.ValueDate || len(prop.Value) == len("20060102")
+	}
+	var tags []string
+	for _, prop := range todo.Props.Values(ical.PropCategories) {
+		values, err := prop.TextList()
+		if err != nil {
+			return err
+		}
+		tags = append(tags, values...)
+	}
+	status, err := todo.Props.Text(ical.PropStatus)
+	if err != nil {
+		return err
+	}
+
+	task.Title = title
+	task.Description = description
+	task.DueDate = due
+	task.DueDateOnly = dateOnly && !due.IsZero()
+	task.Tags = tags
+	switch {
+	case strings.EqualFold(status, "COMPLETED"):
+		task.Status = "done"
+	case domain.IsDone(task.Status):
+		task.Status = "todo"
+	}
+	return nil
+}
+
+// todoTimeRange returns query without the time range of its VTODO filter,
+// and that range.
+func todoTimeRange(query *caldav.CalendarQuery) (caldav.CalendarQuery, time.Time, time.Time) {
+
+	filter := *query
+	filter.CompFilter.Comps = append([]caldav.CompFilter(nil), query.CompFilter.Comps...)
+	var start, end time.Time
+	for i, comp := range filter.CompFilter.Comps {
+		if comp.Name == ical.CompToDo && !comp.Start.IsZero() {
+			start, end = comp.Start, comp.End
+			filter.CompFilter.Comps[i].Start = time.Time{}
+			filter.CompFilter.Comps[i].End = time.Time{}
+		}
+	}
+	return filter, start, end
+}
@@ -0,0 +1,241 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// setupCalDAV serves the controller the way the router does, for the user
+// userid, to be talked to with the go-webdav client.
+func setupCalDAV(t *testing.T, userid string) (*mocks.TaskUsecaseInterface, *mocks.ProjectUsecaseInterface, *httptest.Server) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	mockProjectUsecase := new(mocks.ProjectUsecaseInterface)
+	mockTaskUsecase.On("InWorkspace", "").Return(mockTaskUsecase, nil).Maybe()
+	mockProjectUsecase.On("InWorkspace", "").Return(mockProjectUsecase, nil).Maybe()
+	davController := NewCalDAVController(mockTaskUsecase, mockProjectUsecase)
+
+	router := setupRouter()
+	dav := router.Group(DAVPrefix, func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", userid)
+		ctx.Next()
+	})
+	for _, method := range []string{"OPTIONS", "PROPFIND", "REPORT", "GET", "HEAD", "PUT", "DELETE"} {
+		dav.Handle(method, "/*path", davController.Serve)
+	}
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return mockTaskUsecase, mockProjectUsecase, server
+}
+
+func TestCalDAVController(t *testing.T) {
+	userid := primitive.NewObjectID().Hex()
+	projectID := primitive.NewObjectID()
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	due := domain.Task{ID: primitive.NewObjectID(), Title: "Pay rent", Description: "Pay rent", Status: "todo", DueDate: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), DueDateOnly: true, Tags: []string{"home"}, CreatedAt: created}
+	later := domain.Task{ID: primitive.NewObjectID(), Title: "Renew passport", Description: "Renew passport", Status: "todo", DueDate: time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC), CreatedAt: created}
+	undated := domain.Task{ID: primitive.NewObjectID(), Title: "Read a book", Description: "Read a book", Status: "done", CreatedAt: created}
+	inProject := domain.Task{ID: primitive.NewObjectID(), Title: "Ship it", Description: "Ship it", Status: "todo", ProjectID: projectID, CreatedAt: created}
+	personal := []domain.Task{due, later, undated, inProject}
+	ctx := context.Background()
+
+	t.Run("discovery", func(t *testing.T) {
+		_, mockProjectUsecase, server := setupCalDAV(t, userid)
+		mockProjectUsecase.On("GetProjects", userid).Return(&[]domain.Project{{ID: projectID, Name: "Launch"}}, nil)
+		client, err := caldav.NewClient(http.DefaultClient, server.URL+DAVPrefix+"/")
+		assert.NoError(t, err)
+
+		principal, err := client.FindCurrentUserPrincipal(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "/dav/"+userid+"/", principal)
+
+		home, err := client.FindCalendarHomeSet(ctx, principal)
+		assert.NoError(t, err)
+		assert.Equal(t, "/dav/"+userid+"/calendars/", home)
+
+		calendars, err := client.FindCalendars(ctx, home)
+		assert.NoError(t, err)
+		assert.Len(t, calendars, 2)
+		assert.Equal(t, home+"tasks/", calendars[0].Path)
+		assert.Equal(t, home+projectID.Hex()+"/", calendars[1].Path)
+		assert.Equal(t, "Launch", calendars[1].Name)
+		assert.Equal(t, []string{"VTODO"}, calendars[1].SupportedComponentSet)
+	})
+
+	t.Run("query by due date", func(t *testing.T) {
+		mockTaskUsecase, _, server := setupCalDAV(t, userid)
+		mockTaskUsecase.On("GetTasks", userid).Return(&personal, nil)
+		client, _ := caldav.NewClient(http.DefaultClient, server.URL+DAVPrefix+"/")
+
+		objects, err := client.QueryCalendar(ctx, "/dav/"+userid+"/calendars/tasks/", &caldav.CalendarQuery{
+			CompRequest: caldav.CalendarCompRequest{Name: "VCALENDAR", AllProps: true, AllComps: true},
+			CompFilter: caldav.CompFilter{Name: "VCALENDAR", Comps: []caldav.CompFilter{{
+				Name:  "VTODO",
+				Start: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			}}},
+		})
+
+		assert.NoError(t, err)
+		paths := []string{}
+		for _, object := range objects {
+			paths = append(paths, object.Path)
+		}
+		base := "/dav/" + userid + "/calendars/tasks/"
+		assert.ElementsMatch(t, []string{base + due.ID.Hex() + ".ics", base + undated.ID.Hex() + ".ics"}, paths)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		mockTaskUsecase, _, server := setupCalDAV(t, userid)
+		mockTaskUsecase.On("GetTasks", userid).Return(&personal, nil)
+		client, _ := caldav.NewClient(http.DefaultClient, server.URL+DAVPrefix+"/")
+
+		object, err := client.GetCalendarObject(ctx, "/dav/"+userid+"/calendars/tasks/"+due.ID.Hex()+".ics")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, object.ETag)
+		todo := object.Data.Children[0]
+		assert.Equal(t, "VTODO", todo.Name)
+		summary, _ := todo.Props.Text(ical.PropSummary)
+		assert.Equal(t, "Pay rent", summary)
+		assert.Equal(t, "20240310", todo.Props.Get(ical.PropDue).Value)
+	})
+
+	t.Run("put creates a task in the project", func(t *testing.T) {
+		mockTaskUsecase, _, server := setupCalDAV(t, userid)
+		stored := []domain.Task{}
+		mockTaskUsecase.On("GetProjectTasks", projectID.Hex(), userid).Return(func(string, string) (*[]domain.Task, error) {
+			return &stored, nil
+		})
+		mockTaskUsecase.On("CreateTask", mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Title == "Write changelog" && task.Description == "Write changelog" && task.Status == "todo" &&
+				task.ProjectID == projectID && task.CalendarUID == "abc-123" && task.CalendarName == "abc-123" &&
+				task.DueDate.Equal(time.Date(2024, 5, 2, 15, 0, 0, 0, time.UTC)) && !task.DueDateOnly && assert.ObjectsAreEqual([]string{"docs", "release"}, task.Tags)
+		}), userid).Run(func(args mock.Arguments) {
+			task := args.Get(0).(*domain.Task)
+			task.ID = primitive.NewObjectID()
+			stored = append(stored, *task)
+		}).Return(nil).Once()
+		client, _ := caldav.NewClient(http.DefaultClient, server.URL+DAVPrefix+"/")
+
+		calendar := ical.NewCalendar()
+		calendar.Props.SetText(ical.PropVersion, "2.0")
+		calendar.Props.SetText(ical.PropProductID, "-//test//client//EN")
+		todo := ical.NewComponent(ical.CompToDo)
+		todo.Props.SetText(ical.PropUID, "abc-123")
+		todo.Props.SetDateTime(ical.PropDateTimeStamp, created)
+		todo.Props.SetText(ical.PropSummary, "Write changelog")
+		berlin, _ := time.LoadLocation("Europe/Berlin")
+		todo.Props.SetDateTime(ical.PropDue, time.Date(2024, 5, 2, 17, 0, 0, 0, berlin))
+		categories := ical.NewProp(ical.PropCategories)
+		categories.SetTextList([]string{"docs", "release"})
+		todo.Props.Set(categories)
+		calendar.Children = append(calendar.Children, todo)
+
+		object, err := client.PutCalendarObject(ctx, "/dav/"+userid+"/calendars/"+projectID.Hex()+"/abc-123.ics", calendar)
+
+		if assert.NoError(t, err) {
+			assert.NotEmpty(t, object.ETag)
+		}
+		mockTaskUsecase.AssertExpectations(t)
+	})
+
+	t.Run("put with a stale etag", func(t *testing.T) {
+		mockTaskUsecase, _, server := setupCalDAV(t, userid)
+		mockTaskUsecase.On("GetTasks", userid).Return(&personal, nil)
+
+		body := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//test//client//EN\r\nBEGIN:VTODO\r\nUID:" + due.ID.Hex() + "@task8\r\nDTSTAMP:20240301T090000Z\r\nSUMMARY:Pay rent twice\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+		req, _ := http.NewRequest("PUT", server.URL+"/dav/"+userid+"/calendars/tasks/"+due.ID.Hex()+".ics", strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/calendar")
+		req.Header.Set("If-Match", `"stale"`)
+		resp, err := http.DefaultClient.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+		mockTaskUsecase.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("put updates with a matching etag", func(t *testing.T) {
+		mockTaskUsecase, _, server := setupCalDAV(t, userid)
+		mockTaskUsecase.On("GetTasks", userid).Return(&personal, nil)
+		mockTaskUsecase.On("UpdateTask", due.ID.Hex(), mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Title == "Pay rent twice" && task.Status == "done" &&
+				task.DueDateOnly && task.DueDate.Equal(time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC))
+		}), userid).Return(nil).Once()
+		client, _ := caldav.NewClient(http.DefaultClient, server.URL+DAVPrefix+"/")
+		path := "/dav/" + userid + "/calendars/tasks/" + due.ID.Hex() + ".ics"
+		object, err := client.GetCalendarObject(ctx, path)
+		assert.NoError(t, err)
+
+		body := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//test//client//EN\r\nBEGIN:VTODO\r\nUID:" + due.ID.Hex() + "@task8\r\nDTSTAMP:20240301T090000Z\r\nSUMMARY:Pay rent twice\r\nDUE;VALUE=DATE:20240311\r\nSTATUS:COMPLETED\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+		req, _ := http.NewRequest("PUT", server.URL+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/calendar")
+		req.Header.Set("If-Match", `"`+object.ETag+`"`)
+		resp, err := http.DefaultClient.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		mockTaskUsecase.AssertExpectations(t)
+	})
+
+	t.Run("put rejects events", func(t *testing.T) {
+		_, _, server := setupCalDAV(t, userid)
+
+		body := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//test//client//EN\r\nBEGIN:VEVENT\r\nUID:e1\r\nDTSTAMP:20240301T090000Z\r\nDTSTART:20240301T090000Z\r\nSUMMARY:Meeting\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+		req, _ := http.NewRequest("PUT", server.URL+"/dav/"+userid+"/calendars/tasks/e1.ics", strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/calendar")
+		resp, err := http.DefaultClient.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockTaskUsecase, _, server := setupCalDAV(t, userid)
+		mockTaskUsecase.On("GetTasks", userid).Return(&personal, nil)
+		mockTaskUsecase.On("RemoveTask", later.ID.Hex(), userid).Return(nil).Once()
+		client, _ := webdav.NewClient(http.DefaultClient, server.URL)
+
+		err := client.RemoveAll(ctx, "/dav/"+userid+"/calendars/tasks/"+later.ID.Hex()+".ics")
+
+		assert.NoError(t, err)
+		mockTaskUsecase.AssertExpectations(t)
+	})
+
+	t.Run("delete with a stale etag", func(t *testing.T) {
+		mockTaskUsecase, _, server := setupCalDAV(t, userid)
+		mockTaskUsecase.On("GetTasks", userid).Return(&personal, nil)
+
+		req, _ := http.NewRequest("DELETE", server.URL+"/dav/"+userid+"/calendars/tasks/"+later.ID.Hex()+".ics", nil)
+		req.Header.Set("If-Match", `"stale"`)
+		resp, err := http.DefaultClient.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+		mockTaskUsecase.AssertNotCalled(t, "RemoveTask", mock.Anything, mock.Anything)
+	})
+
+	t.Run("other users are not found", func(t *testing.T) {
+		_, _, server := setupCalDAV(t, userid)
+
+		req, _ := http.NewRequest("GET", server.URL+"/dav/"+primitive.NewObjectID().Hex()+"/calendars/tasks/"+due.ID.Hex()+".ics", nil)
+		resp, err := http.DefaultClient.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
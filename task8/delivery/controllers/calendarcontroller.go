@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarController struct {
+	usecase domain.CalendarUsecaseInterface
+}
+
+func NewCalendarController(usecase domain.CalendarUsecaseInterface) *CalendarController {
+	return &CalendarController{usecase: usecase}
+}
+
+func (cc *CalendarController) GetFeed(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	feed, err := cc.usecase.GetFeed(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, feed)
+}
+
+// RegenerateToken answers with the new token and the feed URL path; the
+// token cannot be read back later.
+func (cc *CalendarController) RegenerateToken(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	feed, err := cc.usecase.RegenerateToken(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"token": feed.Token, "url": "/calendar/" + feed.Token + ".ics", "timezone": feed.Timezone})
+}
+
+func (cc *CalendarController) SetTimezone(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var body struct {
+		Timezone string `json:"timezone"`
+	}
+
+	if err := ctx.BindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	feed, err := cc.usecase.SetTimezone(userid, body.Timezone)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, feed)
+}
+
+// RenderFeed serves GET /calendar/:token.ics without a login, the token
+// standing in for one. ?tag= and ?status= narrow the feed, ?as=todo renders
+// tasks as to-dos instead of events. Unknown tokens get a bare 404.
+func (cc *CalendarController) RenderFeed(ctx *gin.Context) {
+	token, ok := strings.CutSuffix(ctx.Param("token"), ".ics")
+	if !ok || token == "" {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	filter := domain.CalendarFilter{Tags: queryList(ctx, "tag"), Statuses: queryList(ctx, "status"), As: ctx.Query("as")}
+	if filter.As != "" && filter.As != domain.CalendarEvent && filter.As != domain.CalendarTodo {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "as must be event or todo"})
+		return
+	}
+
+	calendar, err := cc.usecase.RenderFeed(token, filter)
+
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(calendar))
+}
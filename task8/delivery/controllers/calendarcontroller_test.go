@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarController(t *testing.T) {
+	mockCalendarUsecase := new(mocks.CalendarUsecaseInterface)
+	calendarController := NewCalendarController(mockCalendarUsecase)
+	router := setupRouter()
+	router.GET("/calendar/:token", calendarController.RenderFeed)
+	user := router.Group("/", func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	user.POST("/calendar/token", calendarController.RegenerateToken)
+	user.PUT("/calendar", calendarController.SetTimezone)
+
+	t.Run("regenerate token", func(t *testing.T) {
+		mockCalendarUsecase.On("RegenerateToken", "userID").Return(&domain.CalendarFeed{Token: "abc", Timezone: "UTC"}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/calendar/token", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"url":"/calendar/abc.ics"`)
+	})
+
+	t.Run("set timezone", func(t *testing.T) {
+		mockCalendarUsecase.On("SetTimezone", "userID", "Europe/Berlin").Return(&domain.CalendarFeed{Timezone: "Europe/Berlin"}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/calendar", strings.NewReader(`{"timezone":"Europe/Berlin"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "token")
+	})
+
+	t.Run("feed", func(t *testing.T) {
+		filter := domain.CalendarFilter{Tags: []string{"work", "ops"}, Statuses: []string{"todo"}, As: "todo"}
+		mockCalendarUsecase.On("RenderFeed", "abc", filter).Return("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/calendar/abc.ics?tag=work,ops&status=todo&as=todo", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(w.Body.String(), "BEGIN:VCALENDAR"))
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mockCalendarUsecase.On("RenderFeed", "guess", domain.CalendarFilter{}).Return("", errors.New("calendar feed not found")).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/calendar/guess.ics", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.NotContains(t, w.Body.String(), "calendar feed")
+	})
+
+	t.Run("path without .ics", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/calendar/abc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	mockCalendarUsecase.AssertExpectations(t)
+}
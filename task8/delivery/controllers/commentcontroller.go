@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"net/http"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentController struct {
+	usecase domain.CommentUsecaseInterface
+}
+
+func NewCommentController(usecase domain.CommentUsecaseInterface) *CommentController {
+	return &CommentController{usecase: usecase}
+}
+
+// workspace is the usecase limited to the active workspace of the request.
+func (cc *CommentController) workspace(ctx *gin.Context) (domain.CommentUsecaseInterface, bool) {
+
+	usecase, err := cc.usecase.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return usecase, true
+}
+
+func (cc *CommentController) CreateComment(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var newcomment domain.Comment
+
+	if err := ctx.ShouldBindJSON(&newcomment); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	taskid := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := cc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.CreateComment(taskid, &newcomment, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, newcomment)
+}
+
+func (cc *CommentController) GetComments(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	taskid := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := cc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	comments, err := usecase.GetComments(taskid, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, comments)
+}
+
+func (cc *CommentController) UpdateComment(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var body struct {
+		Body string `json:"body"`
+	}
+
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	taskid := ctx.Param("id")
+	id := ctx.Param("commentid")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := cc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	comment, err := usecase.UpdateComment(taskid, id, body.Body, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, comment)
+}
+
+// RemoveComment lets users delete their own comments and admins delete any.
+func (cc *CommentController) RemoveComment(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || (role != "user" && role != "admin") {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	taskid := ctx.Param("id")
+	id := ctx.Param("commentid")
+
+	usecase, ok := cc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	var err error
+	if role == "admin" {
+		err = usecase.AdminRemoveComment(taskid, id)
+	} else {
+		err = usecase.RemoveComment(taskid, id, ctx.GetString("user_id"))
+	}
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "comment removed"})
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommentController(t *testing.T) {
+	mockCommentUsecase := new(mocks.CommentUsecaseInterface)
+	mockCommentUsecase.On("InWorkspace", "").Return(mockCommentUsecase, nil).Maybe()
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", ctx.Query("role"))
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	commentController := NewCommentController(mockCommentUsecase)
+	router.POST("/tasks/:id/comments", commentController.CreateComment)
+	router.DELETE("/tasks/:id/comments/:commentid", commentController.RemoveComment)
+
+	t.Run("create comment", func(t *testing.T) {
+		mockCommentUsecase.On("CreateComment", "taskID", mock.AnythingOfType("*domain.Comment"), "userID").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tasks/taskID/comments?role=user", strings.NewReader(`{"body":"looks good"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"body":"looks good"`)
+	})
+
+	t.Run("user deletes own comment", func(t *testing.T) {
+		mockCommentUsecase.On("RemoveComment", "taskID", "commentID", "userID").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/tasks/taskID/comments/commentID?role=user", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("admin deletes any comment", func(t *testing.T) {
+		mockCommentUsecase.On("AdminRemoveComment", "taskID", "commentID").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/tasks/taskID/comments/commentID?role=admin", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	mockCommentUsecase.AssertExpectations(t)
+}
+
+func TestCommentController_Workspace(t *testing.T) {
+	mockCommentUsecase := new(mocks.CommentUsecaseInterface)
+	scopedUsecase := new(mocks.CommentUsecaseInterface)
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Set("workspace_id", "workspaceID")
+		ctx.Next()
+	})
+	router.GET("/tasks/:id/comments", NewCommentController(mockCommentUsecase).GetComments)
+	mockCommentUsecase.On("InWorkspace", "workspaceID").Return(scopedUsecase, nil).Once()
+	scopedUsecase.On("GetComments", "taskID", "userID").Return(&[]domain.Comment{}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks/taskID/comments", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockCommentUsecase.AssertExpectations(t)
+	scopedUsecase.AssertExpectations(t)
+}
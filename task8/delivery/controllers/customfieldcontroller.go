@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"net/http"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomFieldController struct {
+	usecase domain.CustomFieldUsecaseInterface
+}
+
+func NewCustomFieldController(usecase domain.CustomFieldUsecaseInterface) *CustomFieldController {
+	return &CustomFieldController{usecase: usecase}
+}
+
+// workspace is the usecase limited to the active workspace of the request.
+func (cf *CustomFieldController) workspace(ctx *gin.Context) (domain.CustomFieldUsecaseInterface, bool) {
+
+	usecase, err := cf.usecase.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return usecase, true
+}
+
+func (cf *CustomFieldController) CreateField(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var newfield domain.CustomField
+
+	if err := ctx.ShouldBindJSON(&newfield); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := cf.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.CreateField(&newfield, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, newfield)
+}
+
+func (cf *CustomFieldController) GetFields(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := cf.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	fields, err := usecase.GetFields(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, fields)
+}
+
+func (cf *CustomFieldController) UpdateField(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var updatedfield domain.CustomField
+
+	if err := ctx.ShouldBindJSON(&updatedfield); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := cf.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.UpdateField(id, &updatedfield, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, updatedfield)
+}
+
+func (cf *CustomFieldController) RemoveField(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := cf.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.RemoveField(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "field removed"})
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupCustomFieldRouter(usecase *mocks.CustomFieldUsecaseInterface) *gin.Engine {
+	usecase.On("InWorkspace", mock.Anything).Return(usecase, nil).Maybe()
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	fieldController := NewCustomFieldController(usecase)
+	router.POST("/fields", fieldController.CreateField)
+	router.GET("/fields", fieldController.GetFields)
+	router.DELETE("/fields/:id", fieldController.RemoveField)
+	return router
+}
+
+func TestCustomFieldController_CreateField(t *testing.T) {
+	mockFieldUsecase := new(mocks.CustomFieldUsecaseInterface)
+	router := setupCustomFieldRouter(mockFieldUsecase)
+
+	t.Run("success", func(t *testing.T) {
+		mockFieldUsecase.On("CreateField", mock.MatchedBy(func(field *domain.CustomField) bool {
+			return field.Key == "sprint" && field.Type == domain.FieldEnum && len(field.Options) == 2
+		}), "userID").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/fields", strings.NewReader(`{"key":"sprint","name":"Sprint","type":"enum","options":["24","25"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("members cannot define fields", func(t *testing.T) {
+		mockFieldUsecase.On("CreateField", mock.Anything, "userID").Return(errors.New("only workspace admins can change custom fields")).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/fields", strings.NewReader(`{"key":"customer","name":"Customer","type":"text"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error":"only workspace admins can change custom fields"}`, w.Body.String())
+	})
+	mockFieldUsecase.AssertExpectations(t)
+}
+
+func TestCustomFieldController_GetFields(t *testing.T) {
+	mockFieldUsecase := new(mocks.CustomFieldUsecaseInterface)
+	router := setupCustomFieldRouter(mockFieldUsecase)
+
+	mockFieldUsecase.On("GetFields", "userID").Return(&[]domain.CustomField{{Key: "estimate", Name: "Estimate", Type: domain.FieldNumber}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/fields", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"type":"number"`)
+	mockFieldUsecase.AssertExpectations(t)
+}
+
+func TestCustomFieldController_RemoveField(t *testing.T) {
+	mockFieldUsecase := new(mocks.CustomFieldUsecaseInterface)
+	router := setupCustomFieldRouter(mockFieldUsecase)
+
+	mockFieldUsecase.On("RemoveField", "fieldID", "userID").Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/fields/fieldID", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"message":"field removed"}`, w.Body.String())
+	mockFieldUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"net/http"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectController struct {
+	usecase domain.ProjectUsecaseInterface
+	tasks   domain.TaskUsecaseInterface
+}
+
+func NewProjectController(usecase domain.ProjectUsecaseInterface, tasks domain.TaskUsecaseInterface) *ProjectController {
+	return &ProjectController{usecase: usecase, tasks: tasks}
+}
+
+// workspace is the usecase limited to the active workspace of the request.
+func (pc *ProjectController) workspace(ctx *gin.Context) (domain.ProjectUsecaseInterface, bool) {
+
+	usecase, err := pc.usecase.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return usecase, true
+}
+
+func (pc *ProjectController) CreateProject(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var newproject domain.Project
+
+	if err := ctx.ShouldBindJSON(&newproject); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := pc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.CreateProject(&newproject, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, newproject)
+}
+
+func (pc *ProjectController) GetProject(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := pc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	project, err := usecase.GetProject(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, project)
+}
+
+func (pc *ProjectController) GetProjects(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := pc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	projects, err := usecase.GetProjects(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, projects)
+}
+
+func (pc *ProjectController) UpdateProject(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var updatedproject domain.Project
+
+	if err := ctx.ShouldBindJSON(&updatedproject); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := pc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.UpdateProject(id, &updatedproject, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, updatedproject)
+}
+
+func (pc *ProjectController) RemoveProject(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := pc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.RemoveProject(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "project removed"})
+}
+
+func (pc *ProjectController) AddMember(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var body struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}
+
+	if err := ctx.ShouldBindJSON(&body); err != nil || body.Email == "" || body.Role == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "incomplete information"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := pc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	project, err := usecase.AddMember(id, body.Email, body.Role, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, project)
+}
+
+func (pc *ProjectController) RemoveMember(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	memberid := ctx.Param("memberid")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := pc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	project, err := usecase.RemoveMember(id, memberid, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, project)
+}
+
+func (pc *ProjectController) GetProjectTasks(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, err := pc.tasks.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tasks, err := usecase.GetProjectTasks(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, tasks)
+}
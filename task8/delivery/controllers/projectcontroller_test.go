@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupProjectRouter(usecase *mocks.ProjectUsecaseInterface, tasks *mocks.TaskUsecaseInterface) *gin.Engine {
+	usecase.On("InWorkspace", mock.Anything).Return(usecase, nil).Maybe()
+	tasks.On("InWorkspace", mock.Anything).Return(tasks, nil).Maybe()
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	projectController := NewProjectController(usecase, tasks)
+	router.POST("/projects", projectController.CreateProject)
+	router.GET("/projects/:id/tasks", projectController.GetProjectTasks)
+	router.POST("/projects/:id/members", projectController.AddMember)
+	return router
+}
+
+func TestProjectController_CreateProject(t *testing.T) {
+	mockProjectUsecase := new(mocks.ProjectUsecaseInterface)
+	router := setupProjectRouter(mockProjectUsecase, new(mocks.TaskUsecaseInterface))
+
+	mockProjectUsecase.On("CreateProject", mock.AnythingOfType("*domain.Project"), "userID").Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/projects", strings.NewReader(`{"name":"Website"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"Website"`)
+	mockProjectUsecase.AssertExpectations(t)
+}
+
+func TestProjectController_AddMember(t *testing.T) {
+	mockProjectUsecase := new(mocks.ProjectUsecaseInterface)
+	router := setupProjectRouter(mockProjectUsecase, new(mocks.TaskUsecaseInterface))
+
+	t.Run("successful add", func(t *testing.T) {
+		mockProjectUsecase.On("AddMember", "projectID", "new@example.com", "editor", "userID").Return(&domain.Project{Name: "Website"}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/projects/projectID/members", strings.NewReader(`{"email":"new@example.com","role":"editor"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockProjectUsecase.AssertExpectations(t)
+	})
+
+	t.Run("incomplete body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/projects/projectID/members", strings.NewReader(`{"email":"new@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestProjectController_GetProjectTasks(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupProjectRouter(new(mocks.ProjectUsecaseInterface), mockTaskUsecase)
+
+	mockTaskUsecase.On("GetProjectTasks", "projectID", "userID").Return(nil, errors.New("insufficient project permissions")).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/projects/projectID/tasks", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "insufficient project permissions")
+	mockTaskUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"net/http"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderController struct {
+	usecase domain.ReminderUsecaseInterface
+}
+
+func NewReminderController(usecase domain.ReminderUsecaseInterface) *ReminderController {
+	return &ReminderController{usecase: usecase}
+}
+
+// workspace is the usecase limited to the active workspace of the request.
+func (rc *ReminderController) workspace(ctx *gin.Context) (domain.ReminderUsecaseInterface, bool) {
+
+	usecase, err := rc.usecase.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return usecase, true
+}
+
+func (rc *ReminderController) CreateReminder(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var newreminder domain.Reminder
+
+	if err := ctx.BindJSON(&newreminder); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	taskid := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := rc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.CreateReminder(taskid, &newreminder, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, newreminder)
+}
+
+func (rc *ReminderController) GetReminders(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	taskid := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := rc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	reminders, err := usecase.GetReminders(taskid, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, reminders)
+}
+
+func (rc *ReminderController) RemoveReminder(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	taskid := ctx.Param("id")
+	id := ctx.Param("reminderid")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := rc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.RemoveReminder(taskid, id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "reminder removed"})
+}
+
+func (rc *ReminderController) GetNotifications(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	notifications, err := rc.usecase.GetNotifications(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, notifications)
+}
+
+func (rc *ReminderController) ReadNotification(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	err := rc.usecase.ReadNotification(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "notification read"})
+}
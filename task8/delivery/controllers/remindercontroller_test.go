@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReminderController(t *testing.T) {
+	mockReminderUsecase := new(mocks.ReminderUsecaseInterface)
+	mockReminderUsecase.On("InWorkspace", "").Return(mockReminderUsecase, nil).Maybe()
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	reminderController := NewReminderController(mockReminderUsecase)
+	router.POST("/tasks/:id/reminders", reminderController.CreateReminder)
+	router.GET("/notifications", reminderController.GetNotifications)
+
+	t.Run("create reminder", func(t *testing.T) {
+		mockReminderUsecase.On("CreateReminder", "taskID", mock.MatchedBy(func(r *domain.Reminder) bool {
+			return r.Before == "1h" && r.Channel == "in_app"
+		}), "userID").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tasks/taskID/reminders", strings.NewReader(`{"before":"1h","channel":"in_app"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("invalid reminder", func(t *testing.T) {
+		mockReminderUsecase.On("CreateReminder", "taskID", mock.AnythingOfType("*domain.Reminder"), "userID").Return(errors.New("task has no due date")).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tasks/taskID/reminders", strings.NewReader(`{"before":"1h","channel":"email"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "task has no due date")
+	})
+
+	t.Run("list notifications", func(t *testing.T) {
+		mockReminderUsecase.On("GetNotifications", "userID").Return(&[]domain.Notification{{Title: "Reminder: Report"}}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/notifications", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"title":"Reminder: Report"`)
+	})
+
+	mockReminderUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"task8/domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// heartbeatInterval keeps idle connections open through proxies.
+const heartbeatInterval = 15 * time.Second
+
+type StreamController struct {
+	usecase  domain.StreamUsecaseInterface
+	upgrader websocket.Upgrader
+}
+
+func NewStreamController(usecase domain.StreamUsecaseInterface) *StreamController {
+	return &StreamController{usecase: usecase}
+}
+
+// lastEventID reads the resume point: browsers send the Last-Event-ID header
+// when an EventSource reconnects, other clients may use ?last_event_id=.
+func lastEventID(ctx *gin.Context) string {
+	if id := ctx.GetHeader("Last-Event-ID"); id != "" {
+		return id
+	}
+	return ctx.Query("last_event_id")
+}
+
+// Events streams the caller's task changes as Server-Sent Events.
+func (sc *StreamController) Events(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	events, cancel, err := sc.usecase.Subscribe(userid, lastEventID(ctx))
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer cancel()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(ctx.Writer, ": ping\n\n")
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(ctx.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Event, data)
+		}
+		ctx.Writer.Flush()
+	}
+}
+
+// WebSocket streams the same events as Events, one JSON message per event.
+// The connection is server to client only; incoming messages are ignored.
+func (sc *StreamController) WebSocket(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	events, cancel, err := sc.usecase.Subscribe(userid, lastEventID(ctx))
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer cancel()
+
+	conn, err := sc.upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "fell behind, resume with last_event_id"), time.Now().Add(time.Second))
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupStreamRouter(usecase domain.StreamUsecaseInterface) *gin.Engine {
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	streamController := NewStreamController(usecase)
+	router.GET("/events", streamController.Events)
+	router.GET("/ws", streamController.WebSocket)
+	return router
+}
+
+func closedStream(events ...domain.StreamEvent) <-chan domain.StreamEvent {
+	ch := make(chan domain.StreamEvent, len(events))
+	for _, event := range events {
+		ch <- event
+	}
+	close(ch)
+	return ch
+}
+
+func TestStreamController_Events(t *testing.T) {
+	mockStreamUsecase := new(mocks.StreamUsecaseInterface)
+	router := setupStreamRouter(mockStreamUsecase)
+
+	event := domain.StreamEvent{ID: "8", Event: domain.EventTaskUpdated, Task: &domain.Task{Title: "Report"}}
+	mockStreamUsecase.On("Subscribe", "userID", "7").Return(closedStream(event), func() {}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/events", nil)
+	req.Header.Set("Last-Event-ID", "7")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "id: 8\nevent: task.updated\ndata: {")
+	assert.Contains(t, w.Body.String(), `"title":"Report"`)
+	mockStreamUsecase.AssertExpectations(t)
+}
+
+func TestStreamController_WebSocket(t *testing.T) {
+	mockStreamUsecase := new(mocks.StreamUsecaseInterface)
+	server := httptest.NewServer(setupStreamRouter(mockStreamUsecase))
+	defer server.Close()
+
+	event := domain.StreamEvent{ID: "3", Event: domain.EventTaskCreated, Task: &domain.Task{Title: "Report"}}
+	mockStreamUsecase.On("Subscribe", "userID", "2").Return(closedStream(event), func() {}, nil).Once()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws?last_event_id=2", nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	var received domain.StreamEvent
+	assert.NoError(t, conn.ReadJSON(&received))
+	assert.Equal(t, "3", received.ID)
+	assert.Equal(t, "Report", received.Task.Title)
+
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater))
+}
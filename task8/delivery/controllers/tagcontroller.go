@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"net/http"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagController struct {
+	usecase domain.TagUsecaseInterface
+}
+
+func NewTagController(usecase domain.TagUsecaseInterface) *TagController {
+	return &TagController{usecase: usecase}
+}
+
+func (tg *TagController) CreateTag(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var newtag domain.Tag
+
+	if err := ctx.ShouldBindJSON(&newtag); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	err := tg.usecase.CreateTag(&newtag, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, newtag)
+}
+
+func (tg *TagController) GetTags(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	tags, err := tg.usecase.GetTags(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, tags)
+}
+
+func (tg *TagController) UpdateTag(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var updatedtag domain.Tag
+
+	if err := ctx.ShouldBindJSON(&updatedtag); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	err := tg.usecase.UpdateTag(id, &updatedtag, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, updatedtag)
+}
+
+func (tg *TagController) RemoveTag(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	err := tg.usecase.RemoveTag(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "tag removed"})
+}
+
+func (tg *TagController) MergeTags(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var body struct {
+		Into string `json:"into"`
+	}
+
+	if err := ctx.ShouldBindJSON(&body); err != nil || body.Into == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "target tag id is required"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	err := tg.usecase.MergeTags(id, body.Into, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "tags merged"})
+}
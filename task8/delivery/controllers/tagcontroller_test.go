@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTagRouter(usecase domain.TagUsecaseInterface) *gin.Engine {
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	tagController := NewTagController(usecase)
+	router.GET("/tags", tagController.GetTags)
+	router.POST("/tags", tagController.CreateTag)
+	router.PUT("/tags/:id", tagController.UpdateTag)
+	router.DELETE("/tags/:id", tagController.RemoveTag)
+	router.POST("/tags/:id/merge", tagController.MergeTags)
+	return router
+}
+
+func TestTagController_CreateTag(t *testing.T) {
+	mockTagUsecase := new(mocks.TagUsecaseInterface)
+	router := setupTagRouter(mockTagUsecase)
+
+	t.Run("successful creation", func(t *testing.T) {
+		mockTagUsecase.On("CreateTag", mock.AnythingOfType("*domain.Tag"), "userID").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tags", strings.NewReader(`{"name":"urgent","color":"#ff0000"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"urgent"`)
+		mockTagUsecase.AssertExpectations(t)
+	})
+
+	t.Run("usecase error", func(t *testing.T) {
+		mockTagUsecase.On("CreateTag", mock.AnythingOfType("*domain.Tag"), "userID").Return(errors.New("tag already exists")).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tags", strings.NewReader(`{"name":"urgent"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "tag already exists")
+	})
+}
+
+func TestTagController_MergeTags(t *testing.T) {
+	mockTagUsecase := new(mocks.TagUsecaseInterface)
+	router := setupTagRouter(mockTagUsecase)
+
+	t.Run("successful merge", func(t *testing.T) {
+		mockTagUsecase.On("MergeTags", "sourceID", "targetID", "userID").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tags/sourceID/merge", strings.NewReader(`{"into":"targetID"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockTagUsecase.AssertExpectations(t)
+	})
+
+	t.Run("missing target", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tags/sourceID/merge", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
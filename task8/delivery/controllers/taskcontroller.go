@@ -1,12 +1,22 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"task8/domain"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	maxPageSize      = 500
+	totalCountHeader = "X-Total-Count"
+)
+
 type TaskController struct {
 	usecase domain.TaskUsecaseInterface
 }
@@ -15,6 +25,18 @@ func NewTaskController(usecase domain.TaskUsecaseInterface) *TaskController {
 	return &TaskController{usecase: usecase}
 }
 
+// workspace is the usecase limited to the active workspace of the request.
+// A malformed workspace ID is answered with 400 and false returned.
+func (tc *TaskController) workspace(ctx *gin.Context) (domain.TaskUsecaseInterface, bool) {
+
+	usecase, err := tc.usecase.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return usecase, true
+}
+
 func (tc *TaskController) CreateTask(ctx *gin.Context) {
 
 	role, exists := ctx.Get("role")
@@ -24,7 +46,7 @@ func (tc *TaskController) CreateTask(ctx *gin.Context) {
 	}
 	var newtask domain.Task
 
-	if err := ctx.BindJSON(&newtask); err != nil {
+	if err := bindTask(ctx, &newtask); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -39,7 +61,12 @@ func (tc *TaskController) CreateTask(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "user ID is not a valid string"})
 		return
 	}
-	err := tc.usecase.CreateTask(&newtask, useridstr)
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.CreateTask(&newtask, useridstr)
 
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -56,7 +83,13 @@ func (tc *TaskController) GetTask(ctx *gin.Context) {
 		return
 	}
 	id := ctx.Param("id")
-	task, err := tc.usecase.GetTask(id)
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	task, err := usecase.GetTask(id, userid)
 
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -77,14 +110,229 @@ func (tc *TaskController) GetTasks(ctx *gin.Context) {
 		return
 	}
 	userID := userid.(string)
-	tasks, err := tc.usecase.GetTasks(userID)
+
+	filter, err := taskFilterQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	var tasks *[]domain.Task
+
+	if isFiltered(filter) {
+		tasks, err = usecase.FilterTasks(userID, filter)
+	} else {
+		tasks, err = usecase.GetTasks(userID)
+	}
 
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if filter.Limit > 0 {
+		total, err := usecase.CountTasks(userID, filter)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Header(totalCountHeader, strconv.FormatInt(total, 10))
+	}
 	ctx.JSON(http.StatusOK, tasks)
 
+}
+func (tc *TaskController) GetAssignedTasks(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	tasks, err := usecase.GetAssignedTasks(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, tasks)
+
+}
+func (tc *TaskController) AssignTask(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var body struct {
+		AssigneeID string `json:"assignee_id"`
+	}
+
+	if err := ctx.BindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	task, err := usecase.AssignTask(id, body.AssigneeID, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, task)
+
+}
+
+// AddBlocker makes the task wait on the task in the body's blocker_id.
+func (tc *TaskController) AddBlocker(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var body struct {
+		BlockerID string `json:"blocker_id"`
+	}
+
+	if err := ctx.BindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	task, err := usecase.AddBlocker(id, body.BlockerID, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, task)
+
+}
+func (tc *TaskController) RemoveBlocker(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	blockerid := ctx.Param("blockerid")
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	task, err := usecase.RemoveBlocker(id, blockerid, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, task)
+
+}
+func (tc *TaskController) GetDependencyGraph(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	graph, err := usecase.GetDependencyGraph(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, graph)
+
+}
+
+// MoveTask places a card on the board, see domain.TaskMove.
+func (tc *TaskController) MoveTask(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var move domain.TaskMove
+
+	if err := ctx.BindJSON(&move); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	task, err := usecase.MoveTask(id, move, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, task)
+
+}
+
+// BulkTasks applies a batch of task operations, see domain.BulkRequest.
+// A batch that could be read is answered with 200 and a result per item,
+// whether the items succeeded or not.
+func (tc *TaskController) BulkTasks(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var request domain.BulkRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := usecase.BulkTasks(request, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+
 }
 func (tc *TaskController) UpdateTask(ctx *gin.Context) {
 	role, exists := ctx.Get("role")
@@ -97,12 +345,27 @@ func (tc *TaskController) UpdateTask(ctx *gin.Context) {
 
 	var updatedTask domain.Task
 
-	if err := ctx.BindJSON(&updatedTask); err != nil {
+	if err := bindTask(ctx, &updatedTask); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	err := tc.usecase.UpdateTask(id, &updatedTask)
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	var err error
+	switch ctx.DefaultQuery("scope", "this") {
+	case "this":
+		err = usecase.UpdateTask(id, &updatedTask, userid)
+	case "series":
+		err = usecase.UpdateSeries(id, &updatedTask, userid)
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "scope must be this or series"})
+		return
+	}
 
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -110,6 +373,33 @@ func (tc *TaskController) UpdateTask(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, updatedTask)
 
+}
+func (tc *TaskController) GetOccurrences(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	occurrences, err := usecase.GetOccurrences(id, userid, limit)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"occurrences": occurrences})
+
 }
 func (tc *TaskController) RemoveTask(ctx *gin.Context) {
 	role, exists := ctx.Get("role")
@@ -119,7 +409,13 @@ func (tc *TaskController) RemoveTask(ctx *gin.Context) {
 	}
 
 	id := ctx.Param("id")
-	err := tc.usecase.RemoveTask(id)
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.RemoveTask(id, userid)
 
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -128,3 +424,259 @@ func (tc *TaskController) RemoveTask(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "task removed"})
 
 }
+
+func (tc *TaskController) GetStats(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	filter, err := statsFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	stats, err := usecase.GetStats(userid, filter)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, stats)
+
+}
+func (tc *TaskController) GetTodayTasks(ctx *gin.Context) {
+	tc.getDueTasks(ctx, domain.DueToday)
+}
+
+func (tc *TaskController) GetUpcomingTasks(ctx *gin.Context) {
+	tc.getDueTasks(ctx, domain.DueUpcoming)
+}
+
+func (tc *TaskController) GetOverdueTasks(ctx *gin.Context) {
+	tc.getDueTasks(ctx, domain.DueOverdue)
+}
+
+func (tc *TaskController) getDueTasks(ctx *gin.Context, view string) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	days, err := strconv.Atoi(ctx.DefaultQuery("days", "0"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "days must be a number"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	tasks, err := usecase.GetDueTasks(userid, view, days)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, tasks)
+
+}
+
+// NextTasks ranks the user's open tasks by what to do next, explaining the
+// score of each.
+func (tc *TaskController) NextTasks(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "0"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a number"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	tasks, err := usecase.NextTasks(userid, limit)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, tasks)
+}
+
+func (tc *TaskController) GetAllStats(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "admin" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "admin-only"})
+		return
+	}
+	filter, err := statsFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.UserID = ctx.Query("user_id")
+	stats, err := tc.usecase.GetAllStats(filter)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, stats)
+
+}
+
+// statsFilter reads ?from=&to=&interval=, see dateRange.
+func statsFilter(ctx *gin.Context) (domain.StatsFilter, error) {
+
+	from, to, err := dateRange(ctx)
+	return domain.StatsFilter{From: from, To: to, Interval: ctx.Query("interval")}, err
+}
+
+// dateRange reads ?from=&to=. Dates are RFC 3339 timestamps or plain dates;
+// a plain "to" date includes that whole day. Missing bounds are zero.
+func dateRange(ctx *gin.Context) (time.Time, time.Time, error) {
+
+	var from, to time.Time
+	for _, bound := range []struct {
+		key    string
+		target *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := ctx.Query(bound.key)
+		if value == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			*bound.target = t
+			continue
+		}
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return from, to, fmt.Errorf("%s must be a date (YYYY-MM-DD) or an RFC 3339 time", bound.key)
+		}
+		if bound.key == "to" {
+			t = t.AddDate(0, 0, 1)
+		}
+		*bound.target = t
+	}
+	return from, to, nil
+}
+
+// taskFilterQuery reads the filter of GET /tasks, which takes the fields of
+// a saved view as ?status=, ?tag= with ?match=all, ?due_from=, ?due_to=,
+// ?assignee= and ?q=, and its page.
+func taskFilterQuery(ctx *gin.Context) (domain.TaskFilter, error) {
+	filter := domain.TaskFilter{
+		Statuses:   queryList(ctx, "status"),
+		Tags:       queryList(ctx, "tag"),
+		MatchAll:   ctx.Query("match") == "all",
+		DueFrom:    ctx.Query("due_from"),
+		DueTo:      ctx.Query("due_to"),
+		AssigneeID: ctx.Query("assignee"),
+		Text:       ctx.Query("q"),
+		Priorities: queryList(ctx, "priority"),
+		Fields:     fieldQuery(ctx),
+		Sort:       ctx.Query("sort"),
+	}
+	limit, offset, err := pageQuery(ctx)
+	filter.Limit = limit
+	filter.Offset = offset
+	return filter, err
+}
+
+// isFiltered tells whether a list asks for more than all of the tasks.
+func isFiltered(filter domain.TaskFilter) bool {
+	return len(filter.Statuses) > 0 || len(filter.Tags) > 0 || filter.DueFrom != "" || filter.DueTo != "" ||
+		filter.AssigneeID != "" || filter.Text != "" || len(filter.Priorities) > 0 || len(filter.Fields) > 0 || filter.Sort != "" || filter.Limit > 0
+}
+
+// fieldQuery reads the custom field filters of a task list, given as
+// field.<key>=value.
+func fieldQuery(ctx *gin.Context) map[string]string {
+	var fields map[string]string
+	for name, values := range ctx.Request.URL.Query() {
+		key, ok := strings.CutPrefix(name, "field.")
+		if !ok || key == "" || len(values) == 0 {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]string)
+		}
+		fields[key] = values[0]
+	}
+	return fields
+}
+
+// pageQuery reads the page of a list from ?limit= and ?offset=. A list
+// without a limit is not paged; a paged one has its full length in the
+// X-Total-Count header.
+func pageQuery(ctx *gin.Context) (int, int, error) {
+	limit, offset := 0, 0
+	var err error
+	if value := ctx.Query("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > maxPageSize {
+			return 0, 0, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+		}
+	}
+	if value := ctx.Query("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			return 0, 0, errors.New("offset must not be negative")
+		}
+	}
+	return limit, offset, nil
+}
+
+// queryList collects a repeatable query parameter, also splitting
+// comma separated values, so ?tag=a&tag=b and ?tag=a,b are equivalent.
+func queryList(ctx *gin.Context, key string) []string {
+	var values []string
+	for _, value := range ctx.QueryArray(key) {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
+}
+
+// taskBody is a task as clients send it, where duedate may also be words
+// such as "tomorrow 5pm" or "in 3 days".
+type taskBody struct {
+	domain.Task
+	DueDate string `json:"duedate"`
+}
+
+// bindTask reads a task from the request body. A duedate that is not an
+// RFC 3339 timestamp is left in DueText for the usecase to resolve.
+func bindTask(ctx *gin.Context, task *domain.Task) error {
+
+	var body taskBody
+	if err := ctx.BindJSON(&body); err != nil {
+		return err
+	}
+	*task = body.Task
+	if body.DueDate == "" {
+		return nil
+	}
+	if due, err := time.Parse(time.RFC3339, body.DueDate); err == nil {
+		task.DueDate = due
+	} else {
+		task.DueText = body.DueDate
+	}
+	return nil
+}
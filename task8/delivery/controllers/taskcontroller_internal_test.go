@@ -0,0 +1,403 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// setupTaskRouter serves the task routes from usecase, which also stands
+// for itself limited to any workspace.
+func setupTaskRouter(usecase *mocks.TaskUsecaseInterface) *gin.Engine {
+	usecase.On("InWorkspace", mock.Anything).Return(usecase, nil).Maybe()
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	taskController := NewTaskController(usecase)
+	router.GET("/tasks", taskController.GetTasks)
+	router.GET("/tasks/assigned-to-me", taskController.GetAssignedTasks)
+	router.GET("/tasks/stats", taskController.GetStats)
+	router.GET("/tasks/today", taskController.GetTodayTasks)
+	router.GET("/tasks/upcoming", taskController.GetUpcomingTasks)
+	router.GET("/tasks/overdue", taskController.GetOverdueTasks)
+	router.GET("/tasks/next", taskController.NextTasks)
+	router.GET("/admin/stats", taskController.GetAllStats)
+	router.POST("/tasks", taskController.CreateTask)
+	router.GET("/tasks/:id", taskController.GetTask)
+	router.PUT("/tasks/:id", taskController.UpdateTask)
+	router.PUT("/tasks/:id/assignee", taskController.AssignTask)
+	router.POST("/tasks/bulk", taskController.BulkTasks)
+	router.GET("/tasks/export", taskController.ExportTasks)
+	router.POST("/tasks/import", taskController.ImportTasks)
+	router.POST("/tasks/:id/move", taskController.MoveTask)
+	router.POST("/tasks/:id/dependencies", taskController.AddBlocker)
+	router.GET("/tasks/:id/graph", taskController.GetDependencyGraph)
+	router.GET("/tasks/:id/occurrences", taskController.GetOccurrences)
+	return router
+}
+
+func TestTaskController_GetTasksByTag(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	filter := domain.TaskFilter{Tags: []string{"urgent", "client-x"}, MatchAll: true}
+	mockTaskUsecase.On("FilterTasks", "userID", filter).Return(&[]domain.Task{{Title: "Task 1"}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks?tag=urgent,client-x&match=all", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"title":"Task 1"`)
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_DueDateInWords(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+	resolved := time.Date(2024, 3, 14, 17, 0, 0, 0, time.FixedZone("CET", 60*60))
+
+	t.Run("words are resolved by the usecase", func(t *testing.T) {
+		mockTaskUsecase.On("CreateTask", mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Title == "Call" && task.DueText == "tomorrow 5pm" && task.DueDate.IsZero()
+		}), "userID").Run(func(args mock.Arguments) {
+			task := args.Get(0).(*domain.Task)
+			task.DueDate, task.DueText = resolved, ""
+		}).Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tasks", strings.NewReader(`{"title":"Call","description":"Call","status":"todo","duedate":"tomorrow 5pm"}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"duedate":"2024-03-14T17:00:00+01:00"`)
+	})
+
+	t.Run("timestamps are taken as they are", func(t *testing.T) {
+		mockTaskUsecase.On("UpdateTask", "taskID", mock.MatchedBy(func(task *domain.Task) bool {
+			return task.DueText == "" && task.DueDate.Equal(resolved)
+		}), "userID").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/tasks/taskID", strings.NewReader(`{"title":"Call","description":"Call","status":"todo","duedate":"2024-03-14T16:00:00Z"}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_GetAssignedTasks(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	mockTaskUsecase.On("GetAssignedTasks", "userID").Return(&[]domain.Task{{Title: "Assigned"}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks/assigned-to-me", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"title":"Assigned"`)
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_MalformedWorkspace(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	mockTaskUsecase.On("InWorkspace", "not-an-id").Return(nil, errors.New("workspace ID is not a valid ObjectID")).Once()
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Set("workspace_id", "not-an-id")
+		ctx.Next()
+	})
+	router.GET("/tasks", NewTaskController(mockTaskUsecase).GetTasks)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "workspace ID is not a valid ObjectID")
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_AssignTask(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	mockTaskUsecase.On("AssignTask", "taskID", "assigneeID", "userID").Return(&domain.Task{Title: "Task 1"}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/tasks/taskID/assignee", strings.NewReader(`{"assignee_id":"assigneeID"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_MoveTask(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	move := domain.TaskMove{Status: "doing", After: "aboveID", Before: "belowID"}
+	mockTaskUsecase.On("MoveTask", "taskID", move, "userID").Return(&domain.Task{Status: "doing", Rank: 1536}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/tasks/taskID/move", strings.NewReader(`{"status":"doing","after":"aboveID","before":"belowID"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"rank":1536`)
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_BulkTasks(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	request := domain.BulkRequest{Atomic: true, Operations: []domain.BulkOperation{{Op: "delete", ID: "taskID"}}}
+	response := &domain.BulkResponse{Atomic: true, Results: []domain.BulkResult{{Op: "delete", ID: "taskID", Status: domain.BulkFailed, Error: "task not found"}}}
+	mockTaskUsecase.On("BulkTasks", request, "userID").Return(response, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/tasks/bulk", strings.NewReader(`{"atomic":true,"operations":[{"op":"delete","id":"taskID"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"failed"`)
+
+	mockTaskUsecase.On("BulkTasks", domain.BulkRequest{}, "userID").Return(nil, errors.New("no operations given")).Once()
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/tasks/bulk", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_Dependencies(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	mockTaskUsecase.On("AddBlocker", "taskID", "blockerID", "userID").Return(nil, errors.New("the dependency would create a cycle")).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/tasks/taskID/dependencies", strings.NewReader(`{"blocker_id":"blockerID"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "cycle")
+
+	mockTaskUsecase.On("GetDependencyGraph", "taskID", "userID").Return(&domain.TaskGraph{Nodes: []domain.GraphNode{{Title: "Build"}}, Edges: []domain.GraphEdge{}}, nil).Once()
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/taskID/graph", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"title":"Build"`)
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_UpdateSeries(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	mockTaskUsecase.On("UpdateSeries", "taskID", mock.AnythingOfType("*domain.Task"), "userID").Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/tasks/taskID?scope=series", strings.NewReader(`{"title":"Series","description":"All of them"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_GetOccurrences(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	due := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	mockTaskUsecase.On("GetOccurrences", "taskID", "userID", 2).Return([]time.Time{due, due.AddDate(0, 0, 1)}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks/taskID/occurrences?limit=2", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"2024-01-02T09:00:00Z"`)
+	mockTaskUsecase.AssertExpectations(t)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/taskID/occurrences?limit=0", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTaskController_GetDueTasks(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	mockTaskUsecase.On("GetDueTasks", "userID", domain.DueToday, 0).Return(&[]domain.Task{{Title: "Call", DueDate: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), DueDateOnly: true}}, nil).Once()
+	mockTaskUsecase.On("GetDueTasks", "userID", domain.DueUpcoming, 14).Return(&[]domain.Task{}, nil).Once()
+	mockTaskUsecase.On("GetDueTasks", "userID", domain.DueOverdue, 0).Return(nil, errors.New("database down")).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks/today", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"due_date_only":true`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/upcoming?days=14", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]", w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/upcoming?days=fortnight", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "days must be a number")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/overdue", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_NextTasks(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	next := []domain.NextTask{{
+		Task:    domain.Task{Title: "Call", Priority: domain.PriorityHigh},
+		Score:   2,
+		Factors: []domain.ScoreFactor{{Factor: domain.FactorPriority, Value: 0.67, Weight: 3, Points: 2, Reason: "priority high"}},
+	}}
+	mockTaskUsecase.On("NextTasks", "userID", 5).Return(&next, nil).Once()
+	mockTaskUsecase.On("NextTasks", "userID", 500).Return(nil, errors.New("limit must be between 1 and 100")).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks/next?limit=5", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"priority":"high"`)
+	assert.Contains(t, w.Body.String(), `"reason":"priority high"`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/next?limit=few", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "limit must be a number")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/next?limit=500", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_GetStats(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	filter := domain.StatsFilter{
+		From:     time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		Interval: "week",
+	}
+	mockTaskUsecase.On("GetStats", "userID", filter).Return(&domain.TaskStats{Total: 4, ByStatus: map[string]int{"done": 4}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks/stats?from=2024-03-01&to=2024-03-31&interval=week", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":4`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/stats?from=March", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "from must be a date")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/admin/stats", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_GetTasksPaged(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	t.Run("filters a page", func(t *testing.T) {
+		filter := domain.TaskFilter{Statuses: []string{"pending", "in progress"}, DueTo: "friday", AssigneeID: "me", Text: "invoice", Limit: 2, Offset: 4}
+		mockTaskUsecase.On("FilterTasks", "userID", filter).Return(&[]domain.Task{{Title: "Task 5"}, {Title: "Task 6"}}, nil).Once()
+		mockTaskUsecase.On("CountTasks", "userID", filter).Return(int64(9), nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tasks?status=pending,in+progress&due_to=friday&assignee=me&q=invoice&limit=2&offset=4", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "9", w.Header().Get("X-Total-Count"))
+		assert.Contains(t, w.Body.String(), `"title":"Task 6"`)
+	})
+
+	t.Run("by custom fields", func(t *testing.T) {
+		filter := domain.TaskFilter{Fields: map[string]string{"customer": "Acme", "sprint": "25"}, Sort: "-field.estimate"}
+		mockTaskUsecase.On("FilterTasks", "userID", filter).Return(&[]domain.Task{{Title: "Task 1"}}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tasks?field.customer=Acme&field.sprint=25&sort=-field.estimate", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("limit out of range", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tasks?limit=501", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error":"limit must be between 1 and 500"}`, w.Body.String())
+	})
+	mockTaskUsecase.AssertExpectations(t)
+}
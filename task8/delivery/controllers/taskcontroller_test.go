@@ -39,11 +39,13 @@ func setupRouter(taskUsecase domain.TaskUsecaseInterface) *gin.Engine {
 	})
 
 	router.GET("/tasks/:id", func(ctx *gin.Context) {
-		// Simulate user role for testing
+		// Simulate user role and user ID for testing
 		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
 
 		taskID := ctx.Param("id")
-		task, err := taskUsecase.GetTask(taskID)
+		userID := ctx.GetString("user_id")
+		task, err := taskUsecase.GetTask(taskID, userID)
 		if err != nil {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
@@ -79,8 +81,8 @@ func setupRouter(taskUsecase domain.TaskUsecaseInterface) *gin.Engine {
 		}
 
 		taskID := ctx.Param("id")
-		// userID := ctx.GetString("user_id")
-		err := taskUsecase.UpdateTask(taskID, &updatedTask)
+		userID := ctx.GetString("user_id")
+		err := taskUsecase.UpdateTask(taskID, &updatedTask, userID)
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
@@ -95,7 +97,8 @@ func setupRouter(taskUsecase domain.TaskUsecaseInterface) *gin.Engine {
 		ctx.Set("user_id", "userID")
 
 		taskID := ctx.Param("id")
-		err := taskUsecase.RemoveTask(taskID)
+		userID := ctx.GetString("user_id")
+		err := taskUsecase.RemoveTask(taskID, userID)
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
@@ -160,7 +163,7 @@ func TestGetTask(t *testing.T) {
 	router := setupRouter(mockUsecase)
 
 	t.Run("success", func(t *testing.T) {
-		mockUsecase.On("GetTask", "taskID").Return(&domain.Task{Title: "Task Title", Description: "Task Description"}, nil).Once()
+		mockUsecase.On("GetTask", "taskID", "userID").Return(&domain.Task{Title: "Task Title", Description: "Task Description"}, nil).Once()
 
 		req, _ := http.NewRequest("GET", "/tasks/taskID", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -174,7 +177,7 @@ func TestGetTask(t *testing.T) {
 	})
 
 	t.Run("usecase error", func(t *testing.T) {
-		mockUsecase.On("GetTask", "taskID").Return(nil, errors.New("task not found")).Once()
+		mockUsecase.On("GetTask", "taskID", "userID").Return(nil, errors.New("task not found")).Once()
 
 		req, _ := http.NewRequest("GET", "/tasks/taskID", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -232,7 +235,7 @@ func TestUpdateTask(t *testing.T) {
 	router := setupRouter(mockUsecase)
 
 	t.Run("success", func(t *testing.T) {
-		mockUsecase.On("UpdateTask", "taskID", mock.AnythingOfType("*domain.Task")).Return(nil).Once()
+		mockUsecase.On("UpdateTask", "taskID", mock.AnythingOfType("*domain.Task"), "userID").Return(nil).Once()
 
 		reqBody := `{"title": "Updated Task", "description": "Updated Description"}`
 		req, _ := http.NewRequest("PUT", "/tasks/taskID", strings.NewReader(reqBody))
@@ -258,7 +261,7 @@ func TestUpdateTask(t *testing.T) {
 	})
 
 	t.Run("usecase error", func(t *testing.T) {
-		mockUsecase.On("UpdateTask", "taskID", mock.AnythingOfType("*domain.Task")).Return(errors.New("usecase error")).Once()
+		mockUsecase.On("UpdateTask", "taskID", mock.AnythingOfType("*domain.Task"), "userID").Return(errors.New("usecase error")).Once()
 
 		reqBody := `{"title": "Updated Task", "description": "Updated Description"}`
 		req, _ := http.NewRequest("PUT", "/tasks/taskID", strings.NewReader(reqBody))
@@ -279,7 +282,7 @@ func TestRemoveTask(t *testing.T) {
 	router := setupRouter(mockUsecase)
 
 	t.Run("success", func(t *testing.T) {
-		mockUsecase.On("RemoveTask", "taskID").Return(nil).Once()
+		mockUsecase.On("RemoveTask", "taskID", "userID").Return(nil).Once()
 
 		req, _ := http.NewRequest("DELETE", "/tasks/taskID", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -292,7 +295,7 @@ func TestRemoveTask(t *testing.T) {
 	})
 
 	t.Run("usecase error", func(t *testing.T) {
-		mockUsecase.On("RemoveTask", "taskID").Return(errors.New("usecase error")).Once()
+		mockUsecase.On("RemoveTask", "taskID", "userID").Return(errors.New("usecase error")).Once()
 
 		req, _ := http.NewRequest("DELETE", "/tasks/taskID", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -0,0 +1,391 @@
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"task8/domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxImportSize = 10 << 20
+
+// taskColumns are the columns of a CSV export, in order. An import reads
+// them by name and ignores the ones it does not know.
+var taskColumns = []string{"external_id", "id", "title", "description", "status", "priority", "duedate", "tags", "project_id", "assignee_id", "watchers", "rrule", "created_at", "completed_at"}
+
+// ExportTasks streams every task of the user as CSV, a JSON array or
+// newline delimited JSON, one task at a time.
+func (tc *TaskController) ExportTasks(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	format := ctx.DefaultQuery("format", domain.FormatJSON)
+	encoder, contentType, err := newTaskEncoder(format, ctx.Writer)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filename := fmt.Sprintf("tasks-%s.%s", time.Now().UTC().Format("20060102"), format)
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err = usecase.ExportTasks(userid, encoder.Encode)
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		// Once the body has started the status is sent; cutting the
+		// download short is all that is left.
+		if !ctx.Writer.Written() {
+			ctx.Header("Content-Type", "")
+			ctx.Header("Content-Disposition", "")
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Error(err)
+		ctx.Abort()
+	}
+
+}
+
+// ImportTasks reads tasks in the format given by ?format=, or else by the
+// content type, and imports them. With ?dry_run=true nothing is written.
+// A file that could be read is answered with 200 and a result per row.
+func (tc *TaskController) ImportTasks(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	format := ctx.Query("format")
+	if format == "" {
+		format = importFormat(ctx.ContentType())
+	}
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+	records, err := decodeTasks(format, body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := usecase.ImportTasks(records, userid, ctx.Query("dry_run") == "true")
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+
+}
+
+func importFormat(contentType string) string {
+	switch contentType {
+	case "text/csv":
+		return domain.FormatCSV
+	case "application/x-ndjson", "application/ndjson":
+		return domain.FormatNDJSON
+	}
+	return domain.FormatJSON
+}
+
+type taskEncoder interface {
+	Encode(task *domain.Task) error
+	Close() error
+}
+
+func newTaskEncoder(format string, w io.Writer) (taskEncoder, string, error) {
+	switch format {
+	case domain.FormatCSV:
+		return &csvTaskEncoder{writer: csv.NewWriter(w)}, "text/csv; charset=utf-8", nil
+	case domain.FormatJSON:
+		return &jsonTaskEncoder{writer: w}, "application/json; charset=utf-8", nil
+	case domain.FormatNDJSON:
+		return &ndjsonTaskEncoder{encoder: json.NewEncoder(w)}, "application/x-ndjson", nil
+	}
+	return nil, "", errors.New("format must be csv, json or ndjson")
+}
+
+// csvTaskEncoder writes the header with the first task, so that an export
+// failing before it can still be answered with an error.
+type csvTaskEncoder struct {
+	writer  *csv.Writer
+	started bool
+}
+
+func (e *csvTaskEncoder) Encode(task *domain.Task) error {
+	if !e.started {
+		e.started = true
+		if err := e.writer.Write(taskColumns); err != nil {
+			return err
+		}
+	}
+	return e.writer.Write([]string{
+		task.ExternalID,
+		task.ID.Hex(),
+		task.Title,
+		task.Description,
+		task.Status,
+		task.Priority,
+		formatDue(task),
+		strings.Join(task.Tags, ";"),
+		formatObjectID(task.ProjectID),
+		formatObjectID(task.AssigneeID),
+		formatObjectIDs(task.Watchers),
+		task.RRule,
+		formatTime(task.CreatedAt),
+		formatTime(task.CompletedAt),
+	})
+}
+
+func (e *csvTaskEncoder) Close() error {
+	if !e.started {
+		e.started = true
+		e.writer.Write(taskColumns)
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type jsonTaskEncoder struct {
+	writer  io.Writer
+	started bool
+}
+
+func (e *jsonTaskEncoder) Encode(task *domain.Task) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	separator := ","
+	if !e.started {
+		e.started = true
+		separator = "["
+	}
+	_, err = e.writer.Write(append([]byte(separator), data...))
+	return err
+}
+
+func (e *jsonTaskEncoder) Close() error {
+	closing := "]"
+	if !e.started {
+		closing = "[]"
+	}
+	_, err := io.WriteString(e.writer, closing)
+	return err
+}
+
+type ndjsonTaskEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonTaskEncoder) Encode(task *domain.Task) error {
+	return e.encoder.Encode(task)
+}
+
+func (e *ndjsonTaskEncoder) Close() error {
+	return nil
+}
+
+// decodeTasks reads an import file. A row that cannot be read becomes a
+// record with an error; only a file that cannot be read at all fails.
+func decodeTasks(format string, r io.Reader) ([]domain.ImportRecord, error) {
+	switch format {
+	case domain.FormatCSV:
+		return decodeCSVTasks(r)
+	case domain.FormatJSON:
+		return decodeJSONTasks(r)
+	case domain.FormatNDJSON:
+		return decodeNDJSONTasks(r)
+	}
+	return nil, errors.New("format must be csv, json or ndjson")
+}
+
+func decodeCSVTasks(r io.Reader) ([]domain.ImportRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("the header has no title column")
+	}
+
+	records := []domain.ImportRecord{}
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		value := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		record := domain.ImportRecord{Row: len(records) + 1}
+		record.Task, err = csvTask(value)
+		if err != nil {
+			record.Error = err.Error()
+		}
+		records = append(records, record)
+	}
+}
+
+func csvTask(value func(string) string) (domain.Task, error) {
+	task := domain.Task{
+		ExternalID:  value("external_id"),
+		Title:       value("title"),
+		Description: value("description"),
+		Status:      value("status"),
+		Priority:    value("priority"),
+		RRule:       value("rrule"),
+	}
+	if tags := value("tags"); tags != "" {
+		task.Tags = strings.Split(tags, ";")
+	}
+	var err error
+	if task.DueDate, err = parseTime(value("duedate")); err != nil {
+		return task, fmt.Errorf("duedate: %v", err)
+	}
+	task.DueDateOnly = len(value("duedate")) == len("2006-01-02")
+	if task.ProjectID, err = parseObjectID(value("project_id")); err != nil {
+		return task, fmt.Errorf("project_id: %v", err)
+	}
+	if task.AssigneeID, err = parseObjectID(value("assignee_id")); err != nil {
+		return task, fmt.Errorf("assignee_id: %v", err)
+	}
+	if watchers := value("watchers"); watchers != "" {
+		for _, hex := range strings.Split(watchers, ";") {
+			id, err := parseObjectID(strings.TrimSpace(hex))
+			if err != nil {
+				return task, fmt.Errorf("watchers: %v", err)
+			}
+			task.Watchers = append(task.Watchers, id)
+		}
+	}
+	return task, nil
+}
+
+func decodeJSONTasks(r io.Reader) ([]domain.ImportRecord, error) {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, errors.New("a JSON import must be an array of tasks")
+	}
+	records := []domain.ImportRecord{}
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, err
+		}
+		records = append(records, jsonRecord(len(records)+1, raw))
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func decodeNDJSONTasks(r io.Reader) ([]domain.ImportRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	records := []domain.ImportRecord{}
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		records = append(records, jsonRecord(len(records)+1, line))
+	}
+	return records, scanner.Err()
+}
+
+func jsonRecord(row int, data []byte) domain.ImportRecord {
+	record := domain.ImportRecord{Row: row}
+	if err := json.Unmarshal(data, &record.Task); err != nil {
+		record.Error = err.Error()
+	}
+	return record
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatDue writes a date-only due date as a plain date, which parseTime
+// reads back as one.
+func formatDue(task *domain.Task) string {
+	if task.DueDateOnly && !task.DueDate.IsZero() {
+		return task.DueDate.UTC().Format("2006-01-02")
+	}
+	return formatTime(task.DueDate)
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func formatObjectID(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.Hex()
+}
+
+func formatObjectIDs(ids []primitive.ObjectID) string {
+	hexes := make([]string, len(ids))
+	for i, id := range ids {
+		hexes[i] = id.Hex()
+	}
+	return strings.Join(hexes, ";")
+}
+
+func parseObjectID(value string) (primitive.ObjectID, error) {
+	if value == "" {
+		return primitive.NilObjectID, nil
+	}
+	return primitive.ObjectIDFromHex(value)
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskController_ExportTasks(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+	task := &domain.Task{
+		ID:         primitive.NewObjectID(),
+		ExternalID: "jira-1",
+		Title:      "Ship, then rest",
+		Status:     "todo",
+		Priority:   "high",
+		Tags:       []string{"ops", "release"},
+		DueDate:    time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+	}
+	stream := func(userid string, each func(*domain.Task) error) error {
+		return each(task)
+	}
+
+	mockTaskUsecase.On("ExportTasks", "userID", mock.Anything).Return(stream).Times(3)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks/export?format=csv", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Equal(t, strings.Join(taskColumns, ","), lines[0])
+	assert.Equal(t, `jira-1,`+task.ID.Hex()+`,"Ship, then rest",,todo,high,2024-03-04T09:00:00Z,ops;release,,,,,,`, lines[1])
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/export", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(w.Body.String(), `[{"_id":"`+task.ID.Hex()))
+	assert.True(t, strings.HasSuffix(w.Body.String(), `}]`))
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/export?format=ndjson", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 1, strings.Count(w.Body.String(), "\n"))
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/export?format=xml", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockTaskUsecase.On("ExportTasks", "userID", mock.Anything).Return(errors.New("database down")).Once()
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/tasks/export?format=csv", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	mockTaskUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_ImportTasks(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupTaskRouter(mockTaskUsecase)
+
+	body := "external_id,title,description,status,duedate,tags,unknown\n" +
+		"jira-1,Ship,Release it,todo,2024-03-04,ops;release,x\n" +
+		"jira-2,Rest,,todo,next week\n"
+	records := []domain.ImportRecord{
+		{Row: 1, Task: domain.Task{ExternalID: "jira-1", Title: "Ship", Description: "Release it", Status: "todo", DueDate: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), DueDateOnly: true, Tags: []string{"ops", "release"}}},
+		{Row: 2, Task: domain.Task{ExternalID: "jira-2", Title: "Rest", Status: "todo"}, Error: `duedate: parsing time "next week" as "2006-01-02": cannot parse "next week" as "2006"`},
+	}
+	mockTaskUsecase.On("ImportTasks", records, "userID", true).Return(&domain.ImportResult{DryRun: true, Created: 1, Failed: 1}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/tasks/import?dry_run=true", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"dry_run":true`)
+
+	ndjson := `{"external_id":"a","title":"One"}` + "\n\n" + `{"external_id":` + "\n"
+	mockTaskUsecase.On("ImportTasks", mock.MatchedBy(func(records []domain.ImportRecord) bool {
+		return len(records) == 2 && records[0].Task.Title == "One" && records[0].Error == "" && records[1].Row == 2 && records[1].Error != ""
+	}), "userID", false).Return(&domain.ImportResult{}, nil).Once()
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/tasks/import?format=ndjson", strings.NewReader(ndjson))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/tasks/import", strings.NewReader(`{"title":"not an array"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "array")
+	mockTaskUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TemplateController struct {
+	usecase domain.TemplateUsecaseInterface
+}
+
+func NewTemplateController(usecase domain.TemplateUsecaseInterface) *TemplateController {
+	return &TemplateController{usecase: usecase}
+}
+
+// workspace is the usecase limited to the active workspace of the request.
+func (tp *TemplateController) workspace(ctx *gin.Context) (domain.TemplateUsecaseInterface, bool) {
+
+	usecase, err := tp.usecase.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return usecase, true
+}
+
+func (tp *TemplateController) CreateTemplate(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var newtemplate domain.Template
+
+	if err := ctx.ShouldBindJSON(&newtemplate); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tp.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.CreateTemplate(&newtemplate, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, newtemplate)
+}
+
+func (tp *TemplateController) GetTemplate(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tp.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	template, err := usecase.GetTemplate(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, template)
+}
+
+func (tp *TemplateController) GetTemplates(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tp.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	templates, err := usecase.GetTemplates(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, templates)
+}
+
+func (tp *TemplateController) UpdateTemplate(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var updatedtemplate domain.Template
+
+	if err := ctx.ShouldBindJSON(&updatedtemplate); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tp.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.UpdateTemplate(id, &updatedtemplate, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, updatedtemplate)
+}
+
+func (tp *TemplateController) RemoveTemplate(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tp.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.RemoveTemplate(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "template removed"})
+}
+
+// Instantiate creates the tasks of a template. The body, with the
+// variables, the start day and the project, may be left out.
+func (tp *TemplateController) Instantiate(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var instance domain.TemplateInstance
+
+	if err := ctx.ShouldBindJSON(&instance); err != nil && err != io.EOF {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tp.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := usecase.Instantiate(id, instance, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, result)
+}
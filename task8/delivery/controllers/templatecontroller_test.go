@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTemplateRouter(usecase *mocks.TemplateUsecaseInterface) *gin.Engine {
+	usecase.On("InWorkspace", mock.Anything).Return(usecase, nil).Maybe()
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	templateController := NewTemplateController(usecase)
+	router.POST("/templates", templateController.CreateTemplate)
+	router.POST("/templates/:id/instantiate", templateController.Instantiate)
+	return router
+}
+
+func TestTemplateController_CreateTemplate(t *testing.T) {
+	mockTemplateUsecase := new(mocks.TemplateUsecaseInterface)
+	router := setupTemplateRouter(mockTemplateUsecase)
+
+	mockTemplateUsecase.On("CreateTemplate", mock.MatchedBy(func(template *domain.Template) bool {
+		return template.Title == "Onboard {{name}}" && len(template.Checklist) == 1 && template.Checklist[0].DueOffset == "+3d"
+	}), "userID").Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/templates", strings.NewReader(`{"name":"Onboarding","title":"Onboard {{name}}","description":"Welcome","checklist":[{"title":"Laptop","due_offset":"+3d"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockTemplateUsecase.AssertExpectations(t)
+}
+
+func TestTemplateController_Instantiate(t *testing.T) {
+	mockTemplateUsecase := new(mocks.TemplateUsecaseInterface)
+	router := setupTemplateRouter(mockTemplateUsecase)
+
+	t.Run("with variables", func(t *testing.T) {
+		mockTemplateUsecase.On("Instantiate", "templateID", domain.TemplateInstance{Variables: map[string]string{"name": "Ada"}, Start: "monday"}, "userID").
+			Return(&domain.TemplateResult{Task: &domain.Task{Title: "Onboard Ada"}, Checklist: []domain.Task{}}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/templates/templateID/instantiate", strings.NewReader(`{"variables":{"name":"Ada"},"start":"monday"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"title":"Onboard Ada"`)
+	})
+
+	t.Run("without a body", func(t *testing.T) {
+		mockTemplateUsecase.On("Instantiate", "templateID", domain.TemplateInstance{}, "userID").Return(nil, errors.New("missing variables: name")).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/templates/templateID/instantiate", strings.NewReader(""))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error":"missing variables: name"}`, w.Body.String())
+	})
+	mockTemplateUsecase.AssertExpectations(t)
+}
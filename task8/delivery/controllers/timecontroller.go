@@ -0,0 +1,224 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimeController struct {
+	usecase domain.TimeUsecaseInterface
+}
+
+func NewTimeController(usecase domain.TimeUsecaseInterface) *TimeController {
+	return &TimeController{usecase: usecase}
+}
+
+// workspace is the usecase limited to the active workspace of the request.
+func (tc *TimeController) workspace(ctx *gin.Context) (domain.TimeUsecaseInterface, bool) {
+
+	usecase, err := tc.usecase.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return usecase, true
+}
+
+func (tc *TimeController) StartTimer(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	taskid := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	entry, err := usecase.StartTimer(taskid, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, entry)
+}
+
+func (tc *TimeController) StopTimer(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	taskid := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	entry, err := usecase.StopTimer(taskid, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, entry)
+}
+
+// GetRunningTimer answers 204 No Content when no timer is running.
+func (tc *TimeController) GetRunningTimer(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	entry, err := tc.usecase.GetRunningTimer(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if entry == nil {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+	ctx.JSON(http.StatusOK, entry)
+}
+
+func (tc *TimeController) CreateTimeEntry(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var newentry domain.TimeEntry
+
+	if err := ctx.BindJSON(&newentry); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	taskid := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.CreateTimeEntry(taskid, &newentry, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, newentry)
+}
+
+func (tc *TimeController) GetTimeEntries(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	taskid := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	entries, err := usecase.GetTimeEntries(taskid, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, entries)
+}
+
+func (tc *TimeController) RemoveTimeEntry(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	taskid := ctx.Param("id")
+	id := ctx.Param("entryid")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.RemoveTimeEntry(taskid, id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "time entry removed"})
+}
+
+// GetTimesheet returns the time per task and per day as JSON, or as a CSV
+// download with one line per day and task when ?format=csv.
+func (tc *TimeController) GetTimesheet(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	from, to, err := dateRange(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := tc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	timesheet, err := usecase.GetTimesheet(userid, from, to)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if ctx.Query("format") != "csv" {
+		ctx.JSON(http.StatusOK, timesheet)
+		return
+	}
+
+	filename := fmt.Sprintf("timesheet-%s-%s.csv", timesheet.From.Format("20060102"), timesheet.To.Format("20060102"))
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Status(http.StatusOK)
+
+	writer := csv.NewWriter(ctx.Writer)
+	writer.Write([]string{"date", "task_id", "task", "seconds", "hours"})
+	for _, row := range timesheet.Days {
+		writer.Write([]string{
+			row.Day,
+			row.TaskID.Hex(),
+			row.Title,
+			strconv.FormatInt(row.Seconds, 10),
+			strconv.FormatFloat(float64(row.Seconds)/3600, 'f', 2, 64),
+		})
+	}
+	writer.Flush()
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTimeController(t *testing.T) {
+	mockTimeUsecase := new(mocks.TimeUsecaseInterface)
+	mockTimeUsecase.On("InWorkspace", "").Return(mockTimeUsecase, nil).Maybe()
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	timeController := NewTimeController(mockTimeUsecase)
+	router.POST("/tasks/:id/timer/start", timeController.StartTimer)
+	router.GET("/timer", timeController.GetRunningTimer)
+	router.GET("/timesheet", timeController.GetTimesheet)
+
+	t.Run("start timer", func(t *testing.T) {
+		mockTimeUsecase.On("StartTimer", "taskID", "userID").Return(&domain.TimeEntry{Running: true}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tasks/taskID/timer/start", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("timer already running", func(t *testing.T) {
+		mockTimeUsecase.On("StartTimer", "taskID", "userID").Return(nil, errors.New("another timer is already running, stop it first")).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/tasks/taskID/timer/start", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "already running")
+	})
+
+	t.Run("no running timer", func(t *testing.T) {
+		mockTimeUsecase.On("GetRunningTimer", "userID").Return(nil, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/timer", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("timesheet as CSV", func(t *testing.T) {
+		from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC)
+		taskID := primitive.NewObjectID()
+		mockTimeUsecase.On("GetTimesheet", "userID", from, to).Return(&domain.Timesheet{
+			From: from,
+			To:   to,
+			Days: []domain.TimesheetRow{{Day: "2024-03-01", TaskID: taskID, Title: "Design, phase 1", Seconds: 5400}},
+		}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/timesheet?from=2024-03-01&to=2024-03-07&format=csv", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "timesheet-20240301-20240308.csv")
+		assert.Equal(t, "date,task_id,task,seconds,hours\n2024-03-01,"+taskID.Hex()+",\"Design, phase 1\",5400,1.50\n", w.Body.String())
+	})
+
+	mockTimeUsecase.AssertExpectations(t)
+}
@@ -219,3 +219,70 @@ func TestUserController_GetUsers(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "admin-only")
 	})
 }
+
+func TestUserController_SetTimezone(t *testing.T) {
+	router := setupRouter()
+	mockUserUsecase := new(mocks.UserUsecaseInterface)
+	userController := NewUserController(mockUserUsecase)
+	router.PUT("/user/timezone", func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	}, userController.SetTimezone)
+
+	t.Run("sets the timezone", func(t *testing.T) {
+		mockUserUsecase.On("SetTimezone", "userID", "Asia/Tokyo").Return(&domain.User{Email: "user@example.com", Timezone: "Asia/Tokyo"}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/user/timezone", strings.NewReader(`{"timezone":"Asia/Tokyo"}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"timezone":"Asia/Tokyo"`)
+	})
+
+	t.Run("unknown timezone", func(t *testing.T) {
+		mockUserUsecase.On("SetTimezone", "userID", "Nowhere").Return(nil, errors.New("timezone must be an IANA time zone such as Europe/Berlin")).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/user/timezone", strings.NewReader(`{"timezone":"Nowhere"}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	mockUserUsecase.AssertExpectations(t)
+}
+
+func TestUserController_SetNextWeights(t *testing.T) {
+	router := setupRouter()
+	mockUserUsecase := new(mocks.UserUsecaseInterface)
+	userController := NewUserController(mockUserUsecase)
+	router.PUT("/user/next-weights", func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	}, userController.SetNextWeights)
+
+	t.Run("sets the weights", func(t *testing.T) {
+		weights := domain.NextWeights{Priority: 5, Due: 2, Blocked: 1, Age: 0.5}
+		mockUserUsecase.On("SetNextWeights", "userID", weights).Return(&domain.User{Email: "user@example.com", NextWeights: &weights}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/user/next-weights", strings.NewReader(`{"priority":5,"due":2,"blocked":1,"age":0.5}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"next_weights":{"priority":5,"due":2,"blocked":1,"age":0.5}`)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		mockUserUsecase.On("SetNextWeights", "userID", domain.NextWeights{Priority: 20}).Return(nil, errors.New("weights must be between 0 and 10")).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/user/next-weights", strings.NewReader(`{"priority":20}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	mockUserUsecase.AssertExpectations(t)
+}
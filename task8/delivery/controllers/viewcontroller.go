@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ViewController struct {
+	usecase domain.ViewUsecaseInterface
+}
+
+func NewViewController(usecase domain.ViewUsecaseInterface) *ViewController {
+	return &ViewController{usecase: usecase}
+}
+
+// workspace is the usecase limited to the active workspace of the request.
+func (vc *ViewController) workspace(ctx *gin.Context) (domain.ViewUsecaseInterface, bool) {
+
+	usecase, err := vc.usecase.InWorkspace(ctx.GetString("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return usecase, true
+}
+
+func (vc *ViewController) CreateView(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var newview domain.View
+
+	if err := ctx.ShouldBindJSON(&newview); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := vc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.CreateView(&newview, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, newview)
+}
+
+func (vc *ViewController) GetViews(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := vc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	views, err := usecase.GetViews(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, views)
+}
+
+func (vc *ViewController) UpdateView(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var updatedview domain.View
+
+	if err := ctx.ShouldBindJSON(&updatedview); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := vc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.UpdateView(id, &updatedview, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, updatedview)
+}
+
+func (vc *ViewController) RemoveView(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := vc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	err := usecase.RemoveView(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "view removed"})
+}
+
+func (vc *ViewController) PinView(ctx *gin.Context) {
+	vc.pin(ctx, true)
+}
+
+func (vc *ViewController) UnpinView(ctx *gin.Context) {
+	vc.pin(ctx, false)
+}
+
+func (vc *ViewController) pin(ctx *gin.Context, pinned bool) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := vc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	view, err := usecase.PinView(id, pinned, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, view)
+}
+
+// GetViewTasks runs a saved view, paged like GET /tasks.
+func (vc *ViewController) GetViewTasks(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	limit, offset, err := pageQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	usecase, ok := vc.workspace(ctx)
+	if !ok {
+		return
+	}
+
+	tasks, total, err := usecase.GetViewTasks(id, userid, limit, offset)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limit > 0 {
+		ctx.Header(totalCountHeader, strconv.FormatInt(total, 10))
+	}
+	ctx.JSON(http.StatusOK, tasks)
+}
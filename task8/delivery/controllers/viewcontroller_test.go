@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupViewRouter(usecase *mocks.ViewUsecaseInterface) *gin.Engine {
+	usecase.On("InWorkspace", mock.Anything).Return(usecase, nil).Maybe()
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	viewController := NewViewController(usecase)
+	router.POST("/views", viewController.CreateView)
+	router.GET("/views", viewController.GetViews)
+	router.POST("/views/:id/pin", viewController.PinView)
+	router.DELETE("/views/:id/pin", viewController.UnpinView)
+	router.GET("/views/:id/tasks", viewController.GetViewTasks)
+	return router
+}
+
+func TestViewController_CreateView(t *testing.T) {
+	mockViewUsecase := new(mocks.ViewUsecaseInterface)
+	router := setupViewRouter(mockViewUsecase)
+
+	mockViewUsecase.On("CreateView", mock.MatchedBy(func(view *domain.View) bool {
+		return view.Name == "This week" && view.Filter.DueTo == "in 7 days" && view.Filter.Tags[0] == "ops"
+	}), "userID").Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/views", strings.NewReader(`{"name":"This week","filter":{"tags":["ops"],"due_to":"in 7 days"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockViewUsecase.AssertExpectations(t)
+}
+
+func TestViewController_GetViews(t *testing.T) {
+	mockViewUsecase := new(mocks.ViewUsecaseInterface)
+	router := setupViewRouter(mockViewUsecase)
+
+	mockViewUsecase.On("GetViews", "userID").Return(&[]domain.View{{Name: "Urgent", Pinned: true, Count: 3}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/views", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"pinned":true`)
+	assert.Contains(t, w.Body.String(), `"count":3`)
+	mockViewUsecase.AssertExpectations(t)
+}
+
+func TestViewController_PinView(t *testing.T) {
+	mockViewUsecase := new(mocks.ViewUsecaseInterface)
+	router := setupViewRouter(mockViewUsecase)
+
+	mockViewUsecase.On("PinView", "viewID", true, "userID").Return(&domain.View{Pinned: true}, nil).Once()
+	mockViewUsecase.On("PinView", "viewID", false, "userID").Return(&domain.View{}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/views/viewID/pin", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("DELETE", "/views/viewID/pin", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockViewUsecase.AssertExpectations(t)
+}
+
+func TestViewController_GetViewTasks(t *testing.T) {
+	mockViewUsecase := new(mocks.ViewUsecaseInterface)
+	router := setupViewRouter(mockViewUsecase)
+
+	t.Run("paged like the task list", func(t *testing.T) {
+		mockViewUsecase.On("GetViewTasks", "viewID", "userID", 10, 20).Return(&[]domain.Task{{Title: "Task 21"}}, int64(21), nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/views/viewID/tasks?limit=10&offset=20", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "21", w.Header().Get("X-Total-Count"))
+		assert.Contains(t, w.Body.String(), `"title":"Task 21"`)
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/views/viewID/tasks?limit=10&offset=-1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error":"offset must not be negative"}`, w.Body.String())
+	})
+	mockViewUsecase.AssertExpectations(t)
+}
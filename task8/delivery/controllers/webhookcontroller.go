@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"net/http"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	usecase domain.WebhookUsecaseInterface
+}
+
+func NewWebhookController(usecase domain.WebhookUsecaseInterface) *WebhookController {
+	return &WebhookController{usecase: usecase}
+}
+
+func (wc *WebhookController) CreateWebhook(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var newwebhook domain.Webhook
+
+	if err := ctx.BindJSON(&newwebhook); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	err := wc.usecase.CreateWebhook(&newwebhook, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, newwebhook)
+}
+
+func (wc *WebhookController) GetWebhooks(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	webhooks, err := wc.usecase.GetWebhooks(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+func (wc *WebhookController) RemoveWebhook(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	err := wc.usecase.RemoveWebhook(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "webhook removed"})
+}
+
+// GetDeliveries is the admin delivery log, filterable by ?status= and ?webhook_id=.
+func (wc *WebhookController) GetDeliveries(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "admin" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "admin-only"})
+		return
+	}
+	filter := domain.DeliveryFilter{Status: ctx.Query("status"), WebhookID: ctx.Query("webhook_id")}
+
+	deliveries, err := wc.usecase.GetDeliveries(filter)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+func (wc *WebhookController) ReplayDelivery(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "admin" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "admin-only"})
+		return
+	}
+	id := ctx.Param("id")
+
+	delivery, err := wc.usecase.ReplayDelivery(id)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, delivery)
+}
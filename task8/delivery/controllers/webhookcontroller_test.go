@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookController(t *testing.T) {
+	mockWebhookUsecase := new(mocks.WebhookUsecaseInterface)
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", ctx.Query("role"))
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	webhookController := NewWebhookController(mockWebhookUsecase)
+	router.POST("/webhooks", webhookController.CreateWebhook)
+	router.GET("/admin/webhooks/deliveries", webhookController.GetDeliveries)
+	router.POST("/admin/webhooks/deliveries/:id/replay", webhookController.ReplayDelivery)
+
+	t.Run("create webhook", func(t *testing.T) {
+		mockWebhookUsecase.On("CreateWebhook", mock.AnythingOfType("*domain.Webhook"), "userID").Run(func(args mock.Arguments) {
+			args.Get(0).(*domain.Webhook).Secret = "generated"
+		}).Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/webhooks?role=user", strings.NewReader(`{"url":"https://example.com/hook","events":["task.created"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"secret":"generated"`)
+	})
+
+	t.Run("admin lists dead deliveries", func(t *testing.T) {
+		mockWebhookUsecase.On("GetDeliveries", domain.DeliveryFilter{Status: "dead"}).Return(&[]domain.WebhookDelivery{{Event: "task.deleted"}}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin/webhooks/deliveries?role=admin&status=dead", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"event":"task.deleted"`)
+	})
+
+	t.Run("users cannot replay", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/admin/webhooks/deliveries/deliveryID/replay?role=user", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("admin replays", func(t *testing.T) {
+		mockWebhookUsecase.On("ReplayDelivery", "deliveryID").Return(&domain.WebhookDelivery{Status: "pending"}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/admin/webhooks/deliveries/deliveryID/replay?role=admin", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	mockWebhookUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"net/http"
+	"task8/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceController struct {
+	usecase domain.WorkspaceUsecaseInterface
+}
+
+func NewWorkspaceController(usecase domain.WorkspaceUsecaseInterface) *WorkspaceController {
+	return &WorkspaceController{usecase: usecase}
+}
+
+func (wc *WorkspaceController) CreateWorkspace(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var newworkspace domain.Workspace
+
+	if err := ctx.ShouldBindJSON(&newworkspace); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	err := wc.usecase.CreateWorkspace(&newworkspace, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, newworkspace)
+}
+
+func (wc *WorkspaceController) GetWorkspace(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	workspace, err := wc.usecase.GetWorkspace(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, workspace)
+}
+
+func (wc *WorkspaceController) GetWorkspaces(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	workspaces, err := wc.usecase.GetWorkspaces(userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, workspaces)
+}
+
+func (wc *WorkspaceController) UpdateWorkspace(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var updatedworkspace domain.Workspace
+
+	if err := ctx.ShouldBindJSON(&updatedworkspace); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	workspace, err := wc.usecase.UpdateWorkspace(id, &updatedworkspace, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, workspace)
+}
+
+func (wc *WorkspaceController) RemoveWorkspace(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	err := wc.usecase.RemoveWorkspace(id, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "workspace removed"})
+}
+
+func (wc *WorkspaceController) AddMember(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var body struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}
+
+	if err := ctx.ShouldBindJSON(&body); err != nil || body.Email == "" || body.Role == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "incomplete information"})
+		return
+	}
+	id := ctx.Param("id")
+	userid := ctx.GetString("user_id")
+
+	workspace, err := wc.usecase.AddMember(id, body.Email, body.Role, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, workspace)
+}
+
+func (wc *WorkspaceController) RemoveMember(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	id := ctx.Param("id")
+	memberid := ctx.Param("memberid")
+	userid := ctx.GetString("user_id")
+
+	workspace, err := wc.usecase.RemoveMember(id, memberid, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, workspace)
+}
+
+// SwitchWorkspace answers with a token for the workspace given as
+// workspace_id, or for the personal workspace when that is empty. Requests
+// made with it work in that workspace.
+func (wc *WorkspaceController) SwitchWorkspace(ctx *gin.Context) {
+	role, exists := ctx.Get("role")
+	if !exists || role != "user" {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "user-only"})
+		return
+	}
+	var body struct {
+		WorkspaceID string `json:"workspace_id"`
+	}
+
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userid := ctx.GetString("user_id")
+
+	token, err := wc.usecase.SwitchWorkspace(body.WorkspaceID, userid)
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"workspace_id": body.WorkspaceID, "token": token})
+}
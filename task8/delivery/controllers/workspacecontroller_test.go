@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupWorkspaceRouter(usecase *mocks.WorkspaceUsecaseInterface) *gin.Engine {
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Next()
+	})
+	workspaceController := NewWorkspaceController(usecase)
+	router.POST("/workspaces", workspaceController.CreateWorkspace)
+	router.POST("/workspaces/switch", workspaceController.SwitchWorkspace)
+	router.POST("/workspaces/:id/members", workspaceController.AddMember)
+	router.DELETE("/workspaces/:id/members/:memberid", workspaceController.RemoveMember)
+	return router
+}
+
+func TestWorkspaceController_CreateWorkspace(t *testing.T) {
+	mockWorkspaceUsecase := new(mocks.WorkspaceUsecaseInterface)
+	router := setupWorkspaceRouter(mockWorkspaceUsecase)
+
+	mockWorkspaceUsecase.On("CreateWorkspace", mock.AnythingOfType("*domain.Workspace"), "userID").Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/workspaces", strings.NewReader(`{"name":"Acme"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"Acme"`)
+	mockWorkspaceUsecase.AssertExpectations(t)
+}
+
+func TestWorkspaceController_AddMember(t *testing.T) {
+	mockWorkspaceUsecase := new(mocks.WorkspaceUsecaseInterface)
+	router := setupWorkspaceRouter(mockWorkspaceUsecase)
+
+	t.Run("successful add", func(t *testing.T) {
+		mockWorkspaceUsecase.On("AddMember", "workspaceID", "new@example.com", "admin", "userID").Return(&domain.Workspace{Name: "Acme"}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/workspaces/workspaceID/members", strings.NewReader(`{"email":"new@example.com","role":"admin"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("missing role", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/workspaces/workspaceID/members", strings.NewReader(`{"email":"new@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error":"incomplete information"}`, w.Body.String())
+	})
+
+	t.Run("insufficient permissions", func(t *testing.T) {
+		mockWorkspaceUsecase.On("AddMember", "workspaceID", "new@example.com", "owner", "userID").Return(nil, errors.New("insufficient workspace permissions")).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/workspaces/workspaceID/members", strings.NewReader(`{"email":"new@example.com","role":"owner"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error":"insufficient workspace permissions"}`, w.Body.String())
+	})
+	mockWorkspaceUsecase.AssertExpectations(t)
+}
+
+func TestWorkspaceController_SwitchWorkspace(t *testing.T) {
+	mockWorkspaceUsecase := new(mocks.WorkspaceUsecaseInterface)
+	router := setupWorkspaceRouter(mockWorkspaceUsecase)
+
+	mockWorkspaceUsecase.On("SwitchWorkspace", "workspaceID", "userID").Return("token", nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/workspaces/switch", strings.NewReader(`{"workspace_id":"workspaceID"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"workspace_id":"workspaceID","token":"token"}`, w.Body.String())
+	mockWorkspaceUsecase.AssertExpectations(t)
+}
+
+func TestTaskController_ActiveWorkspace(t *testing.T) {
+	mockTaskUsecase := new(mocks.TaskUsecaseInterface)
+	scopedUsecase := new(mocks.TaskUsecaseInterface)
+	router := setupRouter()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Set("user_id", "userID")
+		ctx.Set("workspace_id", "workspaceID")
+		ctx.Next()
+	})
+	router.GET("/tasks", NewTaskController(mockTaskUsecase).GetTasks)
+
+	mockTaskUsecase.On("InWorkspace", "workspaceID").Return(scopedUsecase, nil).Once()
+	scopedUsecase.On("GetTasks", "userID").Return(&[]domain.Task{}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tasks", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockTaskUsecase.AssertExpectations(t)
+	scopedUsecase.AssertExpectations(t)
+}
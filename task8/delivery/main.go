@@ -6,9 +6,11 @@ import (
 	"os"
 	"task8/delivery/controllers"
 	"task8/delivery/routers"
+	"task8/domain"
 	"task8/infrastructure"
 	"task8/repositories"
 	"task8/usecases"
+	"time"
 
 	"github.com/joho/godotenv"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -38,16 +40,185 @@ func main() {
 	}
 
 	db := client.Database("taskmanager")
-	taskrepository := repositories.NewTaskRepository(db)
-	taskusecase := usecases.NewTaskUsecase(taskrepository)
-	taskcontroller := controllers.NewTaskController(taskusecase)
-
 	ps := infrastructure.NewPasswordService()
 	js := infrastructure.NewJWTService()
 	usererpository := repositories.NewUserRepository(db, ps)
 	userusecase := usecases.NewUserUsecase(usererpository, js)
 	usercontroller := controllers.NewUserController(userusecase)
 
-	router := routers.SetRouter(taskcontroller, usercontroller)
+	projectrepository := repositories.NewProjectRepository(db)
+	workspacerepository := repositories.NewWorkspaceRepository(db)
+	attachmentrepository := repositories.NewAttachmentRepository(db)
+	webhookrepository := repositories.NewWebhookRepository(db)
+	webhookusecase := usecases.NewWebhookUsecase(webhookrepository, projectrepository, workspacerepository, infrastructure.NewWebhookSender())
+	webhookcontroller := controllers.NewWebhookController(webhookusecase)
+
+	streamusecase := usecases.NewStreamUsecase(projectrepository, workspacerepository, infrastructure.NewMemoryBroker())
+	streamcontroller := controllers.NewStreamController(streamusecase)
+
+	reminderrepository := repositories.NewReminderRepository(db)
+	commentrepository := repositories.NewCommentRepository(db)
+	timeentryrepository := repositories.NewTimeEntryRepository(db)
+	taskrepository := repositories.NewTaskRepository(db)
+	customfieldrepository := repositories.NewCustomFieldRepository(db)
+	taskevents := infrastructure.NewPublishers(webhookusecase, streamusecase)
+	automationrepository := repositories.NewAutomationRepository(db)
+	taskdeps := usecases.TaskUsecaseDeps{
+		Tasks:       taskrepository,
+		Projects:    projectrepository,
+		Users:       usererpository,
+		Attachments: attachmentrepository,
+		Reminders:   reminderrepository,
+		Comments:    commentrepository,
+		TimeEntries: timeentryrepository,
+		Fields:      customfieldrepository,
+		Events:      taskevents,
+	}
+	automationtasks := usecases.NewTaskUsecase(taskdeps)
+	automationusecase := usecases.NewAutomationUsecase(automationrepository, automationtasks, usererpository, taskevents)
+	automationcontroller := controllers.NewAutomationController(automationusecase)
+	taskdeps.Events = infrastructure.NewPublishers(taskevents, automationusecase)
+	taskusecase := usecases.NewTaskUsecase(taskdeps)
+	taskcontroller := controllers.NewTaskController(taskusecase)
+
+	attachmentusecase := usecases.NewAttachmentUsecase(attachmentrepository, taskusecase)
+	attachmentcontroller := controllers.NewAttachmentController(attachmentusecase)
+
+	projectusecase := usecases.NewProjectUsecase(projectrepository, usererpository)
+	projectcontroller := controllers.NewProjectController(projectusecase, taskusecase)
+
+	commentusecase := usecases.NewCommentUsecase(commentrepository, taskusecase, usererpository)
+	commentcontroller := controllers.NewCommentController(commentusecase)
+
+	notificationrepository := repositories.NewNotificationRepository(db)
+	notifiers := map[string]domain.Notifier{
+		domain.ReminderEmail:   infrastructure.NewEmailNotifier(),
+		domain.ReminderWebhook: infrastructure.NewWebhookNotifier(),
+		domain.ReminderInApp:   infrastructure.NewInAppNotifier(notificationrepository),
+	}
+	reminderusecase := usecases.NewReminderUsecase(reminderrepository, notificationrepository, taskusecase, usererpository, notifiers)
+	remindercontroller := controllers.NewReminderController(reminderusecase)
+
+	timeusecase := usecases.NewTimeUsecase(timeentryrepository, taskusecase, usererpository)
+	timecontroller := controllers.NewTimeController(timeusecase)
+
+	calendarrepository := repositories.NewCalendarRepository(db)
+	calendarusecase := usecases.NewCalendarUsecase(calendarrepository, taskrepository, usererpository, workspacerepository)
+	calendarcontroller := controllers.NewCalendarController(calendarusecase)
+	caldavcontroller := controllers.NewCalDAVController(taskusecase, projectusecase)
+
+	tagrepository := repositories.NewTagRepository(db)
+	tagusecase := usecases.NewTagUsecase(tagrepository, workspacerepository)
+	tagcontroller := controllers.NewTagController(tagusecase)
+
+	workspaceusecase := usecases.NewWorkspaceUsecase(workspacerepository, usererpository, js)
+	workspacecontroller := controllers.NewWorkspaceController(workspaceusecase)
+
+	customfieldusecase := usecases.NewCustomFieldUsecase(customfieldrepository, workspacerepository)
+	customfieldcontroller := controllers.NewCustomFieldController(customfieldusecase)
+
+	templaterepository := repositories.NewTemplateRepository(db)
+	templateusecase := usecases.NewTemplateUsecase(templaterepository, taskusecase, usererpository)
+	templatecontroller := controllers.NewTemplateController(templateusecase)
+
+	viewrepository := repositories.NewViewRepository(db)
+	viewusecase := usecases.NewViewUsecase(viewrepository, taskusecase, customfieldrepository)
+	viewcontroller := controllers.NewViewController(viewusecase)
+
+	if err := taskrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := tagrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := projectrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := commentrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := attachmentrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := reminderrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := notificationrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := webhookrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := timeentryrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := calendarrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := workspacerepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := templaterepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := viewrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := automationrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+	if err := customfieldrepository.EnsureIndexes(); err != nil {
+		log.Fatal(err)
+	}
+
+	reminderscheduler := infrastructure.NewScheduler(time.Minute, func(now time.Time) {
+		if _, err := reminderusecase.DispatchReminders(now); err != nil {
+			log.Println("reminders:", err)
+		}
+	})
+	reminderscheduler.Start(context.Background())
+
+	webhookscheduler := infrastructure.NewScheduler(10*time.Second, func(now time.Time) {
+		if _, err := webhookusecase.DispatchDeliveries(now); err != nil {
+			log.Println("webhooks:", err)
+		}
+	})
+	webhookscheduler.Start(context.Background())
+
+	boardscheduler := infrastructure.NewScheduler(5*time.Minute, func(now time.Time) {
+		if _, err := taskusecase.RebalanceBoards(); err != nil {
+			log.Println("boards:", err)
+		}
+	})
+	boardscheduler.Start(context.Background())
+
+	overduescheduler := infrastructure.NewScheduler(time.Minute, func(now time.Time) {
+		if _, err := taskusecase.PublishOverdue(now); err != nil {
+			log.Println("overdue:", err)
+		}
+	})
+	overduescheduler.Start(context.Background())
+
+	router := routers.SetRouter(routers.Handlers{
+		Tasks:            taskcontroller,
+		Users:            usercontroller,
+		Tags:             tagcontroller,
+		Projects:         projectcontroller,
+		Comments:         commentcontroller,
+		Attachments:      attachmentcontroller,
+		Reminders:        remindercontroller,
+		Webhooks:         webhookcontroller,
+		Stream:           streamcontroller,
+		Time:             timecontroller,
+		Calendar:         calendarcontroller,
+		CalDAV:           caldavcontroller,
+		Workspaces:       workspacecontroller,
+		Templates:        templatecontroller,
+		Views:            viewcontroller,
+		Automations:      automationcontroller,
+		Fields:           customfieldcontroller,
+		UserUsecase:      userusecase,
+		WorkspaceUsecase: workspaceusecase,
+	})
 	router.Run(":8080")
 }
@@ -2,27 +2,163 @@ package routers
 
 import (
 	"task8/delivery/controllers"
+	"task8/domain"
 	"task8/infrastructure"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetRouter(c *controllers.TaskController, u *controllers.UserController) *gin.Engine {
+// Handlers are the controllers the router serves and the usecases its
+// middleware authorizes with.
+type Handlers struct {
+	Tasks            *controllers.TaskController
+	Users            *controllers.UserController
+	Tags             *controllers.TagController
+	Projects         *controllers.ProjectController
+	Comments         *controllers.CommentController
+	Attachments      *controllers.AttachmentController
+	Reminders        *controllers.ReminderController
+	Webhooks         *controllers.WebhookController
+	Stream           *controllers.StreamController
+	Time             *controllers.TimeController
+	Calendar         *controllers.CalendarController
+	CalDAV           *controllers.CalDAVController
+	Workspaces       *controllers.WorkspaceController
+	Templates        *controllers.TemplateController
+	Views            *controllers.ViewController
+	Automations      *controllers.AutomationController
+	Fields           *controllers.CustomFieldController
+	UserUsecase      domain.UserUsecaseInterface
+	WorkspaceUsecase domain.WorkspaceUsecaseInterface
+}
+
+func SetRouter(h Handlers) *gin.Engine {
 
 	router := gin.Default()
-	route := router.Group("/", infrastructure.UserAuthorizaiton())
+	route := router.Group("/", infrastructure.UserAuthorizaiton(), infrastructure.WorkspaceAuthorization(h.WorkspaceUsecase))
 	{
 
-		route.GET("tasks/", c.GetTasks)
-		route.GET("tasks/:id", c.GetTask)
-		route.PUT("tasks/:id", c.UpdateTask)
-		route.POST("tasks/", c.CreateTask)
-		route.DELETE("tasks/:id", c.RemoveTask)
-		route.GET("users/", u.GetUsers)
-		route.GET("user/:email", u.GetUser)
+		route.GET("tasks/", h.Tasks.GetTasks)
+		route.GET("tasks/assigned-to-me", h.Tasks.GetAssignedTasks)
+		route.GET("tasks/stats", h.Tasks.GetStats)
+		route.GET("tasks/today", h.Tasks.GetTodayTasks)
+		route.GET("tasks/upcoming", h.Tasks.GetUpcomingTasks)
+		route.GET("tasks/overdue", h.Tasks.GetOverdueTasks)
+		route.GET("tasks/next", h.Tasks.NextTasks)
+		route.GET("tasks/export", h.Tasks.ExportTasks)
+		route.POST("tasks/import", h.Tasks.ImportTasks)
+		route.GET("tasks/:id", h.Tasks.GetTask)
+		route.PUT("tasks/:id", h.Tasks.UpdateTask)
+		route.POST("tasks/", h.Tasks.CreateTask)
+		route.POST("tasks/bulk", h.Tasks.BulkTasks)
+		route.DELETE("tasks/:id", h.Tasks.RemoveTask)
+		route.PUT("tasks/:id/assignee", h.Tasks.AssignTask)
+		route.POST("tasks/:id/move", h.Tasks.MoveTask)
+		route.POST("tasks/:id/dependencies", h.Tasks.AddBlocker)
+		route.DELETE("tasks/:id/dependencies/:blockerid", h.Tasks.RemoveBlocker)
+		route.GET("tasks/:id/graph", h.Tasks.GetDependencyGraph)
+		route.GET("tasks/:id/occurrences", h.Tasks.GetOccurrences)
+		route.GET("tasks/:id/comments", h.Comments.GetComments)
+		route.POST("tasks/:id/comments", h.Comments.CreateComment)
+		route.PUT("tasks/:id/comments/:commentid", h.Comments.UpdateComment)
+		route.DELETE("tasks/:id/comments/:commentid", h.Comments.RemoveComment)
+		route.GET("tasks/:id/attachments", h.Attachments.GetAttachments)
+		route.POST("tasks/:id/attachments", h.Attachments.UploadAttachment)
+		route.GET("tasks/:id/attachments/:attachmentid", h.Attachments.DownloadAttachment)
+		route.DELETE("tasks/:id/attachments/:attachmentid", h.Attachments.RemoveAttachment)
+		route.GET("tasks/:id/reminders", h.Reminders.GetReminders)
+		route.POST("tasks/:id/reminders", h.Reminders.CreateReminder)
+		route.DELETE("tasks/:id/reminders/:reminderid", h.Reminders.RemoveReminder)
+		route.POST("tasks/:id/timer/start", h.Time.StartTimer)
+		route.POST("tasks/:id/timer/stop", h.Time.StopTimer)
+		route.GET("tasks/:id/time-entries", h.Time.GetTimeEntries)
+		route.POST("tasks/:id/time-entries", h.Time.CreateTimeEntry)
+		route.DELETE("tasks/:id/time-entries/:entryid", h.Time.RemoveTimeEntry)
+		route.GET("timer", h.Time.GetRunningTimer)
+		route.GET("timesheet", h.Time.GetTimesheet)
+		route.GET("notifications/", h.Reminders.GetNotifications)
+		route.PUT("notifications/:id/read", h.Reminders.ReadNotification)
+		route.GET("webhooks/", h.Webhooks.GetWebhooks)
+		route.POST("webhooks/", h.Webhooks.CreateWebhook)
+		route.DELETE("webhooks/:id", h.Webhooks.RemoveWebhook)
+		route.GET("admin/stats", h.Tasks.GetAllStats)
+		route.GET("admin/webhooks/deliveries", h.Webhooks.GetDeliveries)
+		route.POST("admin/webhooks/deliveries/:id/replay", h.Webhooks.ReplayDelivery)
+		route.GET("tags/", h.Tags.GetTags)
+		route.POST("tags/", h.Tags.CreateTag)
+		route.PUT("tags/:id", h.Tags.UpdateTag)
+		route.DELETE("tags/:id", h.Tags.RemoveTag)
+		route.POST("tags/:id/merge", h.Tags.MergeTags)
+		route.GET("projects/", h.Projects.GetProjects)
+		route.POST("projects/", h.Projects.CreateProject)
+		route.GET("projects/:id", h.Projects.GetProject)
+		route.PUT("projects/:id", h.Projects.UpdateProject)
+		route.DELETE("projects/:id", h.Projects.RemoveProject)
+		route.GET("projects/:id/tasks", h.Projects.GetProjectTasks)
+		route.POST("projects/:id/members", h.Projects.AddMember)
+		route.DELETE("projects/:id/members/:memberid", h.Projects.RemoveMember)
+		route.GET("workspaces/", h.Workspaces.GetWorkspaces)
+		route.POST("workspaces/", h.Workspaces.CreateWorkspace)
+		route.POST("workspaces/switch", h.Workspaces.SwitchWorkspace)
+		route.GET("workspaces/:id", h.Workspaces.GetWorkspace)
+		route.PUT("workspaces/:id", h.Workspaces.UpdateWorkspace)
+		route.DELETE("workspaces/:id", h.Workspaces.RemoveWorkspace)
+		route.POST("workspaces/:id/members", h.Workspaces.AddMember)
+		route.DELETE("workspaces/:id/members/:memberid", h.Workspaces.RemoveMember)
+		route.GET("templates/", h.Templates.GetTemplates)
+		route.POST("templates/", h.Templates.CreateTemplate)
+		route.GET("templates/:id", h.Templates.GetTemplate)
+		route.PUT("templates/:id", h.Templates.UpdateTemplate)
+		route.DELETE("templates/:id", h.Templates.RemoveTemplate)
+		route.POST("templates/:id/instantiate", h.Templates.Instantiate)
+		route.GET("views/", h.Views.GetViews)
+		route.POST("views/", h.Views.CreateView)
+		route.PUT("views/:id", h.Views.UpdateView)
+		route.DELETE("views/:id", h.Views.RemoveView)
+		route.POST("views/:id/pin", h.Views.PinView)
+		route.DELETE("views/:id/pin", h.Views.UnpinView)
+		route.GET("views/:id/tasks", h.Views.GetViewTasks)
+		route.GET("automations/", h.Automations.GetAutomations)
+		route.POST("automations/", h.Automations.CreateAutomation)
+		route.PUT("automations/:id", h.Automations.UpdateAutomation)
+		route.DELETE("automations/:id", h.Automations.RemoveAutomation)
+		route.GET("automations/:id/runs", h.Automations.GetRuns)
+		route.GET("fields/", h.Fields.GetFields)
+		route.POST("fields/", h.Fields.CreateField)
+		route.PUT("fields/:id", h.Fields.UpdateField)
+		route.DELETE("fields/:id", h.Fields.RemoveField)
+		route.GET("calendar", h.Calendar.GetFeed)
+		route.PUT("calendar", h.Calendar.SetTimezone)
+		route.POST("calendar/token", h.Calendar.RegenerateToken)
+		route.GET("users/", h.Users.GetUsers)
+		route.GET("user/:email", h.Users.GetUser)
+		route.PUT("user/timezone", h.Users.SetTimezone)
+		route.PUT("user/next-weights", h.Users.SetNextWeights)
+	}
+	stream := router.Group("/", infrastructure.StreamAuthorization(), infrastructure.WorkspaceAuthorization(h.WorkspaceUsecase))
+	{
+		stream.GET("events", h.Stream.Events)
+		stream.GET("ws", h.Stream.WebSocket)
+	}
+	// Calendar apps log in with HTTP Basic on every request.
+	caldav := router.Group(controllers.DAVPrefix, infrastructure.BasicAuthorization(h.UserUsecase), infrastructure.WorkspaceAuthorization(h.WorkspaceUsecase))
+	{
+		caldav.Handle("OPTIONS", "/*path", h.CalDAV.Serve)
+		caldav.Handle("PROPFIND", "/*path", h.CalDAV.Serve)
+		caldav.Handle("REPORT", "/*path", h.CalDAV.Serve)
+		caldav.Handle("GET", "/*path", h.CalDAV.Serve)
+		caldav.Handle("HEAD", "/*path", h.CalDAV.Serve)
+		caldav.Handle("PUT", "/*path", h.CalDAV.Serve)
+		caldav.Handle("DELETE", "/*path", h.CalDAV.Serve)
+	}
+	wellknown := router.Group("/.well-known", infrastructure.BasicAuthorization(h.UserUsecase), infrastructure.WorkspaceAuthorization(h.WorkspaceUsecase))
+	{
+		wellknown.Handle("GET", "/caldav", h.CalDAV.Serve)
+		wellknown.Handle("PROPFIND", "/caldav", h.CalDAV.Serve)
 	}
-	router.POST("/register", u.Register)
-	router.POST("/login", u.Login)
+	router.POST("/register", h.Users.Register)
+	router.POST("/login", h.Users.Login)
+	router.GET("/calendar/:token", h.Calendar.RenderFeed)
 
 	return router
 
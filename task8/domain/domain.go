@@ -1,18 +1,738 @@
 package domain
 
 import (
+	"errors"
+	"io"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Task struct {
+	ID           primitive.ObjectID   `bson:"_id,omitempty" json:"_id,omitempty"`
+	UserID       primitive.ObjectID   `bson:"user_id,omitempty" json:"-"`
+	Title        string               `json:"title"`
+	Description  string               `json:"description"`
+	DueDate      time.Time            `json:"duedate"`
+	Status       string               `json:"status"`
+	Tags         []string             `bson:"tags" json:"tags"`
+	ProjectID    primitive.ObjectID   `bson:"project_id,omitempty" json:"project_id,omitempty"`
+	AssigneeID   primitive.ObjectID   `bson:"assignee_id,omitempty" json:"assignee_id,omitempty"`
+	Watchers     []primitive.ObjectID `bson:"watchers" json:"watchers"`
+	History      []TaskEvent          `bson:"history,omitempty" json:"history,omitempty"`
+	CommentCount int                  `bson:"comment_count,omitempty" json:"comment_count"`
+	RRule        string               `bson:"rrule,omitempty" json:"rrule,omitempty"`
+	SeriesID     primitive.ObjectID   `bson:"series_id,omitempty" json:"series_id,omitempty"`
+	CreatedAt    time.Time            `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	CompletedAt  time.Time            `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	Rank         float64              `bson:"rank,omitempty" json:"rank"`
+	BlockedBy    []primitive.ObjectID `bson:"blocked_by,omitempty" json:"blocked_by,omitempty"`
+	ExternalID   string               `bson:"external_id,omitempty" json:"external_id,omitempty"`
+	// DueText is a due date in words, such as "tomorrow 5pm", which the
+	// usecase resolves into DueDate in the user's timezone.
+	DueText string `bson:"-" json:"-"`
+	// DueDateOnly makes DueDate a day rather than a moment. It then holds
+	// midnight UTC of that date, which is due all day in any timezone.
+	DueDateOnly bool `bson:"due_date_only,omitempty" json:"due_date_only,omitempty"`
+	// CalendarUID and CalendarName are the UID and the resource name a
+	// CalDAV client gave the task when it created it.
+	CalendarUID  string `bson:"calendar_uid,omitempty" json:"-"`
+	CalendarName string `bson:"calendar_name,omitempty" json:"-"`
+	// WorkspaceID is the workspace the task belongs to, zero for the
+	// personal workspace of its creator.
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty" json:"workspace_id,omitempty"`
+	// OverdueFor is the due date task.overdue was last published for, so
+	// that it is published once for each.
+	OverdueFor time.Time `bson:"overdue_for,omitempty" json:"-"`
+	// NextScheduled records that completing the occurrence created the next
+	// one of its series, so that completing it again does not.
+	NextScheduled bool `bson:"next_scheduled,omitempty" json:"-"`
+	// Fields holds the values of the custom fields of the workspace by
+	// key. Left out of an update, the values stay as they are.
+	Fields map[string]interface{} `bson:"fields,omitempty" json:"fields,omitempty"`
+	// Priority is one of Priorities, empty for none. Left out of an update,
+	// it stays as it is.
+	Priority string `bson:"priority,omitempty" json:"priority,omitempty"`
+}
+
+const (
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+// Priorities lists the priority levels of a task, lowest first.
+var Priorities = []string{PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
+// DoneStatuses are the task statuses that count as completed.
+var DoneStatuses = []string{"done", "completed"}
+
+// IsDone reports whether status is one of DoneStatuses, ignoring case.
+func IsDone(status string) bool {
+	for _, done := range DoneStatuses {
+		if strings.EqualFold(status, done) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrTaskNotFound and ErrNoPermission are what loading a task fails with
+// when the user cannot see it or lacks the project role it needs.
+var (
+	ErrTaskNotFound = errors.New("task not found")
+	ErrNoPermission = errors.New("insufficient project permissions")
+)
+
+// Day returns the date of t, in t's location, as midnight UTC: the form a
+// date-only due date is kept in.
+func Day(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// The due date views of GET /tasks/today, /tasks/upcoming and /tasks/overdue.
+const (
+	DueToday    = "today"
+	DueUpcoming = "upcoming"
+	DueOverdue  = "overdue"
+)
+
+// DueWindow selects open tasks by due date. Due dates with a time of day
+// are compared with From and To, date-only ones with FromDay and ToDay.
+// The ends are exclusive and a zero start leaves the window open, though
+// tasks without a due date never fall in it.
+type DueWindow struct {
+	From    time.Time
+	To      time.Time
+	FromDay time.Time
+	ToDay   time.Time
+}
+
+type TaskEvent struct {
+	Action string             `bson:"action" json:"action"`
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	From   string             `bson:"from,omitempty" json:"from,omitempty"`
+	To     string             `bson:"to,omitempty" json:"to,omitempty"`
+	At     time.Time          `bson:"at" json:"at"`
+}
+
+type Comment struct {
+	ID        primitive.ObjectID   `bson:"_id,omitempty" json:"_id,omitempty"`
+	TaskID    primitive.ObjectID   `bson:"task_id" json:"task_id"`
+	UserID    primitive.ObjectID   `bson:"user_id" json:"user_id"`
+	ParentID  primitive.ObjectID   `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	Body      string               `bson:"body" json:"body"`
+	Mentions  []primitive.ObjectID `bson:"mentions" json:"mentions"`
+	CreatedAt time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time            `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+	Replies   []Comment            `bson:"-" json:"replies,omitempty"`
+}
+
+type Attachment struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	TaskID      primitive.ObjectID `bson:"task_id" json:"task_id"`
+	UserID      primitive.ObjectID `bson:"user_id" json:"user_id"`
+	FileID      primitive.ObjectID `bson:"file_id" json:"-"`
+	Filename    string             `bson:"filename" json:"filename"`
+	ContentType string             `bson:"content_type" json:"content_type"`
+	Size        int64              `bson:"size" json:"size"`
+	SHA256      string             `bson:"sha256" json:"sha256"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+}
+
+const (
+	ReminderEmail   = "email"
+	ReminderWebhook = "webhook"
+	ReminderInApp   = "in_app"
+)
+
+const (
+	ReminderPending   = "pending"
+	ReminderSending   = "sending"
+	ReminderSent      = "sent"
+	ReminderFailed    = "failed"
+	ReminderCancelled = "cancelled"
+)
+
+type Reminder struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	TaskID      primitive.ObjectID `bson:"task_id" json:"task_id"`
+	UserID      primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Before      string             `bson:"before" json:"before"`
+	Offset      int64              `bson:"offset" json:"-"`
+	Channel     string             `bson:"channel" json:"channel"`
+	Target      string             `bson:"target,omitempty" json:"target,omitempty"`
+	RemindAt    time.Time          `bson:"remind_at" json:"remind_at"`
+	Status      string             `bson:"status" json:"status"`
+	Attempts    int                `bson:"attempts" json:"attempts"`
+	LockedUntil time.Time          `bson:"locked_until,omitempty" json:"-"`
+	SentAt      time.Time          `bson:"sent_at,omitempty" json:"sent_at,omitempty"`
+	Error       string             `bson:"error,omitempty" json:"error,omitempty"`
+}
+
+type Notification struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TaskID    primitive.ObjectID `bson:"task_id,omitempty" json:"task_id,omitempty"`
+	Title     string             `bson:"title" json:"title"`
+	Message   string             `bson:"message" json:"message"`
+	Read      bool               `bson:"read" json:"read"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// Notifier delivers a notification over one channel. The notification ID is
+// stable across retries so receivers can drop duplicates.
+type Notifier interface {
+	Notify(target string, notification *Notification) error
+}
+
+const (
+	EventTaskCreated   = "task.created"
+	EventTaskUpdated   = "task.updated"
+	EventTaskCompleted = "task.completed"
+	EventTaskDeleted   = "task.deleted"
+	EventTaskOverdue   = "task.overdue"
+	// EventStreamResync tells a stream client that the events after its
+	// Last-Event-ID are gone and it has to reload the tasks it shows.
+	EventStreamResync = "stream.resync"
+)
+
+// TaskEvents lists the events webhooks and automations can subscribe to.
+var TaskEvents = []string{EventTaskCreated, EventTaskUpdated, EventTaskCompleted, EventTaskDeleted, EventTaskOverdue}
+
+// EventPublisher is told about every change TaskUsecase makes to a task.
+type EventPublisher interface {
+	Publish(event string, task *Task)
+}
+
+// StreamEvent is a task change pushed to connected clients. IDs increase
+// over time so a client can resume after the last one it saw, as long as
+// the broker that gave it out keeps running.
+type StreamEvent struct {
+	ID       string               `json:"id"`
+	Event    string               `json:"event"`
+	Task     *Task                `json:"task"`
+	At       time.Time            `json:"at"`
+	Audience []primitive.ObjectID `json:"-"`
+}
+
+// EventBroker fans stream events out to the subscribed users. Subscribe
+// first replays the retained events after lastEventID, then follows live
+// ones; the channel is closed when the subscriber falls too far behind.
+type EventBroker interface {
+	Publish(event StreamEvent)
+	Subscribe(userid string, lastEventID string) (<-chan StreamEvent, func())
+}
+
+type Webhook struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	URL       string             `bson:"url" json:"url"`
+	Secret    string             `bson:"secret" json:"secret,omitempty"`
+	Events    []string           `bson:"events" json:"events"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySending   = "sending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+type WebhookDelivery struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	WebhookID    primitive.ObjectID `bson:"webhook_id" json:"webhook_id"`
+	UserID       primitive.ObjectID `bson:"user_id" json:"user_id"`
+	URL          string             `bson:"url" json:"url"`
+	Event        string             `bson:"event" json:"event"`
+	Payload      string             `bson:"payload" json:"payload"`
+	Status       string             `bson:"status" json:"status"`
+	Attempts     int                `bson:"attempts" json:"attempts"`
+	NextAttempt  time.Time          `bson:"next_attempt" json:"next_attempt"`
+	LockedUntil  time.Time          `bson:"locked_until,omitempty" json:"-"`
+	ResponseCode int                `bson:"response_code,omitempty" json:"response_code,omitempty"`
+	LastError    string             `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+	DeliveredAt  time.Time          `bson:"delivered_at,omitempty" json:"delivered_at,omitempty"`
+}
+
+type DeliveryFilter struct {
+	Status    string
+	WebhookID string
+}
+
+// WebhookSender posts one signed delivery and reports the status code the
+// receiver answered with.
+type WebhookSender interface {
+	Send(delivery *WebhookDelivery, secret string) (int, error)
+}
+
+type Tag struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	UserID primitive.ObjectID `bson:"user_id,omitempty" json:"-"`
+	Name   string             `bson:"name" json:"name"`
+	Color  string             `bson:"color" json:"color"`
+}
+
+const (
+	ProjectOwner  = "owner"
+	ProjectEditor = "editor"
+	ProjectViewer = "viewer"
+)
+
+type ProjectMember struct {
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Role   string             `bson:"role" json:"role"`
+}
+
+type Project struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Name        string             `bson:"name" json:"name"`
+	Description string             `bson:"description" json:"description"`
+	Members     []ProjectMember    `bson:"members" json:"members"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty" json:"workspace_id,omitempty"`
+}
+
+const (
+	WorkspaceOwner  = "owner"
+	WorkspaceAdmin  = "admin"
+	WorkspaceMember = "member"
+)
+
+type WorkspaceMembership struct {
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Role   string             `bson:"role" json:"role"`
+}
+
+// Workspace is a team's own space on the deployment. Its tasks and projects
+// are only seen by members while it is their active workspace, which the
+// token carries; without one a user works in their personal workspace.
+type Workspace struct {
+	ID        primitive.ObjectID    `bson:"_id,omitempty" json:"_id,omitempty"`
+	Name      string                `bson:"name" json:"name"`
+	Members   []WorkspaceMembership `bson:"members" json:"members"`
+	CreatedAt time.Time             `bson:"created_at" json:"created_at"`
+}
+
+// Template is a saved recipe for tasks that are set up again and again.
+// Title, Description and the checklist may hold {{variables}}, filled in
+// when it is instantiated, and due offsets such as "+3d" count from that
+// day. Templates of a workspace are shared by its members.
+type Template struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	UserID      primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty" json:"workspace_id,omitempty"`
+	Name        string             `bson:"name" json:"name"`
+	Title       string             `bson:"title" json:"title"`
+	Description string             `bson:"description" json:"description"`
+	Status      string             `bson:"status" json:"status"`
+	Tags        []string           `bson:"tags" json:"tags"`
+	DueOffset   string             `bson:"due_offset,omitempty" json:"due_offset,omitempty"`
+	Checklist   []TemplateItem     `bson:"checklist" json:"checklist"`
+	CreatedAt   time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// TemplateItem becomes a task of its own, which the templated task is
+// blocked by.
+type TemplateItem struct {
+	Title     string `bson:"title" json:"title"`
+	DueOffset string `bson:"due_offset,omitempty" json:"due_offset,omitempty"`
+}
+
+// TemplateInstance is what a template is instantiated with. Start is the
+// day the due offsets count from, in words or as a date, today if empty.
+type TemplateInstance struct {
+	Variables map[string]string  `json:"variables"`
+	Start     string             `json:"start"`
+	ProjectID primitive.ObjectID `json:"project_id"`
+}
+
+type TemplateResult struct {
+	Task      *Task  `json:"task"`
+	Checklist []Task `json:"checklist"`
+}
+
+const (
+	ActionAddTag      = "add_tag"
+	ActionRemoveTag   = "remove_tag"
+	ActionSetStatus   = "set_status"
+	ActionAssign      = "assign"
+	ActionCreateTask  = "create_task"
+	ActionSetPriority = "set_priority"
+)
+
+// Automation is a rule run on task events: when Trigger happens to a task
+// that meets the conditions, the actions are applied to it. Personal
+// automations run on the personal tasks of their user, those of a
+// workspace on all of its tasks.
+type Automation struct {
+	ID          primitive.ObjectID   `bson:"_id,omitempty" json:"_id,omitempty"`
+	UserID      primitive.ObjectID   `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	WorkspaceID primitive.ObjectID   `bson:"workspace_id,omitempty" json:"workspace_id,omitempty"`
+	Name        string               `bson:"name" json:"name"`
+	Trigger     string               `bson:"trigger" json:"trigger"`
+	Conditions  AutomationConditions `bson:"conditions" json:"conditions"`
+	Actions     []AutomationAction   `bson:"actions" json:"actions"`
+	Disabled    bool                 `bson:"disabled,omitempty" json:"disabled"`
+	CreatedAt   time.Time            `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// AutomationConditions narrow the tasks an automation runs on. A task must
+// have one of the statuses and all of the tags; empty conditions match
+// every task.
+type AutomationConditions struct {
+	Statuses  []string           `bson:"statuses,omitempty" json:"statuses,omitempty"`
+	Tags      []string           `bson:"tags,omitempty" json:"tags,omitempty"`
+	ProjectID primitive.ObjectID `bson:"project_id,omitempty" json:"project_id,omitempty"`
+}
+
+// AutomationAction is one step of an automation. Value is the tag, status,
+// priority or assignee it sets. create_task makes a follow-up task from Title,
+// Description and DueOffset, where {{title}} is the title of the task the
+// automation runs on.
+type AutomationAction struct {
+	Type        string `bson:"type" json:"type"`
+	Value       string `bson:"value,omitempty" json:"value,omitempty"`
+	Title       string `bson:"title,omitempty" json:"title,omitempty"`
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+	DueOffset   string `bson:"due_offset,omitempty" json:"due_offset,omitempty"`
+}
+
+const (
+	RunSucceeded = "succeeded"
+	RunFailed    = "failed"
+	RunSkipped   = "skipped"
+)
+
+// AutomationRun is an entry of the execution log: one time an automation
+// was triggered, at Depth in a chain of automations triggering each other.
+type AutomationRun struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	AutomationID primitive.ObjectID `bson:"automation_id" json:"automation_id"`
+	TaskID       primitive.ObjectID `bson:"task_id" json:"task_id"`
+	Event        string             `bson:"event" json:"event"`
+	Status       string             `bson:"status" json:"status"`
+	Error        string             `bson:"error,omitempty" json:"error,omitempty"`
+	Depth        int                `bson:"depth" json:"depth"`
+	At           time.Time          `bson:"at" json:"at"`
+}
+
+type StatsFilter struct {
+	UserID   string
+	From     time.Time
+	To       time.Time
+	Interval string
+	// Today is the user's current date as midnight UTC. Date-only due
+	// dates before it are overdue.
+	Today time.Time
+}
+
+type StatsBucket struct {
+	Start time.Time `bson:"_id" json:"start"`
+	Count int       `bson:"count" json:"count"`
+}
+
+type TaskStats struct {
+	Total                int            `json:"total"`
+	ByStatus             map[string]int `json:"by_status"`
+	Overdue              int            `json:"overdue"`
+	Completed            []StatsBucket  `json:"completed"`
+	AvgCompletionSeconds float64        `json:"avg_completion_seconds"`
+}
+
+// BoardColumn is one status column of a board: a project's board, or the
+// board of a user's own tasks in a workspace when ProjectID is zero.
+type BoardColumn struct {
+	ProjectID   primitive.ObjectID `bson:"project_id,omitempty"`
+	UserID      primitive.ObjectID `bson:"user_id,omitempty"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty"`
+	Status      string             `bson:"status"`
+}
+
+// TaskMove places a task in a status column, right after the task After or
+// right before the task Before. With neither it goes to the bottom.
+type TaskMove struct {
+	Status string `json:"status"`
+	After  string `json:"after"`
+	Before string `json:"before"`
+}
+
+// GraphNode is a task in a dependency graph. Tasks the user cannot read are
+// Hidden, with only their ID.
+type GraphNode struct {
+	ID     primitive.ObjectID `json:"_id"`
+	Title  string             `json:"title,omitempty"`
+	Status string             `json:"status,omitempty"`
+	Hidden bool               `json:"hidden,omitempty"`
+}
+
+// GraphEdge reads "From blocks To".
+type GraphEdge struct {
+	From primitive.ObjectID `json:"from"`
+	To   primitive.ObjectID `json:"to"`
+}
+
+type TaskGraph struct {
+	Root  primitive.ObjectID `json:"root"`
+	Nodes []GraphNode        `json:"nodes"`
+	Edges []GraphEdge        `json:"edges"`
+}
+
+// Bulk operations and the actions a filter can be combined with.
+const (
+	BulkCreate    = "create"
+	BulkUpdate    = "update"
+	BulkDelete    = "delete"
+	BulkSetStatus = "set_status"
+	BulkAddTag    = "add_tag"
+	BulkRemoveTag = "remove_tag"
+)
+
+// Outcome of one bulk item.
+const (
+	BulkOK      = "ok"
+	BulkFailed  = "failed"
+	BulkSkipped = "skipped"
+)
+
+type BulkOperation struct {
+	Op   string `json:"op"`
+	ID   string `json:"id,omitempty"`
+	Task *Task  `json:"task,omitempty"`
+}
+
+// BulkFilter selects the user's own tasks, or a project's tasks when
+// ProjectID is set.
+type BulkFilter struct {
+	Tags      []string `json:"tags"`
+	MatchAll  bool     `json:"match_all"`
+	Status    string   `json:"status"`
+	ProjectID string   `json:"project_id"`
+}
+
+type BulkAction struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// BulkRequest carries either a list of operations or a filter with an
+// action to apply to every task it matches. With Atomic set, either every
+// item is applied, in one transaction, or none is.
+type BulkRequest struct {
+	Operations []BulkOperation `json:"operations"`
+	Filter     *BulkFilter     `json:"filter"`
+	Action     *BulkAction     `json:"action"`
+	Atomic     bool            `json:"atomic"`
+}
+
+type BulkResult struct {
+	Index  int    `json:"index"`
+	Op     string `json:"op"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Task   *Task  `json:"task,omitempty"`
+}
+
+type BulkResponse struct {
+	Atomic  bool         `json:"atomic"`
+	Results []BulkResult `json:"results"`
+}
+
+// TaskWrite is one change of an atomic batch: Create inserts a task, Update
+// replaces one (with Assignment, when set, recorded as AssignTask does) and
+// Delete removes one.
+type TaskWrite struct {
+	ID         string
+	Create     *Task
+	Update     *Task
+	Assignee   string
+	Assignment *TaskEvent
+	Delete     bool
+}
+
+// Formats tasks are exported and imported in.
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// ImportRecord is one task read from an import file, Row counting from 1.
+// Error says why the row could not be read, when it could not.
+type ImportRecord struct {
+	Row   int
+	Task  Task
+	Error string
+}
+
+// What an import did, or would do on a dry run, with a row.
+const (
+	ImportCreated = "created"
+	ImportUpdated = "updated"
+	ImportFailed  = "failed"
+)
+
+type ImportRow struct {
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id,omitempty"`
+	ID         string `json:"id,omitempty"`
+	Action     string `json:"action"`
+	Error      string `json:"error,omitempty"`
+}
+
+type ImportResult struct {
+	DryRun  bool        `json:"dry_run"`
+	Created int         `json:"created"`
+	Updated int         `json:"updated"`
+	Failed  int         `json:"failed"`
+	Rows    []ImportRow `json:"rows"`
+}
+
+// TaskFilter narrows the tasks of GET /tasks; empty fields match every
+// task. Saved views keep it as it was given, so a due range in words such
+// as "today" to "in 7 days" moves along with time.
+type TaskFilter struct {
+	Statuses []string `bson:"statuses,omitempty" json:"statuses,omitempty"`
+	Tags     []string `bson:"tags,omitempty" json:"tags,omitempty"`
+	MatchAll bool     `bson:"match_all,omitempty" json:"match_all,omitempty"`
+	// DueFrom and DueTo bound the due date, in words or as dates read in
+	// the user's timezone. A DueTo naming a day includes that day.
+	DueFrom string `bson:"due_from,omitempty" json:"due_from,omitempty"`
+	DueTo   string `bson:"due_to,omitempty" json:"due_to,omitempty"`
+	// AssigneeID is a user ID, "me" or "none".
+	AssigneeID string `bson:"assignee_id,omitempty" json:"assignee_id,omitempty"`
+	// Text is searched for in titles and descriptions.
+	Text string `bson:"text,omitempty" json:"text,omitempty"`
+	// Priorities are the priority levels to match.
+	Priorities []string `bson:"priorities,omitempty" json:"priorities,omitempty"`
+	// Fields match custom field values by key, read by the type of the
+	// field: text is searched for, other types must be equal.
+	Fields map[string]string `bson:"fields,omitempty" json:"fields,omitempty"`
+	// Sort is "title", "due_date", "created_at" or a custom field as
+	// "field.<key>", descending with a leading "-". Empty is board order.
+	Sort string `bson:"sort,omitempty" json:"sort,omitempty"`
+	// Due is the due range resolved for the repository, and Limit and
+	// Offset the page of the list; a zero Limit is every task.
+	Due    DueWindow `bson:"-" json:"-"`
+	Limit  int       `bson:"-" json:"-"`
+	Offset int       `bson:"-" json:"-"`
+	// FieldValues are the Fields resolved for the repository.
+	FieldValues map[string]interface{} `bson:"-" json:"-"`
+}
+
+const (
+	AssigneeMe   = "me"
+	AssigneeNone = "none"
+)
+
+// Types of a custom field.
+const (
+	FieldText   = "text"
+	FieldNumber = "number"
+	FieldDate   = "date"
+	FieldEnum   = "enum"
+	FieldUser   = "user"
+)
+
+// CustomFieldTypes lists the types a custom field can have.
+var CustomFieldTypes = []string{FieldText, FieldNumber, FieldDate, FieldEnum, FieldUser}
+
+// CustomField is an attribute the admins of a workspace define for its
+// tasks, which hold its value under Key. Numbers are stored as floats,
+// dates as midnight UTC of the day, users as their IDs and enums as one of
+// the Options.
+type CustomField struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	UserID      primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty" json:"workspace_id,omitempty"`
+	Key         string             `bson:"key" json:"key"`
+	Name        string             `bson:"name" json:"name"`
+	Type        string             `bson:"type" json:"type"`
+	Options     []string           `bson:"options,omitempty" json:"options,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// View is a named, saved TaskFilter. Pinned views come first in the list,
+// which carries the live number of tasks each one matches.
+type View struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
 	UserID      primitive.ObjectID `bson:"user_id,omitempty" json:"-"`
-	Title       string             `json:"title"`
-	Description string             `json:"description"`
-	DueDate     time.Time          `json:"duedate"`
-	Status      string             `json:"status"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty" json:"workspace_id,omitempty"`
+	Name        string             `bson:"name" json:"name"`
+	Filter      TaskFilter         `bson:"filter" json:"filter"`
+	Pinned      bool               `bson:"pinned" json:"pinned"`
+	CreatedAt   time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	Count       int64              `bson:"-" json:"count"`
+}
+
+// TimeEntry is time a user spent on a task, either tracked with a timer or
+// entered by hand. A running timer has no End yet; a user runs one at most.
+type TimeEntry struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	TaskID  primitive.ObjectID `bson:"task_id" json:"task_id"`
+	UserID  primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Start   time.Time          `bson:"start" json:"start"`
+	End     time.Time          `bson:"end,omitempty" json:"end,omitempty"`
+	Seconds int64              `bson:"seconds" json:"seconds"`
+	Running bool               `bson:"running" json:"running"`
+	Manual  bool               `bson:"manual" json:"manual"`
+	Note    string             `bson:"note,omitempty" json:"note,omitempty"`
+}
+
+type TimeEntryFilter struct {
+	UserID string
+	TaskID string
+	From   time.Time
+	To     time.Time
+}
+
+// TimesheetRow is the time spent on one task, on one day or, with Day
+// empty, over the whole timesheet.
+type TimesheetRow struct {
+	Day     string             `json:"day,omitempty"`
+	TaskID  primitive.ObjectID `json:"task_id"`
+	Title   string             `json:"title"`
+	Seconds int64              `json:"seconds"`
+}
+
+type Timesheet struct {
+	From         time.Time      `json:"from"`
+	To           time.Time      `json:"to"`
+	Days         []TimesheetRow `json:"days"`
+	Tasks        []TimesheetRow `json:"tasks"`
+	TotalSeconds int64          `json:"total_seconds"`
+}
+
+// CalendarFeed is a user's secret iCalendar feed of due dates. Only a hash
+// of the token is stored; the token itself is shown once, when generated.
+// Timezone is the IANA zone dates are laid out in; when empty they follow the
+// time zone of the user.
+type CalendarFeed struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"-"`
+	TokenHash string             `bson:"token_hash" json:"-"`
+	Token     string             `bson:"-" json:"token,omitempty"`
+	Timezone  string             `bson:"timezone" json:"timezone"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// Components a feed renders tasks as.
+const (
+	CalendarEvent = "event"
+	CalendarTodo  = "todo"
+)
+
+// CalendarFilter narrows a feed to the tasks carrying any of Tags and in any
+// of Statuses; As picks the component they are rendered as.
+type CalendarFilter struct {
+	Tags     []string
+	Statuses []string
+	As       string
 }
 
 type User struct {
@@ -20,30 +740,357 @@ type User struct {
 	Email    string             `bson:"email" json:"email"`
 	Password string             `bson:"password" json:"-"`
 	Role     string             `bson:"role" json:"role"`
+	// Timezone is the IANA time zone dates are read and shown in for the
+	// user; empty means UTC.
+	Timezone string `bson:"timezone,omitempty" json:"timezone,omitempty"`
+	// NextWeights are the user's own weights for GET /tasks/next, nil for
+	// DefaultNextWeights.
+	NextWeights *NextWeights `bson:"next_weights,omitempty" json:"next_weights,omitempty"`
+}
+
+// NextWeights weigh the factors GET /tasks/next scores open tasks by. Each
+// factor is worth between 0 and 1 before it is weighed; being blocked
+// counts against a task.
+type NextWeights struct {
+	Priority float64 `bson:"priority" json:"priority"`
+	Due      float64 `bson:"due" json:"due"`
+	Blocked  float64 `bson:"blocked" json:"blocked"`
+	Age      float64 `bson:"age" json:"age"`
+}
+
+// DefaultNextWeights are the weights of users who have not set their own.
+var DefaultNextWeights = NextWeights{Priority: 3, Due: 4, Blocked: 5, Age: 1}
+
+const (
+	FactorPriority = "priority"
+	FactorDue      = "due"
+	FactorBlocked  = "blocked"
+	FactorAge      = "age"
+)
+
+// NextTask is an open task ranked by GET /tasks/next, with the factors of
+// its score.
+type NextTask struct {
+	Task    Task          `json:"task"`
+	Score   float64       `json:"score"`
+	Factors []ScoreFactor `json:"factors"`
+}
+
+// ScoreFactor explains one part of a score: the factor's Value between 0
+// and 1, the Weight it had and the Points it added, negative when it
+// counts against the task.
+type ScoreFactor struct {
+	Factor string  `json:"factor"`
+	Value  float64 `json:"value"`
+	Weight float64 `json:"weight"`
+	Points float64 `json:"points"`
+	Reason string  `json:"reason"`
 }
 type TaskRepositoryInterface interface {
 	CreateTask(newtask *Task, userid string) error
 	GetTask(id string) (*Task, error)
 	GetTasks(userid string) (*[]Task, error)
+	FilterTasks(userid string, filter TaskFilter) (*[]Task, error)
+	CountTasks(userid string, filter TaskFilter) (int64, error)
+	GetProjectTasks(projectid string) (*[]Task, error)
+	GetAssignedTasks(userid string) (*[]Task, error)
+	GetDueTasks(userid string, window DueWindow) (*[]Task, error)
+	GetOpenTasks(userid string) (*[]Task, error)
+	GetNewlyOverdue(window DueWindow) (*[]Task, error)
+	MarkOverdue(id string, duedate time.Time) error
 	UpdateTask(id string, updatedtask *Task) error
+	UpdateSeries(seriesid string, ownerid string, updatedtask *Task) error
+	MarkNextScheduled(id string) (bool, error)
+	AssignTask(id string, assigneeid string, event TaskEvent) error
 	RemoveTask(id string) error
+	GetStats(filter StatsFilter) (*TaskStats, error)
+	GetColumn(column BoardColumn) (*[]Task, error)
+	LastRank(column BoardColumn) (float64, error)
+	MoveTask(id string, status string, rank float64, completedat time.Time) error
+	RebalanceColumn(column BoardColumn, step float64) error
+	DenseColumns(gap float64) ([]BoardColumn, error)
+	GetTasksByIDs(ids []primitive.ObjectID) (*[]Task, error)
+	AddBlocker(id string, blockerid string) error
+	RemoveBlocker(id string, blockerid string) error
+	ClearBlocker(blockerid string) error
+	GetBlockers(id string) (*[]Task, error)
+	GetDependencyGraph(id string) (*[]Task, error)
+	WriteTasks(writes []TaskWrite) error
+	StreamTasks(userid string, each func(*Task) error) error
+	GetImportedTasks(userid string, keys []string) (*[]Task, error)
+	InWorkspace(workspaceid primitive.ObjectID) TaskRepositoryInterface
+}
+type TagRepositoryInterface interface {
+	CreateTag(newtag *Tag, userid string) error
+	GetTag(id string) (*Tag, error)
+	GetTags(userid string) (*[]Tag, error)
+	UpdateTag(id string, updatedtag *Tag) error
+	RemoveTag(id string) error
+	MergeTags(sourceid string, targetid string) error
+	InWorkspaces(workspaceids []primitive.ObjectID) TagRepositoryInterface
+}
+type CommentRepositoryInterface interface {
+	CreateComment(newcomment *Comment) error
+	GetComment(id string) (*Comment, error)
+	GetComments(taskid string) (*[]Comment, error)
+	UpdateComment(id string, updatedcomment *Comment) error
+	RemoveComments(taskid string, ids []string) error
+	RemoveTaskComments(taskid string) error
+}
+type AttachmentRepositoryInterface interface {
+	CreateAttachment(newattachment *Attachment, content io.Reader) error
+	GetAttachment(id string) (*Attachment, error)
+	GetAttachments(taskid string) (*[]Attachment, error)
+	OpenAttachment(attachment *Attachment) (io.ReadSeekCloser, error)
+	RemoveAttachment(id string) error
+	RemoveTaskAttachments(taskid string) error
+}
+type ReminderRepositoryInterface interface {
+	CreateReminder(newreminder *Reminder) error
+	GetReminder(id string) (*Reminder, error)
+	GetReminders(taskid string, userid string) (*[]Reminder, error)
+	RemoveReminder(id string) error
+	RemoveTaskReminders(taskid string) error
+	RescheduleReminders(taskid string, duedate time.Time) error
+	ClaimReminder(now time.Time, lease time.Duration) (*Reminder, error)
+	FinishReminder(id string, status string, failure string) error
+}
+type NotificationRepositoryInterface interface {
+	CreateNotification(notification *Notification) error
+	GetNotifications(userid string) (*[]Notification, error)
+	ReadNotification(id string, userid string) error
+}
+type WebhookRepositoryInterface interface {
+	CreateWebhook(newwebhook *Webhook) error
+	GetWebhook(id string) (*Webhook, error)
+	GetWebhooks(userid string) (*[]Webhook, error)
+	RemoveWebhook(id string) error
+	FindSubscribers(event string, userids []primitive.ObjectID) (*[]Webhook, error)
+	CreateDeliveries(deliveries []WebhookDelivery) error
+	GetDelivery(id string) (*WebhookDelivery, error)
+	GetDeliveries(filter DeliveryFilter) (*[]WebhookDelivery, error)
+	ClaimDelivery(now time.Time, lease time.Duration) (*WebhookDelivery, error)
+	UpdateDelivery(id string, delivery *WebhookDelivery) error
+}
+type TimeEntryRepositoryInterface interface {
+	CreateTimeEntry(newentry *TimeEntry) error
+	GetTimeEntry(id string) (*TimeEntry, error)
+	GetTimeEntries(filter TimeEntryFilter) (*[]TimeEntry, error)
+	GetRunningTimer(userid string) (*TimeEntry, error)
+	StopTimer(userid string, taskid string, end time.Time) (*TimeEntry, error)
+	RemoveTimeEntry(id string) error
+	RemoveTaskEntries(taskid string) error
+}
+type CalendarRepositoryInterface interface {
+	SaveToken(userid string, tokenhash string) (*CalendarFeed, error)
+	SetTimezone(userid string, timezone string) (*CalendarFeed, error)
+	GetFeed(userid string) (*CalendarFeed, error)
+	GetFeedByToken(tokenhash string) (*CalendarFeed, error)
+}
+type ProjectRepositoryInterface interface {
+	CreateProject(newproject *Project) error
+	GetProject(id string) (*Project, error)
+	GetProjects(userid string) (*[]Project, error)
+	UpdateProject(id string, updatedproject *Project) error
+	RemoveProject(id string) error
+	InWorkspace(workspaceid primitive.ObjectID) ProjectRepositoryInterface
+}
+type WorkspaceRepositoryInterface interface {
+	CreateWorkspace(newworkspace *Workspace) error
+	GetWorkspace(id string) (*Workspace, error)
+	GetWorkspaces(userid string) (*[]Workspace, error)
+	UpdateWorkspace(id string, updatedworkspace *Workspace) error
+	RemoveWorkspace(id string) error
+}
+type TemplateRepositoryInterface interface {
+	CreateTemplate(newtemplate *Template, userid string) error
+	GetTemplate(id string) (*Template, error)
+	GetTemplates(userid string) (*[]Template, error)
+	UpdateTemplate(id string, updatedtemplate *Template) error
+	RemoveTemplate(id string) error
+	InWorkspace(workspaceid primitive.ObjectID) TemplateRepositoryInterface
+}
+type CustomFieldRepositoryInterface interface {
+	CreateField(newfield *CustomField, userid string) error
+	GetField(id string) (*CustomField, error)
+	GetFields(userid string) (*[]CustomField, error)
+	UpdateField(id string, updatedfield *CustomField) error
+	RemoveField(id string) error
+	InWorkspace(workspaceid primitive.ObjectID) CustomFieldRepositoryInterface
+}
+type ViewRepositoryInterface interface {
+	CreateView(newview *View, userid string) error
+	GetView(id string) (*View, error)
+	GetViews(userid string) (*[]View, error)
+	UpdateView(id string, updatedview *View) error
+	PinView(id string, pinned bool) error
+	RemoveView(id string) error
+	InWorkspace(workspaceid primitive.ObjectID) ViewRepositoryInterface
+}
+type AutomationRepositoryInterface interface {
+	CreateAutomation(newautomation *Automation, userid string) error
+	GetAutomation(id string) (*Automation, error)
+	GetAutomations(userid string) (*[]Automation, error)
+	UpdateAutomation(id string, updatedautomation *Automation) error
+	RemoveAutomation(id string) error
+	GetTriggered(event string, task *Task) (*[]Automation, error)
+	LogRun(run *AutomationRun) error
+	GetRuns(automationid string) (*[]AutomationRun, error)
+	InWorkspace(workspaceid primitive.ObjectID) AutomationRepositoryInterface
 }
 type UserRepositoryInterface interface {
 	Register(user *User) error
 	Login(user *User) (string, error)
 	GetUser(email string) (*User, error)
+	GetUserByID(id string) (*User, error)
 	GetUsers() (*[]User, error)
+	SetTimezone(id string, timezone string) (*User, error)
+	SetNextWeights(id string, weights NextWeights) (*User, error)
 }
 type TaskUsecaseInterface interface {
 	CreateTask(newtask *Task, userid string) error
-	GetTask(id string) (*Task, error)
+	GetTask(id string, userID string) (*Task, error)
+	GetEditableTask(id string, userID string) (*Task, error)
+	GetTasksByIDs(ids []primitive.ObjectID, userID string) (*[]Task, error)
 	GetTasks(userID string) (*[]Task, error)
-	UpdateTask(id string, updatedTask *Task) error
-	RemoveTask(id string) error
+	FilterTasks(userID string, filter TaskFilter) (*[]Task, error)
+	CountTasks(userID string, filter TaskFilter) (int64, error)
+	GetProjectTasks(projectID string, userID string) (*[]Task, error)
+	GetAssignedTasks(userID string) (*[]Task, error)
+	GetDueTasks(userID string, view string, days int) (*[]Task, error)
+	NextTasks(userID string, limit int) (*[]NextTask, error)
+	UpdateTask(id string, updatedTask *Task, userID string) error
+	UpdateSeries(id string, updatedTask *Task, userID string) error
+	GetOccurrences(id string, userID string, limit int) ([]time.Time, error)
+	AssignTask(id string, assigneeID string, userID string) (*Task, error)
+	RemoveTask(id string, userID string) error
+	GetStats(userID string, filter StatsFilter) (*TaskStats, error)
+	GetAllStats(filter StatsFilter) (*TaskStats, error)
+	MoveTask(id string, move TaskMove, userID string) (*Task, error)
+	AddBlocker(id string, blockerID string, userID string) (*Task, error)
+	RemoveBlocker(id string, blockerID string, userID string) (*Task, error)
+	GetDependencyGraph(id string, userID string) (*TaskGraph, error)
+	BulkTasks(request BulkRequest, userID string) (*BulkResponse, error)
+	ExportTasks(userID string, each func(*Task) error) error
+	ImportTasks(records []ImportRecord, userID string, dryRun bool) (*ImportResult, error)
+	PublishOverdue(now time.Time) (int, error)
+	InWorkspace(workspaceID string) (TaskUsecaseInterface, error)
+	WithEvents(events EventPublisher) TaskUsecaseInterface
+}
+type TagUsecaseInterface interface {
+	CreateTag(newtag *Tag, userid string) error
+	GetTags(userid string) (*[]Tag, error)
+	UpdateTag(id string, updatedtag *Tag, userid string) error
+	RemoveTag(id string, userid string) error
+	MergeTags(sourceid string, targetid string, userid string) error
+}
+type CommentUsecaseInterface interface {
+	CreateComment(taskid string, newcomment *Comment, userid string) error
+	GetComments(taskid string, userid string) (*[]Comment, error)
+	UpdateComment(taskid string, id string, body string, userid string) (*Comment, error)
+	RemoveComment(taskid string, id string, userid string) error
+	AdminRemoveComment(taskid string, id string) error
+	InWorkspace(workspaceID string) (CommentUsecaseInterface, error)
+}
+type AttachmentUsecaseInterface interface {
+	UploadAttachment(taskid string, filename string, contenttype string, content io.Reader, userid string) (*Attachment, error)
+	GetAttachments(taskid string, userid string) (*[]Attachment, error)
+	OpenAttachment(taskid string, id string, userid string) (*Attachment, io.ReadSeekCloser, error)
+	RemoveAttachment(taskid string, id string, userid string) error
+	InWorkspace(workspaceID string) (AttachmentUsecaseInterface, error)
+}
+type ReminderUsecaseInterface interface {
+	CreateReminder(taskid string, newreminder *Reminder, userid string) error
+	GetReminders(taskid string, userid string) (*[]Reminder, error)
+	RemoveReminder(taskid string, id string, userid string) error
+	GetNotifications(userid string) (*[]Notification, error)
+	ReadNotification(id string, userid string) error
+	InWorkspace(workspaceID string) (ReminderUsecaseInterface, error)
+}
+type WebhookUsecaseInterface interface {
+	CreateWebhook(newwebhook *Webhook, userid string) error
+	GetWebhooks(userid string) (*[]Webhook, error)
+	RemoveWebhook(id string, userid string) error
+	GetDeliveries(filter DeliveryFilter) (*[]WebhookDelivery, error)
+	ReplayDelivery(id string) (*WebhookDelivery, error)
+}
+type StreamUsecaseInterface interface {
+	Subscribe(userid string, lastEventID string) (<-chan StreamEvent, func(), error)
+}
+type TimeUsecaseInterface interface {
+	StartTimer(taskid string, userid string) (*TimeEntry, error)
+	StopTimer(taskid string, userid string) (*TimeEntry, error)
+	GetRunningTimer(userid string) (*TimeEntry, error)
+	CreateTimeEntry(taskid string, newentry *TimeEntry, userid string) error
+	GetTimeEntries(taskid string, userid string) (*[]TimeEntry, error)
+	RemoveTimeEntry(taskid string, id string, userid string) error
+	GetTimesheet(userid string, from time.Time, to time.Time) (*Timesheet, error)
+	InWorkspace(workspaceID string) (TimeUsecaseInterface, error)
+}
+type CalendarUsecaseInterface interface {
+	GetFeed(userID string) (*CalendarFeed, error)
+	RegenerateToken(userID string) (*CalendarFeed, error)
+	SetTimezone(userID string, timezone string) (*CalendarFeed, error)
+	RenderFeed(token string, filter CalendarFilter) (string, error)
+}
+type ProjectUsecaseInterface interface {
+	CreateProject(newproject *Project, userid string) error
+	GetProject(id string, userid string) (*Project, error)
+	GetProjects(userid string) (*[]Project, error)
+	UpdateProject(id string, updatedproject *Project, userid string) error
+	RemoveProject(id string, userid string) error
+	AddMember(id string, email string, role string, userid string) (*Project, error)
+	RemoveMember(id string, memberid string, userid string) (*Project, error)
+	InWorkspace(workspaceID string) (ProjectUsecaseInterface, error)
+}
+type WorkspaceUsecaseInterface interface {
+	CreateWorkspace(newworkspace *Workspace, userid string) error
+	GetWorkspace(id string, userid string) (*Workspace, error)
+	GetWorkspaces(userid string) (*[]Workspace, error)
+	UpdateWorkspace(id string, updatedworkspace *Workspace, userid string) (*Workspace, error)
+	RemoveWorkspace(id string, userid string) error
+	AddMember(id string, email string, role string, userid string) (*Workspace, error)
+	RemoveMember(id string, memberid string, userid string) (*Workspace, error)
+	SwitchWorkspace(id string, userid string) (string, error)
+}
+type TemplateUsecaseInterface interface {
+	CreateTemplate(newtemplate *Template, userid string) error
+	GetTemplate(id string, userid string) (*Template, error)
+	GetTemplates(userid string) (*[]Template, error)
+	UpdateTemplate(id string, updatedtemplate *Template, userid string) error
+	RemoveTemplate(id string, userid string) error
+	Instantiate(id string, instance TemplateInstance, userid string) (*TemplateResult, error)
+	InWorkspace(workspaceID string) (TemplateUsecaseInterface, error)
+}
+type CustomFieldUsecaseInterface interface {
+	CreateField(newfield *CustomField, userid string) error
+	GetFields(userid string) (*[]CustomField, error)
+	UpdateField(id string, updatedfield *CustomField, userid string) error
+	RemoveField(id string, userid string) error
+	InWorkspace(workspaceID string) (CustomFieldUsecaseInterface, error)
+}
+type ViewUsecaseInterface interface {
+	CreateView(newview *View, userid string) error
+	GetViews(userid string) (*[]View, error)
+	UpdateView(id string, updatedview *View, userid string) error
+	PinView(id string, pinned bool, userid string) (*View, error)
+	RemoveView(id string, userid string) error
+	GetViewTasks(id string, userid string, limit int, offset int) (*[]Task, int64, error)
+	InWorkspace(workspaceID string) (ViewUsecaseInterface, error)
+}
+type AutomationUsecaseInterface interface {
+	CreateAutomation(newautomation *Automation, userid string) error
+	GetAutomations(userid string) (*[]Automation, error)
+	UpdateAutomation(id string, updatedautomation *Automation, userid string) error
+	RemoveAutomation(id string, userid string) error
+	GetRuns(id string, userid string) (*[]AutomationRun, error)
+	InWorkspace(workspaceID string) (AutomationUsecaseInterface, error)
 }
 type UserUsecaseInterface interface {
 	Register(user *User) error
 	Login(user *User) (string, error)
 	GetUser(email string) (*User, error)
 	GetUsers() (*[]User, error)
+	SetTimezone(userID string, timezone string) (*User, error)
+	SetNextWeights(userID string, weights NextWeights) (*User, error)
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"task8/domain"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
@@ -32,49 +33,120 @@ func UserAuthorizaiton() gin.HandlerFunc {
 			return
 		}
 
-		secret := []byte(os.Getenv("secret"))
+		authorizeToken(ctx, authstring[1])
+	}
 
-		token, err := jwt.Parse(authstring[1], func(token *jwt.Token) (interface{}, error) {
+}
 
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return secret, nil
+// StreamAuthorization is UserAuthorizaiton for the event streams. Browsers
+// cannot set headers on EventSource and WebSocket connections, so the token
+// may also come as ?access_token=.
+func StreamAuthorization() gin.HandlerFunc {
 
-		})
+	header := UserAuthorizaiton()
+	return func(ctx *gin.Context) {
 
-		if err != nil {
-			ctx.JSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("Failed to parse token: %v", err)})
+		token := ctx.Query("access_token")
+		if token == "" || ctx.GetHeader("Authorization") != "" {
+			header(ctx)
+			return
+		}
+		authorizeToken(ctx, token)
+	}
+}
+
+// BasicAuthorization is UserAuthorizaiton for clients that only speak HTTP
+// Basic authentication, such as calendar apps. The email and password are
+// logged in with on every request.
+func BasicAuthorization(users domain.UserUsecaseInterface) gin.HandlerFunc {
+
+	return func(ctx *gin.Context) {
+
+		email, password, ok := ctx.Request.BasicAuth()
+		if !ok {
+			ctx.Header("WWW-Authenticate", `Basic realm="task8", charset="UTF-8"`)
+			ctx.JSON(401, gin.H{"error": "Authorization header is required"})
 			ctx.Abort()
 			return
 		}
-		if !token.Valid {
-			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid JWT"})
+		token, err := users.Login(&domain.User{Email: email, Password: password})
+		if err != nil {
+			ctx.Header("WWW-Authenticate", `Basic realm="task8", charset="UTF-8"`)
+			ctx.JSON(401, gin.H{"error": "Invalid email or password"})
 			ctx.Abort()
 			return
 		}
+		authorizeToken(ctx, token)
+	}
+}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
+func authorizeToken(ctx *gin.Context, tokenstring string) {
 
-		if !ok {
-			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			ctx.Abort()
-			return
+	secret := []byte(os.Getenv("secret"))
+
+	token, err := jwt.Parse(tokenstring, func(token *jwt.Token) (interface{}, error) {
+
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		userID, userIDExists := claims["user_id"]
-		email, emailExists := claims["email"]
-		role, roleExists := claims["role"]
+		return secret, nil
+
+	})
+
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("Failed to parse token: %v", err)})
+		ctx.Abort()
+		return
+	}
+	if !token.Valid {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid JWT"})
+		ctx.Abort()
+		return
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+		ctx.Abort()
+		return
+	}
+	userID, userIDExists := claims["user_id"]
+	email, emailExists := claims["email"]
+	role, roleExists := claims["role"]
+
+	if !userIDExists || !emailExists || !roleExists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Token does not contain necessary claims"})
+		ctx.Abort()
+		return
+	}
 
-		if !userIDExists || !emailExists || !roleExists {
-			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Token does not contain necessary claims"})
+	ctx.Set("user_id", userID)
+	ctx.Set("email", email)
+	ctx.Set("role", role)
+	if workspaceID, ok := claims["workspace_id"].(string); ok {
+		ctx.Set("workspace_id", workspaceID)
+	}
+	ctx.Next()
+}
+
+// WorkspaceAuthorization checks that the user still is a member of the
+// active workspace of their token, which may have been issued before they
+// were removed from it.
+func WorkspaceAuthorization(workspaces domain.WorkspaceUsecaseInterface) gin.HandlerFunc {
+
+	return func(ctx *gin.Context) {
+
+		workspaceID := ctx.GetString("workspace_id")
+		if workspaceID == "" {
+			ctx.Next()
+			return
+		}
+		if _, err := workspaces.GetWorkspace(workspaceID, ctx.GetString("user_id")); err != nil {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			ctx.Abort()
 			return
 		}
-
-		ctx.Set("user_id", userID)
-		ctx.Set("email", email)
-		ctx.Set("role", role)
 		ctx.Next()
 	}
-
 }
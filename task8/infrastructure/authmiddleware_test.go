@@ -0,0 +1,63 @@
+package infrastructure
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"task8/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupWorkspaceRouter(workspaces *mocks.WorkspaceUsecaseInterface) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/tasks", UserAuthorizaiton(), WorkspaceAuthorization(workspaces), func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, ctx.GetString("workspace_id"))
+	})
+	return router
+}
+
+func TestWorkspaceAuthorization(t *testing.T) {
+	t.Setenv("secret", "s3cret")
+	workspaces := new(mocks.WorkspaceUsecaseInterface)
+	router := setupWorkspaceRouter(workspaces)
+
+	request := func(workspaceID string) *httptest.ResponseRecorder {
+		token, err := NewJWTService().NewToken("userID", "user@example.com", "user", workspaceID)
+		assert.NoError(t, err)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tasks", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("members use the workspace of their token", func(t *testing.T) {
+		workspaces.On("GetWorkspace", "workspaceID", "userID").Return(nil, nil).Once()
+
+		w := request("workspaceID")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "workspaceID", w.Body.String())
+	})
+
+	t.Run("removed members are turned away", func(t *testing.T) {
+		workspaces.On("GetWorkspace", "workspaceID", "userID").Return(nil, errors.New("workspace not found")).Once()
+
+		w := request("workspaceID")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{"error":"workspace not found"}`, w.Body.String())
+	})
+
+	t.Run("tokens without a workspace are personal", func(t *testing.T) {
+		w := request("")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+	workspaces.AssertExpectations(t)
+}
@@ -0,0 +1,131 @@
+package infrastructure
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// retainedEvents is how many past events a reconnecting client can resume from.
+	retainedEvents = 1000
+	// subscriberBuffer is how many live events a slow client may lag behind
+	// before it is dropped and has to reconnect with Last-Event-ID.
+	subscriberBuffer = 64
+)
+
+type subscriber struct {
+	userID primitive.ObjectID
+	events chan domain.StreamEvent
+}
+
+type memoryBroker struct {
+	mu sync.Mutex
+	// epoch tells the event IDs of this process from those handed out
+	// before a restart, when the sequence started over.
+	epoch       string
+	seq         int64
+	history     []domain.StreamEvent
+	subscribers map[*subscriber]struct{}
+}
+
+// NewMemoryBroker fans events out within this process only. Running several
+// instances needs a broker fed from a shared source such as a change stream.
+func NewMemoryBroker() domain.EventBroker {
+	return &memoryBroker{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+func (mb *memoryBroker) Publish(event domain.StreamEvent) {
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.seq++
+	event.ID = mb.eventID(mb.seq)
+	mb.history = append(mb.history, event)
+	if len(mb.history) > retainedEvents {
+		mb.history = mb.history[len(mb.history)-retainedEvents:]
+	}
+
+	for sub := range mb.subscribers {
+		if !inAudience(event, sub.userID) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			delete(mb.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+func (mb *memoryBroker) Subscribe(userid string, lastEventID string) (<-chan domain.StreamEvent, func()) {
+
+	uid, _ := primitive.ObjectIDFromHex(userid)
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	var replay []domain.StreamEvent
+	if lastEventID != "" {
+		replay = mb.since(lastEventID, uid)
+	}
+
+	sub := &subscriber{userID: uid, events: make(chan domain.StreamEvent, len(replay)+subscriberBuffer)}
+	for _, event := range replay {
+		sub.events <- event
+	}
+	mb.subscribers[sub] = struct{}{}
+
+	cancel := func() {
+		mb.mu.Lock()
+		defer mb.mu.Unlock()
+		if _, ok := mb.subscribers[sub]; ok {
+			delete(mb.subscribers, sub)
+			close(sub.events)
+		}
+	}
+	return sub.events, cancel
+}
+
+// since lists the retained events for the user after lastEventID. An ID
+// from before a restart, or older than the retained events, cannot be
+// resumed from, so the client is told to resync instead.
+func (mb *memoryBroker) since(lastEventID string, userID primitive.ObjectID) []domain.StreamEvent {
+
+	epoch, seq, _ := strings.Cut(lastEventID, "-")
+	last, err := strconv.ParseInt(seq, 10, 64)
+	first := mb.seq - int64(len(mb.history)) + 1
+	if err != nil || epoch != mb.epoch || last > mb.seq || last < first-1 {
+		return []domain.StreamEvent{{ID: mb.eventID(mb.seq), Event: domain.EventStreamResync, At: time.Now()}}
+	}
+	var replay []domain.StreamEvent
+	for _, event := range mb.history[last-first+1:] {
+		if inAudience(event, userID) {
+			replay = append(replay, event)
+		}
+	}
+	return replay
+}
+
+// eventID is the ID of the seq-th event of this process.
+func (mb *memoryBroker) eventID(seq int64) string {
+	return mb.epoch + "-" + strconv.FormatInt(seq, 10)
+}
+
+func inAudience(event domain.StreamEvent, userID primitive.ObjectID) bool {
+	for _, id := range event.Audience {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
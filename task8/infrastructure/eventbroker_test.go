@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"task8/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMemoryBroker(t *testing.T) {
+	ann := primitive.NewObjectID()
+	bob := primitive.NewObjectID()
+
+	t.Run("delivers only to the audience", func(t *testing.T) {
+		broker := NewMemoryBroker()
+		annEvents, cancelAnn := broker.Subscribe(ann.Hex(), "")
+		defer cancelAnn()
+		bobEvents, cancelBob := broker.Subscribe(bob.Hex(), "")
+		defer cancelBob()
+
+		broker.Publish(domain.StreamEvent{Event: domain.EventTaskCreated, Audience: []primitive.ObjectID{ann}})
+
+		event := <-annEvents
+		assert.Equal(t, broker.(*memoryBroker).eventID(1), event.ID)
+		assert.Len(t, bobEvents, 0)
+	})
+
+	t.Run("resumes after the last event ID", func(t *testing.T) {
+		broker := NewMemoryBroker()
+		for i := 0; i < 3; i++ {
+			broker.Publish(domain.StreamEvent{Event: domain.EventTaskUpdated, Audience: []primitive.ObjectID{ann}})
+		}
+		broker.Publish(domain.StreamEvent{Event: domain.EventTaskUpdated, Audience: []primitive.ObjectID{bob}})
+
+		mb := broker.(*memoryBroker)
+		events, cancel := broker.Subscribe(ann.Hex(), mb.eventID(1))
+		defer cancel()
+
+		assert.Equal(t, mb.eventID(2), (<-events).ID)
+		assert.Equal(t, mb.eventID(3), (<-events).ID)
+		assert.Len(t, events, 0)
+	})
+
+	t.Run("asks for a resync after a restart", func(t *testing.T) {
+		before := NewMemoryBroker()
+		before.Publish(domain.StreamEvent{Event: domain.EventTaskUpdated, Audience: []primitive.ObjectID{ann}})
+		last := before.(*memoryBroker).eventID(1)
+		broker := &memoryBroker{epoch: "restarted", subscribers: map[*subscriber]struct{}{}}
+		for i := 0; i < 3; i++ {
+			broker.Publish(domain.StreamEvent{Event: domain.EventTaskUpdated, Audience: []primitive.ObjectID{ann}})
+		}
+
+		events, cancel := broker.Subscribe(ann.Hex(), last)
+		defer cancel()
+
+		event := <-events
+		assert.Equal(t, domain.EventStreamResync, event.Event)
+		assert.Equal(t, "restarted-3", event.ID)
+		assert.Len(t, events, 0)
+	})
+
+	t.Run("asks for a resync past the retained events", func(t *testing.T) {
+		broker := NewMemoryBroker()
+		for i := 0; i < retainedEvents+2; i++ {
+			broker.Publish(domain.StreamEvent{Event: domain.EventTaskUpdated, Audience: []primitive.ObjectID{ann}})
+		}
+
+		events, cancel := broker.Subscribe(ann.Hex(), broker.(*memoryBroker).eventID(1))
+		defer cancel()
+
+		assert.Equal(t, domain.EventStreamResync, (<-events).Event)
+	})
+
+	t.Run("drops a subscriber that falls behind", func(t *testing.T) {
+		broker := NewMemoryBroker()
+		events, cancel := broker.Subscribe(ann.Hex(), "")
+		defer cancel()
+
+		for i := 0; i <= subscriberBuffer; i++ {
+			broker.Publish(domain.StreamEvent{Event: domain.EventTaskUpdated, Audience: []primitive.ObjectID{ann}})
+		}
+
+		received := 0
+		for range events {
+			received++
+		}
+		assert.Equal(t, subscriberBuffer, received)
+	})
+}
@@ -0,0 +1,151 @@
+package infrastructure
+
+import (
+	"strings"
+	"task8/domain"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the length RFC 5545 folds content lines at.
+const maxLineOctets = 75
+
+// Calendar builds an iCalendar (RFC 5545) document out of tasks. Times of
+// day are written in UTC; date-only due dates are written as dates.
+type Calendar struct {
+	location *time.Location
+	stamp    time.Time
+	body     strings.Builder
+}
+
+// NewCalendar starts a published calendar called name. stamp is the DTSTAMP
+// of every component, normally the time the document is made.
+func NewCalendar(name string, location *time.Location, stamp time.Time) *Calendar {
+
+	c := NewCalendarObject(location, stamp)
+	c.line("METHOD", "PUBLISH")
+	if name != "" {
+		c.line("X-WR-CALNAME", escapeText(name))
+	}
+	c.line("X-WR-TIMEZONE", location.String())
+	return c
+}
+
+// NewCalendarObject starts a calendar holding a single task, as stored in a
+// CalDAV collection.
+func NewCalendarObject(location *time.Location, stamp time.Time) *Calendar {
+
+	c := &Calendar{location: location, stamp: stamp}
+	c.line("BEGIN", "VCALENDAR")
+	c.line("VERSION", "2.0")
+	c.line("PRODID", "-//task8//tasks//EN")
+	c.line("CALSCALE", "GREGORIAN")
+	return c
+}
+
+// TaskUID is the UID of a task's component: the one a CalDAV client created
+// it with, or else one made from the task ID. It never changes, so calendar
+// clients update an entry rather than add another.
+func TaskUID(task *domain.Task) string {
+	if task.CalendarUID != "" {
+		return task.CalendarUID
+	}
+	return task.ID.Hex() + "@task8"
+}
+
+// AddEvent adds the task as a VEVENT at its due date.
+func (c *Calendar) AddEvent(task *domain.Task) {
+
+	c.line("BEGIN", "VEVENT")
+	c.taskLines(task)
+	if task.DueDateOnly {
+		c.dateLine("DTSTART", task.DueDate)
+		c.dateLine("DTEND", task.DueDate.AddDate(0, 0, 1))
+	} else {
+		c.line("DTSTART", formatUTC(task.DueDate))
+	}
+	c.line("END", "VEVENT")
+}
+
+// AddTodo adds the task as a VTODO due at its due date, if it has one.
+func (c *Calendar) AddTodo(task *domain.Task) {
+
+	c.line("BEGIN", "VTODO")
+	c.taskLines(task)
+	switch {
+	case task.DueDate.IsZero():
+	case task.DueDateOnly:
+		c.dateLine("DUE", task.DueDate)
+	default:
+		c.line("DUE", formatUTC(task.DueDate))
+	}
+	if domain.IsDone(task.Status) {
+		c.line("STATUS", "COMPLETED")
+		if !task.CompletedAt.IsZero() {
+			c.line("COMPLETED", formatUTC(task.CompletedAt))
+		}
+	} else {
+		c.line("STATUS", "NEEDS-ACTION")
+	}
+	c.line("END", "VTODO")
+}
+
+// String ends the calendar and returns the document.
+func (c *Calendar) String() string {
+	return c.body.String() + "END:VCALENDAR\r\n"
+}
+
+func (c *Calendar) taskLines(task *domain.Task) {
+
+	c.line("UID", TaskUID(task))
+	c.line("DTSTAMP", formatUTC(c.stamp))
+	if !task.CreatedAt.IsZero() {
+		c.line("CREATED", formatUTC(task.CreatedAt))
+	}
+	c.line("SUMMARY", escapeText(task.Title))
+	if task.Description != "" {
+		c.line("DESCRIPTION", escapeText(task.Description))
+	}
+	if len(task.Tags) > 0 {
+		categories := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			categories[i] = escapeText(tag)
+		}
+		c.line("CATEGORIES", strings.Join(categories, ","))
+	}
+}
+
+func (c *Calendar) dateLine(name string, t time.Time) {
+	c.line(name+";VALUE=DATE", t.UTC().Format("20060102"))
+}
+
+// line writes a content line, folded so that no line is longer than 75
+// octets without splitting a UTF-8 sequence.
+func (c *Calendar) line(name string, value string) {
+
+	content := name + ":" + value
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		c.body.WriteString(content[:cut])
+		c.body.WriteString("\r\n ")
+		content = content[cut:]
+		// The leading space of a continuation line counts too.
+		limit = maxLineOctets - 1
+	}
+	c.body.WriteString(content)
+	c.body.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "")
+
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
@@ -0,0 +1,75 @@
+package infrastructure
+
+import (
+	"strings"
+	"task8/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCalendar(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+	stamp := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	id, _ := primitive.ObjectIDFromHex("65e1a0000000000000000001")
+
+	t.Run("a date-only due date is a whole day", func(t *testing.T) {
+		calendar := NewCalendar("Tasks", berlin, stamp)
+		// March 31st, the day the clocks go forward in Berlin.
+		calendar.AddEvent(&domain.Task{ID: id, Title: "Release", DueDate: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), DueDateOnly: true})
+
+		document := calendar.String()
+		assert.True(t, strings.HasPrefix(document, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(document, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+		assert.Contains(t, document, "X-WR-TIMEZONE:Europe/Berlin\r\n")
+		assert.Contains(t, document, "UID:65e1a0000000000000000001@task8\r\n")
+		assert.Contains(t, document, "DTSTAMP:20240301T120000Z\r\n")
+		assert.Contains(t, document, "DTSTART;VALUE=DATE:20240331\r\n")
+		assert.Contains(t, document, "DTEND;VALUE=DATE:20240401\r\n")
+	})
+
+	t.Run("a time of day is written in UTC", func(t *testing.T) {
+		calendar := NewCalendar("", berlin, stamp)
+		calendar.AddEvent(&domain.Task{ID: id, Title: "Call", DueDate: time.Date(2024, 7, 1, 9, 30, 0, 0, berlin)})
+		calendar.AddEvent(&domain.Task{ID: id, Title: "Midnight", DueDate: time.Date(2024, 7, 2, 0, 0, 0, 0, berlin)})
+
+		assert.Contains(t, calendar.String(), "DTSTART:20240701T073000Z\r\n")
+		assert.Contains(t, calendar.String(), "DTSTART:20240701T220000Z\r\n")
+	})
+
+	t.Run("to-dos carry their status", func(t *testing.T) {
+		calendar := NewCalendar("Tasks", time.UTC, stamp)
+		calendar.AddTodo(&domain.Task{ID: id, Title: "Done", Status: "Done", DueDate: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), DueDateOnly: true, CompletedAt: time.Date(2024, 3, 3, 18, 0, 0, 0, time.UTC)})
+		calendar.AddTodo(&domain.Task{ID: primitive.NewObjectID(), Title: "Open", Status: "todo"})
+
+		document := calendar.String()
+		assert.Contains(t, document, "BEGIN:VTODO\r\n")
+		assert.Contains(t, document, "DUE;VALUE=DATE:20240304\r\n")
+		assert.Contains(t, document, "STATUS:COMPLETED\r\nCOMPLETED:20240303T180000Z\r\n")
+		assert.Contains(t, document, "STATUS:NEEDS-ACTION\r\n")
+		assert.Equal(t, 1, strings.Count(document, "DUE"))
+	})
+
+	t.Run("text is escaped and folded", func(t *testing.T) {
+		calendar := NewCalendar("Tasks", time.UTC, stamp)
+		calendar.AddEvent(&domain.Task{
+			ID:          id,
+			Title:       "Plan; review, ship",
+			Description: "Line one\nline two with a backslash \\ and " + strings.Repeat("é", 40),
+			Tags:        []string{"ops", "a,b"},
+			DueDate:     time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		})
+
+		document := calendar.String()
+		assert.Contains(t, document, `SUMMARY:Plan\; review\, ship`+"\r\n")
+		assert.Contains(t, document, `CATEGORIES:ops,a\,b`+"\r\n")
+		for _, line := range strings.Split(document, "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+		}
+		unfolded := strings.ReplaceAll(document, "\r\n ", "")
+		assert.Contains(t, unfolded, `DESCRIPTION:Line one\nline two with a backslash \\ and `+strings.Repeat("é", 40)+"\r\n")
+	})
+}
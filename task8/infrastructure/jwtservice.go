@@ -7,7 +7,7 @@ import (
 )
 
 type JWTService interface {
-	NewToken(id string, email string, role string) (string, error)
+	NewToken(id string, email string, role string, workspaceid string) (string, error)
 }
 
 type jwtService struct {
@@ -18,13 +18,19 @@ func NewJWTService() JWTService {
 
 }
 
-func (js *jwtService) NewToken(id string, email string, role string) (string, error) {
+// NewToken signs the claims of a user. workspaceid is the active workspace,
+// left out for the personal one.
+func (js *jwtService) NewToken(id string, email string, role string, workspaceid string) (string, error) {
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	claims := jwt.MapClaims{
 		"user_id": id,
 		"email":   email,
 		"role":    role,
-	})
+	}
+	if workspaceid != "" {
+		claims["workspace_id"] = workspaceid
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	var jwtSecret []byte = []byte(os.Getenv("secret"))
 
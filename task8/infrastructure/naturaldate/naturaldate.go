@@ -0,0 +1,494 @@
+// Package naturaldate reads due dates the way people write them, such as
+// "tomorrow 5pm", "next friday" or "in 3 days", in English and German.
+//
+// Phrases are resolved relative to a point in time and in its location. A
+// phrase naming a day but no time of day resolves to midnight, and ParseDay
+// tells it apart from one naming midnight itself.
+package naturaldate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parse resolves text relative to now, trying every language in turn. A
+// time of day alone means its next occurrence, so "9am" said in the
+// evening is tomorrow morning.
+func Parse(text string, now time.Time) (time.Time, error) {
+
+	due, _, err := ParseDay(text, now)
+	return due, err
+}
+
+// ParseDay is Parse, also reporting whether text names a day only, with no
+// time of day: "tomorrow" does, "tomorrow 0:00" and "in 2 hours" do not.
+func ParseDay(text string, now time.Time) (time.Time, bool, error) {
+
+	tokens := strings.Fields(strings.ToLower(strings.ReplaceAll(text, ",", " ")))
+	if len(tokens) == 0 {
+		return time.Time{}, false, fmt.Errorf("no date given")
+	}
+	for _, lang := range languages {
+		p := &parser{lang: lang, tokens: tokens, now: now}
+		if due, ok := p.parse(); ok {
+			return due, !p.hasTime, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("could not read %q as a date", text)
+}
+
+// language holds the words of one language. Multi-word phrases are keys
+// joined by single spaces.
+type language struct {
+	// days are the days named relative to today, by their offset.
+	days map[string]int
+	// weekdayPrefixes may stand before a weekday or a date without
+	// changing it.
+	weekdayPrefixes []string
+	weekdays        map[string]time.Weekday
+	months          map[string]time.Month
+	// in starts a duration, as in "in 3 days".
+	in      []string
+	numbers map[string]int
+	units   map[string]unit
+	// at may stand before a time of day, which may then be a bare hour.
+	at []string
+	// times are times of day said in words.
+	times map[string]int
+	// meridiems follow a 12-hour time, hourSuffixes a 24-hour one.
+	meridiems    map[string]bool
+	hourSuffixes []string
+}
+
+type unit int
+
+const (
+	minutes unit = iota
+	hours
+	days
+	weeks
+	months
+	years
+)
+
+// maxAmounts bounds durations to about a century, well clear of where
+// time.Duration and the calendar overflow.
+var maxAmounts = map[unit]int{
+	minutes: 100 * 366 * 24 * 60,
+	hours:   100 * 366 * 24,
+	days:    100 * 366,
+	weeks:   100 * 53,
+	months:  100 * 12,
+	years:   100,
+}
+
+var english = &language{
+	days:            map[string]int{"today": 0, "tomorrow": 1, "day after tomorrow": 2, "the day after tomorrow": 2},
+	weekdayPrefixes: []string{"next", "this", "coming", "on"},
+	weekdays: map[string]time.Weekday{
+		"monday": time.Monday, "mon": time.Monday,
+		"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday,
+		"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday,
+		"saturday": time.Saturday, "sat": time.Saturday,
+		"sunday": time.Sunday, "sun": time.Sunday,
+	},
+	months: map[string]time.Month{
+		"january": time.January, "jan": time.January,
+		"february": time.February, "feb": time.February,
+		"march": time.March, "mar": time.March,
+		"april": time.April, "apr": time.April,
+		"may":  time.May,
+		"june": time.June, "jun": time.June,
+		"july": time.July, "jul": time.July,
+		"august": time.August, "aug": time.August,
+		"september": time.September, "sep": time.September, "sept": time.September,
+		"october": time.October, "oct": time.October,
+		"november": time.November, "nov": time.November,
+		"december": time.December, "dec": time.December,
+	},
+	in: []string{"in"},
+	numbers: map[string]int{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10, "twelve": 12,
+	},
+	units: map[string]unit{
+		"minute": minutes, "minutes": minutes, "min": minutes, "mins": minutes,
+		"hour": hours, "hours": hours,
+		"day": days, "days": days,
+		"week": weeks, "weeks": weeks,
+		"month": months, "months": months,
+		"year": years, "years": years,
+	},
+	at:        []string{"at"},
+	times:     map[string]int{"noon": 12, "midday": 12},
+	meridiems: map[string]bool{"am": false, "a.m.": false, "pm": true, "p.m.": true},
+}
+
+var german = &language{
+	days:            map[string]int{"heute": 0, "morgen": 1, "übermorgen": 2, "uebermorgen": 2},
+	weekdayPrefixes: []string{"nächsten", "nächster", "nächste", "naechsten", "kommenden", "kommender", "diesen", "am"},
+	weekdays: map[string]time.Weekday{
+		"montag":     time.Monday,
+		"dienstag":   time.Tuesday,
+		"mittwoch":   time.Wednesday,
+		"donnerstag": time.Thursday,
+		"freitag":    time.Friday,
+		"samstag":    time.Saturday,
+		"sonnabend":  time.Saturday,
+		"sonntag":    time.Sunday,
+	},
+	months: map[string]time.Month{
+		"januar": time.January, "jänner": time.January, "jan": time.January,
+		"februar": time.February, "feb": time.February,
+		"märz": time.March, "maerz": time.March, "mär": time.March,
+		"april": time.April, "apr": time.April,
+		"mai":  time.May,
+		"juni": time.June, "jun": time.June,
+		"juli": time.July, "jul": time.July,
+		"august": time.August, "aug": time.August,
+		"september": time.September, "sep": time.September, "sept": time.September,
+		"oktober": time.October, "okt": time.October,
+		"november": time.November, "nov": time.November,
+		"dezember": time.December, "dez": time.December,
+	},
+	in: []string{"in"},
+	numbers: map[string]int{
+		"einer": 1, "einem": 1, "einen": 1, "ein": 1, "eine": 1, "zwei": 2, "drei": 3, "vier": 4,
+		"fünf": 5, "sechs": 6, "sieben": 7, "acht": 8, "neun": 9, "zehn": 10, "zwölf": 12,
+	},
+	units: map[string]unit{
+		"minute": minutes, "minuten": minutes,
+		"stunde": hours, "stunden": hours,
+		"tag": days, "tage": days, "tagen": days,
+		"woche": weeks, "wochen": weeks,
+		"monat": months, "monate": months, "monaten": months,
+		"jahr": years, "jahre": years, "jahren": years,
+	},
+	at:           []string{"um"},
+	times:        map[string]int{"mittag": 12, "mittags": 12},
+	hourSuffixes: []string{"uhr"},
+}
+
+var languages = []*language{english, german}
+
+var (
+	clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm|a\.m\.|p\.m\.)?$`)
+	dayPattern   = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th|\.)?$`)
+	isoPattern   = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+)
+
+// parser reads a day and a time of day out of the tokens, in either order.
+type parser struct {
+	lang   *language
+	tokens []string
+	pos    int
+	now    time.Time
+
+	day          time.Time
+	hasDay       bool
+	hour, minute int
+	hasTime      bool
+}
+
+func (p *parser) parse() (time.Time, bool) {
+
+	for p.pos < len(p.tokens) {
+		if !p.hasDay && p.readDay() {
+			continue
+		}
+		if !p.hasTime && p.readTime() {
+			continue
+		}
+		return time.Time{}, false
+	}
+
+	switch {
+	case p.hasDay && p.hasTime:
+		return wallClock(p.day, p.hour, p.minute), true
+	case p.hasDay:
+		return wallClock(p.day, 0, 0), true
+	case p.hasTime:
+		due := wallClock(p.today(), p.hour, p.minute)
+		if !due.After(p.now) {
+			due = wallClock(p.today().AddDate(0, 0, 1), p.hour, p.minute)
+		}
+		return due, true
+	}
+	return time.Time{}, false
+}
+
+// wallClock is the time of day on day, in its location. A time skipped
+// when the clocks go forward moves forward with them, so 2:30 on that
+// night is 3:30, where time.Date may make it 1:30.
+func wallClock(day time.Time, hour int, minute int) time.Time {
+
+	due := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+	want := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, time.UTC)
+	got := time.Date(due.Year(), due.Month(), due.Day(), due.Hour(), due.Minute(), 0, 0, time.UTC)
+	if got.Before(want) {
+		return due.Add(want.Sub(got))
+	}
+	return due
+}
+
+// readDay reads a day, or for "in 3 hours" a day and a time of day.
+func (p *parser) readDay() bool {
+
+	start := p.pos
+	if offset, ok := p.phrase(p.lang.days); ok {
+		p.setDay(p.today().AddDate(0, 0, offset))
+		return true
+	}
+
+	p.accept(p.lang.weekdayPrefixes)
+	if weekday, ok := p.lang.weekdays[p.peek()]; ok {
+		p.pos++
+		ahead := (int(weekday) - int(p.now.Weekday()) + 7) % 7
+		if ahead == 0 {
+			ahead = 7
+		}
+		p.setDay(p.today().AddDate(0, 0, ahead))
+		return true
+	}
+	// "on may 2" and "am 2. mai" take the same words.
+	if p.pos > start && p.readCalendarDate() {
+		return true
+	}
+	p.pos = start
+
+	if p.accept(p.lang.in) {
+		if p.readDuration() {
+			return true
+		}
+		p.pos = start
+		return false
+	}
+
+	if match := isoPattern.FindStringSubmatch(p.peek()); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		day, _ := strconv.Atoi(match[3])
+		if p.validDate(year, time.Month(month), day) {
+			p.pos++
+			p.setDay(time.Date(year, time.Month(month), day, 0, 0, 0, 0, p.now.Location()))
+			return true
+		}
+		return false
+	}
+
+	return p.readCalendarDate()
+}
+
+// readDuration reads the "3 days" of "in 3 days".
+func (p *parser) readDuration() bool {
+
+	count, ok := p.lang.numbers[p.peek()]
+	if !ok {
+		n, err := strconv.Atoi(p.peek())
+		if err != nil || n < 0 {
+			return false
+		}
+		count = n
+	}
+	p.pos++
+	u, ok := p.lang.units[p.peek()]
+	if !ok || count > maxAmounts[u] {
+		return false
+	}
+	p.pos++
+
+	switch u {
+	case minutes, hours:
+		step := time.Minute
+		if u == hours {
+			step = time.Hour
+		}
+		due := p.now.Add(time.Duration(count) * step)
+		p.setDay(due)
+		p.hour, p.minute, p.hasTime = due.Hour(), due.Minute(), true
+	case days:
+		p.setDay(p.today().AddDate(0, 0, count))
+	case weeks:
+		p.setDay(p.today().AddDate(0, 0, 7*count))
+	case months:
+		p.setDay(addMonths(p.today(), count))
+	case years:
+		p.setDay(addMonths(p.today(), 12*count))
+	}
+	return true
+}
+
+// readCalendarDate reads "may 2", "2nd may" or "2. mai", each with an
+// optional year. Without one the date is the next one to come.
+func (p *parser) readCalendarDate() bool {
+
+	start := p.pos
+	var month time.Month
+	var day int
+	if m, ok := p.lang.months[p.peek()]; ok {
+		p.pos++
+		d, ok := p.dayOfMonth()
+		if !ok {
+			p.pos = start
+			return false
+		}
+		month, day = m, d
+	} else if d, ok := p.dayOfMonth(); ok {
+		m, ok := p.lang.months[p.peek()]
+		if !ok {
+			p.pos = start
+			return false
+		}
+		p.pos++
+		month, day = m, d
+	} else {
+		return false
+	}
+
+	today := p.today()
+	year := today.Year()
+	explicit := false
+	if y, err := strconv.Atoi(p.peek()); err == nil && len(p.peek()) == 4 {
+		p.pos++
+		year, explicit = y, true
+	}
+	if !p.validDate(year, month, day) {
+		p.pos = start
+		return false
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, p.now.Location())
+	if !explicit && date.Before(today) {
+		date = time.Date(year+1, month, day, 0, 0, 0, 0, p.now.Location())
+	}
+	p.setDay(date)
+	return true
+}
+
+func (p *parser) dayOfMonth() (int, bool) {
+
+	match := dayPattern.FindStringSubmatch(p.peek())
+	if match == nil {
+		return 0, false
+	}
+	day, _ := strconv.Atoi(match[1])
+	if day < 1 || day > 31 {
+		return 0, false
+	}
+	p.pos++
+	return day, true
+}
+
+// readTime reads a time of day: "5pm", "5:30 pm", "17:00", "17 uhr",
+// "noon", and after "at" or "um" also a bare hour.
+func (p *parser) readTime() bool {
+
+	start := p.pos
+	at := p.accept(p.lang.at)
+	if hour, ok := p.lang.times[p.peek()]; ok {
+		p.pos++
+		p.hour, p.minute, p.hasTime = hour, 0, true
+		return true
+	}
+
+	match := clockPattern.FindStringSubmatch(p.peek())
+	if match == nil {
+		p.pos = start
+		return false
+	}
+	p.pos++
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	meridiem := match[3]
+	if meridiem == "" {
+		if _, ok := p.lang.meridiems[p.peek()]; ok {
+			meridiem = p.peek()
+			p.pos++
+		}
+	}
+	suffixed := p.accept(p.lang.hourSuffixes)
+
+	switch {
+	case meridiem != "":
+		pm, ok := p.lang.meridiems[meridiem]
+		if !ok || hour < 1 || hour > 12 {
+			p.pos = start
+			return false
+		}
+		hour %= 12
+		if pm {
+			hour += 12
+		}
+	case match[2] == "" && !at && !suffixed:
+		// A bare number is only a time when something says so.
+		p.pos = start
+		return false
+	}
+	if hour > 23 || minute > 59 {
+		p.pos = start
+		return false
+	}
+	p.hour, p.minute, p.hasTime = hour, minute, true
+	return true
+}
+
+func (p *parser) setDay(day time.Time) {
+	p.day, p.hasDay = day, true
+}
+
+func (p *parser) today() time.Time {
+	return time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+}
+
+func (p *parser) validDate(year int, month time.Month, day int) bool {
+	date := time.Date(year, month, day, 0, 0, 0, 0, p.now.Location())
+	return month >= time.January && month <= time.December && date.Day() == day
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// accept skips the next token if it is one of words.
+func (p *parser) accept(words []string) bool {
+	for _, word := range words {
+		if p.peek() == word {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+// phrase reads the longest run of tokens that is a key of phrases.
+func (p *parser) phrase(phrases map[string]int) (int, bool) {
+	for n := len(p.tokens) - p.pos; n > 0; n-- {
+		if value, ok := phrases[strings.Join(p.tokens[p.pos:p.pos+n], " ")]; ok {
+			p.pos += n
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+// addMonths moves a date by whole months, keeping to the last day of a
+// shorter month rather than spilling into the next one.
+func addMonths(date time.Time, n int) time.Time {
+	first := time.Date(date.Year(), date.Month()+time.Month(n), 1, 0, 0, 0, 0, date.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	day := date.Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, date.Location())
+}
@@ -0,0 +1,180 @@
+package naturaldate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A Wednesday afternoon, two and a half weeks before summer time.
+	now := time.Date(2024, 3, 13, 15, 4, 0, 0, berlin)
+	at := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, berlin)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want time.Time
+	}{
+		{"today", "today", at(2024, 3, 13, 0, 0)},
+		{"tomorrow", "Tomorrow", at(2024, 3, 14, 0, 0)},
+		{"tomorrow with a time", "tomorrow 5pm", at(2024, 3, 14, 17, 0)},
+		{"tomorrow at a time", "tomorrow at 5:30 pm", at(2024, 3, 14, 17, 30)},
+		{"time before day", "9am tomorrow", at(2024, 3, 14, 9, 0)},
+		{"day after tomorrow", "the day after tomorrow", at(2024, 3, 15, 0, 0)},
+		{"weekday", "friday", at(2024, 3, 15, 0, 0)},
+		{"next weekday", "next friday", at(2024, 3, 15, 0, 0)},
+		{"next of today's weekday", "next wednesday", at(2024, 3, 20, 0, 0)},
+		{"weekday with a 24-hour time", "next mon 17:00", at(2024, 3, 18, 17, 0)},
+		{"weekday at noon", "friday, noon", at(2024, 3, 15, 12, 0)},
+		{"in days", "in 3 days", at(2024, 3, 16, 0, 0)},
+		{"in a week", "in a week", at(2024, 3, 20, 0, 0)},
+		{"in weeks past summer time", "in 3 weeks at 9am", at(2024, 4, 3, 9, 0)},
+		{"in hours", "in 2 hours", at(2024, 3, 13, 17, 4)},
+		{"in minutes", "in 45 minutes", at(2024, 3, 13, 15, 49)},
+		{"in a month", "in one month", at(2024, 4, 13, 0, 0)},
+		{"later time today", "6pm", at(2024, 3, 13, 18, 0)},
+		{"earlier time is tomorrow", "at 9", at(2024, 3, 14, 9, 0)},
+		{"midnight meridiem", "tomorrow 12am", at(2024, 3, 14, 0, 0)},
+		{"month and day", "may 2nd", at(2024, 5, 2, 0, 0)},
+		{"day and month", "on 2 may 2025 at 8:15", at(2025, 5, 2, 8, 15)},
+		{"past date is next year", "march 1", at(2025, 3, 1, 0, 0)},
+		{"iso date", "2024-12-24 18:00", at(2024, 12, 24, 18, 0)},
+
+		{"german tomorrow", "morgen", at(2024, 3, 14, 0, 0)},
+		{"german tomorrow at a time", "morgen um 17 Uhr", at(2024, 3, 14, 17, 0)},
+		{"german day after tomorrow", "Übermorgen 8:30", at(2024, 3, 15, 8, 30)},
+		{"german next weekday", "nächsten Freitag", at(2024, 3, 15, 0, 0)},
+		{"german weekday at noon", "am Montag mittags", at(2024, 3, 18, 12, 0)},
+		{"german in days", "in 3 Tagen", at(2024, 3, 16, 0, 0)},
+		{"german in a week", "in einer Woche um 9 Uhr", at(2024, 3, 20, 9, 0)},
+		{"german in hours", "in zwei Stunden", at(2024, 3, 13, 17, 4)},
+		{"german date", "am 2. Mai", at(2024, 5, 2, 0, 0)},
+		{"german date with a year", "24. Dezember 2024 18:00 Uhr", at(2024, 12, 24, 18, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text, now)
+
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+			assert.Equal(t, berlin, got.Location())
+		})
+	}
+}
+
+func TestParse_SummerTime(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	// Clocks go from 2:00 to 3:00 that night.
+	now := time.Date(2024, 3, 31, 1, 30, 0, 0, berlin)
+
+	tests := []struct {
+		name string
+		text string
+		want time.Time
+	}{
+		{"hours count elapsed time", "in 2 hours", time.Date(2024, 3, 31, 4, 30, 0, 0, berlin)},
+		{"days keep the wall clock", "tomorrow 1:30am", time.Date(2024, 4, 1, 1, 30, 0, 0, berlin)},
+		{"a day is a date", "in 1 day", time.Date(2024, 4, 1, 0, 0, 0, 0, berlin)},
+		{"a skipped time moves with the clocks", "today 2:30am", time.Date(2024, 3, 31, 3, 30, 0, 0, berlin)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text, now)
+
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestParse_SkippedTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The day before clocks go from 2:00 to 3:00.
+	now := time.Date(2024, 3, 9, 12, 0, 0, 0, newYork)
+
+	tests := []struct {
+		name string
+		text string
+		want time.Time
+	}{
+		{"tomorrow at a skipped time", "tomorrow 2:30am", time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC)},
+		{"next skipped time", "2:30am", time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC)},
+		{"time after the change", "tomorrow 3:30am", time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC)},
+		{"time before the change", "tomorrow 1:30am", time.Date(2024, 3, 10, 6, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text, now)
+
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestParseDay(t *testing.T) {
+	now := time.Date(2024, 3, 13, 15, 4, 0, 0, time.UTC)
+
+	tests := []struct {
+		text    string
+		dayOnly bool
+	}{
+		{"tomorrow", true},
+		{"in 3 days", true},
+		{"may 2nd", true},
+		{"tomorrow 0:00", false},
+		{"tomorrow 12am", false},
+		{"in 9 hours", false},
+		{"at 9", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			_, dayOnly, err := ParseDay(tt.text, now)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.dayOnly, dayOnly)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	now := time.Date(2024, 3, 13, 15, 4, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"empty", "  "},
+		{"nonsense", "whenever"},
+		{"bare number", "5"},
+		{"two days", "tomorrow friday"},
+		{"two times", "5pm 6pm"},
+		{"hour out of range", "13pm"},
+		{"minute out of range", "tomorrow 10:75"},
+		{"no such date", "february 30"},
+		{"duration without unit", "in 3"},
+		{"mixed languages", "tomorrow um 17 uhr"},
+		{"time after a duration in hours", "in 2 hours at 5pm"},
+		{"too many days", "in 99999999999 days"},
+		{"too many hours", "in 99999999999 hours"},
+		{"too many years", "in 101 years"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.text, now)
+
+			assert.Error(t, err)
+		})
+	}
+}
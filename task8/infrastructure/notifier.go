@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"task8/domain"
+	"time"
+)
+
+type emailNotifier struct {
+	addr     string
+	auth     smtp.Auth
+	from     string
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailNotifier sends notifications by mail through the SMTP server named
+// by SMTP_HOST and SMTP_PORT, authenticating with SMTP_USER and SMTP_PASSWORD
+// when set. Mails come from SMTP_FROM.
+func NewEmailNotifier() domain.Notifier {
+
+	host := os.Getenv("SMTP_HOST")
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	var auth smtp.Auth
+	if user := os.Getenv("SMTP_USER"); user != "" {
+		auth = smtp.PlainAuth("", user, os.Getenv("SMTP_PASSWORD"), host)
+	}
+	return &emailNotifier{addr: host + ":" + port, auth: auth, from: os.Getenv("SMTP_FROM"), sendMail: smtp.SendMail}
+}
+
+// Notify mails the notification to the target address. The Message-ID is
+// derived from the notification ID so a resent mail is recognisably the same.
+func (en *emailNotifier) Notify(target string, notification *domain.Notification) error {
+
+	if target == "" || strings.ContainsAny(target, "\r\n") {
+		return errors.New("invalid email address")
+	}
+	domainPart := en.from[strings.LastIndex(en.from, "@")+1:]
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", en.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", target)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", strings.NewReplacer("\r", " ", "\n", " ").Replace(notification.Title))
+	fmt.Fprintf(&msg, "Message-ID: <%s@%s>\r\n", notification.ID.Hex(), domainPart)
+	fmt.Fprintf(&msg, "Date: %s\r\n", notification.CreatedAt.Format(time.RFC1123Z))
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(notification.Message)
+	msg.WriteString("\r\n")
+
+	return en.sendMail(en.addr, en.auth, en.from, []string{target}, msg.Bytes())
+}
+
+type webhookNotifier struct {
+	client *http.Client
+}
+
+func NewWebhookNotifier() domain.Notifier {
+	return &webhookNotifier{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify posts the notification as JSON to the target URL, with the
+// notification ID as Idempotency-Key. Any non 2xx answer is a failure.
+func (wn *webhookNotifier) Notify(target string, notification *domain.Notification) error {
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", notification.ID.Hex())
+
+	resp, err := wn.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+type inAppNotifier struct {
+	repository domain.NotificationRepositoryInterface
+}
+
+// NewInAppNotifier stores notifications for the user to read in the app.
+func NewInAppNotifier(repository domain.NotificationRepositoryInterface) domain.Notifier {
+	return &inAppNotifier{repository: repository}
+}
+
+func (in *inAppNotifier) Notify(target string, notification *domain.Notification) error {
+	return in.repository.CreateNotification(notification)
+}
@@ -0,0 +1,65 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"task8/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	notification := &domain.Notification{ID: primitive.NewObjectID(), Title: "Reminder: Report"}
+
+	t.Run("posts the notification with an idempotency key", func(t *testing.T) {
+		var key string
+		var body domain.Notification
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key = r.Header.Get("Idempotency-Key")
+			json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		err := NewWebhookNotifier().Notify(server.URL, notification)
+
+		assert.NoError(t, err)
+		assert.Equal(t, notification.ID.Hex(), key)
+		assert.Equal(t, "Reminder: Report", body.Title)
+	})
+
+	t.Run("fails on an error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		err := NewWebhookNotifier().Notify(server.URL, notification)
+
+		assert.EqualError(t, err, "webhook answered 502 Bad Gateway")
+	})
+}
+
+func TestEmailNotifier(t *testing.T) {
+	var to []string
+	var msg string
+	notifier := &emailNotifier{addr: "smtp.example.com:587", from: "tasks@example.com",
+		sendMail: func(addr string, a smtp.Auth, from string, rcpt []string, body []byte) error {
+			to, msg = rcpt, string(body)
+			return nil
+		}}
+	notification := &domain.Notification{ID: primitive.NewObjectID(), Title: "Reminder:\r\nBcc: x@y.z", Message: "Due soon."}
+
+	err := notifier.Notify("ann@example.com", notification)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ann@example.com"}, to)
+	assert.Contains(t, msg, "Message-ID: <"+notification.ID.Hex()+"@example.com>")
+	assert.False(t, strings.Contains(msg, "\r\nBcc:"))
+	assert.Error(t, notifier.Notify("ann@example.com\r\nBcc: x@y.z", notification))
+}
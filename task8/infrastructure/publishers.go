@@ -0,0 +1,16 @@
+package infrastructure
+
+import "task8/domain"
+
+type publishers []domain.EventPublisher
+
+// NewPublishers passes every task event on to each of the given publishers, in order.
+func NewPublishers(list ...domain.EventPublisher) domain.EventPublisher {
+	return publishers(list)
+}
+
+func (p publishers) Publish(event string, task *domain.Task) {
+	for _, publisher := range p {
+		publisher.Publish(event, task)
+	}
+}
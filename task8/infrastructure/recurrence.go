@@ -0,0 +1,210 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RRule is the subset of an RFC 5545 recurrence rule that tasks support:
+// DAILY, WEEKLY (optionally BYDAY) and MONTHLY (optionally BYMONTHDAY),
+// with INTERVAL and either COUNT or UNTIL.
+type RRule struct {
+	Freq       string
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Count      int
+	Until      time.Time
+}
+
+// maxRecurrencePeriods bounds the search for occurrences so rules whose
+// days never match (BYMONTHDAY=31 in short months only) cannot spin forever.
+const maxRecurrencePeriods = 5000
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// ParseRRule parses a rule such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10".
+// A leading "RRULE:" is accepted.
+func ParseRRule(value string) (*RRule, error) {
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	rule := &RRule{Interval: 1}
+
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("rrule: malformed part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, errors.New("rrule: INTERVAL must be a positive number")
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, errors.New("rrule: COUNT must be a positive number")
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("rrule: unsupported BYDAY value %q", day)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(day)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("rrule: invalid BYMONTHDAY value %q", day)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "WKST":
+			if strings.ToUpper(val) != "MO" {
+				return nil, errors.New("rrule: only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("rrule: unsupported part %q", key)
+		}
+	}
+
+	switch rule.Freq {
+	case "DAILY", "WEEKLY", "MONTHLY":
+	case "":
+		return nil, errors.New("rrule: FREQ is required")
+	default:
+		return nil, fmt.Errorf("rrule: unsupported FREQ %q", rule.Freq)
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, errors.New("rrule: COUNT and UNTIL cannot be combined")
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != "WEEKLY" {
+		return nil, errors.New("rrule: BYDAY is only supported with FREQ=WEEKLY")
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq != "MONTHLY" {
+		return nil, errors.New("rrule: BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				until = until.Add(24*time.Hour - time.Second)
+			}
+			return until, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("rrule: invalid UNTIL value %q", value)
+}
+
+// Occurrences returns up to limit occurrences strictly after the given time
+// for a series starting at start. The start itself is the first occurrence
+// and counts towards COUNT. Occurrences keep the weekday and the time of day
+// of start in its location, across daylight saving changes.
+func (r *RRule) Occurrences(start time.Time, after time.Time, limit int) []time.Time {
+
+	var result []time.Time
+	index := 0
+	for period := 0; period < maxRecurrencePeriods && len(result) < limit; period++ {
+		for _, occurrence := range r.period(start, period) {
+			if occurrence.Before(start) {
+				continue
+			}
+			if r.Count > 0 && index >= r.Count {
+				return result
+			}
+			if !r.Until.IsZero() && occurrence.After(r.Until) {
+				return result
+			}
+			index++
+			if occurrence.After(after) {
+				result = append(result, occurrence)
+				if len(result) == limit {
+					return result
+				}
+			}
+		}
+	}
+	return result
+}
+
+// period lists the candidate occurrences of the n-th period of the rule, in order.
+func (r *RRule) period(start time.Time, n int) []time.Time {
+
+	hour, min, sec := start.Clock()
+	location := start.Location()
+	step := n * r.Interval
+
+	switch r.Freq {
+	case "DAILY":
+		return []time.Time{start.AddDate(0, 0, step)}
+
+	case "WEEKLY":
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		monday := start.AddDate(0, 0, -mondayOffset(start.Weekday())+7*step)
+		var occurrences []time.Time
+		for _, day := range days {
+			date := monday.AddDate(0, 0, mondayOffset(day))
+			occurrences = append(occurrences, time.Date(date.Year(), date.Month(), date.Day(), hour, min, sec, 0, location))
+		}
+		sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+		return occurrences
+
+	default:
+		days := r.ByMonthDay
+		if len(days) == 0 {
+			days = []int{start.Day()}
+		}
+		first := time.Date(start.Year(), start.Month()+time.Month(step), 1, hour, min, sec, 0, location)
+		length := first.AddDate(0, 1, -1).Day()
+		var occurrences []time.Time
+		for _, day := range days {
+			if day < 0 {
+				day = length + day + 1
+			}
+			if day < 1 || day > length {
+				continue
+			}
+			occurrences = append(occurrences, first.AddDate(0, 0, day-1))
+		}
+		sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+		return occurrences
+	}
+}
+
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr bool
+	}{
+		{"daily", "FREQ=DAILY", false},
+		{"weekly by day", "RRULE:FREQ=WEEKLY;BYDAY=MO,FR;COUNT=4", false},
+		{"monthly until", "FREQ=MONTHLY;BYMONTHDAY=-1;UNTIL=20250101", false},
+		{"missing freq", "COUNT=3", true},
+		{"yearly unsupported", "FREQ=YEARLY", true},
+		{"count and until", "FREQ=DAILY;COUNT=2;UNTIL=20250101", true},
+		{"byday outside weekly", "FREQ=DAILY;BYDAY=MO", true},
+		{"bad interval", "FREQ=DAILY;INTERVAL=0", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRRule(tt.rule)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	// Wednesday 1 January 2025, 09:00
+	start := time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time { return time.Date(2025, month, d, 9, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name  string
+		rule  string
+		after time.Time
+		limit int
+		want  []time.Time
+	}{
+		{"every other day", "FREQ=DAILY;INTERVAL=2", start, 3, []time.Time{day(1, 3), day(1, 5), day(1, 7)}},
+		{"weekly on monday and friday", "FREQ=WEEKLY;BYDAY=MO,FR", start, 3, []time.Time{day(1, 3), day(1, 6), day(1, 10)}},
+		{"count includes the start", "FREQ=WEEKLY;COUNT=3", start, 10, []time.Time{day(1, 8), day(1, 15)}},
+		{"last day of month", "FREQ=MONTHLY;BYMONTHDAY=-1", start, 3, []time.Time{day(1, 31), day(2, 28), day(3, 31)}},
+		{"31st skips short months", "FREQ=MONTHLY;BYMONTHDAY=31", start, 2, []time.Time{day(1, 31), day(3, 31)}},
+		{"until is inclusive", "FREQ=DAILY;UNTIL=20250103", start, 10, []time.Time{day(1, 2), day(1, 3)}},
+		{"after a later occurrence", "FREQ=DAILY;COUNT=5", day(1, 3), 10, []time.Time{day(1, 4), day(1, 5)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rule.Occurrences(start, tt.after, tt.limit))
+		})
+	}
+}
+
+func TestOccurrences_Timezone(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	// Thursday 7 March 2024, 21:30 in New York, which is already Friday in UTC.
+	start := time.Date(2024, time.March, 7, 21, 30, 0, 0, newYork)
+	rule, _ := ParseRRule("FREQ=WEEKLY;BYDAY=TH")
+
+	dates := rule.Occurrences(start, start, 2)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2024, time.March, 15, 1, 30, 0, 0, time.UTC),
+		time.Date(2024, time.March, 22, 1, 30, 0, 0, time.UTC),
+	}, []time.Time{dates[0].UTC(), dates[1].UTC()})
+}
@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+)
+
+// Scheduler runs a job in the background every interval, passing the time
+// of the tick. Ticks are skipped while a run is still going.
+type Scheduler struct {
+	interval time.Duration
+	job      func(now time.Time)
+}
+
+func NewScheduler(interval time.Duration, job func(now time.Time)) *Scheduler {
+	return &Scheduler{interval: interval, job: job}
+}
+
+// Start runs the job once right away, to catch up on anything that fell due
+// while the server was down, and then on every tick until ctx is done.
+func (s *Scheduler) Start(ctx context.Context) {
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.job(time.Now())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				s.job(now)
+			}
+		}
+	}()
+}
@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"task8/domain"
+	"time"
+)
+
+type webhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender() domain.WebhookSender {
+	return &webhookSender{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// SignPayload returns the X-Task8-Signature value for a payload:
+// "sha256=" followed by the hex HMAC-SHA256 of the body keyed with the secret.
+func SignPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the delivery payload to its URL. Receivers identify retries of
+// the same delivery by X-Task8-Delivery. Any non 2xx answer is a failure.
+func (ws *webhookSender) Send(delivery *domain.WebhookDelivery, secret string) (int, error) {
+
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Task8-Event", delivery.Event)
+	req.Header.Set("X-Task8-Delivery", delivery.ID.Hex())
+	req.Header.Set("X-Task8-Signature", SignPayload(secret, payload))
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"task8/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSignPayload(t *testing.T) {
+	// Reference value from `printf '{"event":"task.created"}' | openssl dgst -sha256 -hmac s3cret`.
+	assert.Equal(t,
+		"sha256=55f0f7a725c7d3e2da4ba0580dc6369793af0045bb11d93a3630c8b3317776c5",
+		SignPayload("s3cret", []byte(`{"event":"task.created"}`)))
+}
+
+func TestWebhookSender(t *testing.T) {
+	delivery := &domain.WebhookDelivery{ID: primitive.NewObjectID(), Event: domain.EventTaskCreated, Payload: `{"event":"task.created"}`}
+
+	var headers http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+	delivery.URL = server.URL
+
+	code, err := NewWebhookSender().Send(delivery, "s3cret")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, delivery.Payload, string(body))
+	assert.Equal(t, SignPayload("s3cret", body), headers.Get("X-Task8-Signature"))
+	assert.Equal(t, delivery.ID.Hex(), headers.Get("X-Task8-Delivery"))
+	assert.Equal(t, "task.created", headers.Get("X-Task8-Event"))
+}
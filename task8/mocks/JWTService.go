@@ -9,9 +9,9 @@ type JWTService struct {
 	mock.Mock
 }
 
-// NewToken provides a mock function with given fields: id, email, role
-func (_m *JWTService) NewToken(id string, email string, role string) (string, error) {
-	ret := _m.Called(id, email, role)
+// NewToken provides a mock function with given fields: id, email, role, workspaceid
+func (_m *JWTService) NewToken(id string, email string, role string, workspaceid string) (string, error) {
+	ret := _m.Called(id, email, role, workspaceid)
 
 	if len(ret) == 0 {
 		panic("no return value specified for NewToken")
@@ -19,17 +19,17 @@ func (_m *JWTService) NewToken(id string, email string, role string) (string, er
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (string, error)); ok {
-		return rf(id, email, role)
+	if rf, ok := ret.Get(0).(func(string, string, string, string) (string, error)); ok {
+		return rf(id, email, role, workspaceid)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
-		r0 = rf(id, email, role)
+	if rf, ok := ret.Get(0).(func(string, string, string, string) string); ok {
+		r0 = rf(id, email, role, workspaceid)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(id, email, role)
+	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = rf(id, email, role, workspaceid)
 	} else {
 		r1 = ret.Error(1)
 	}
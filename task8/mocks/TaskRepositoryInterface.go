@@ -6,6 +6,10 @@ import (
 	domain "task8/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	time "time"
 )
 
 // TaskRepositoryInterface is an autogenerated mock type for the TaskRepositoryInterface type
@@ -13,6 +17,88 @@ type TaskRepositoryInterface struct {
 	mock.Mock
 }
 
+// AddBlocker provides a mock function with given fields: id, blockerid
+func (_m *TaskRepositoryInterface) AddBlocker(id string, blockerid string) error {
+	ret := _m.Called(id, blockerid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBlocker")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(id, blockerid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AssignTask provides a mock function with given fields: id, assigneeid, event
+func (_m *TaskRepositoryInterface) AssignTask(id string, assigneeid string, event domain.TaskEvent) error {
+	ret := _m.Called(id, assigneeid, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, domain.TaskEvent) error); ok {
+		r0 = rf(id, assigneeid, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClearBlocker provides a mock function with given fields: blockerid
+func (_m *TaskRepositoryInterface) ClearBlocker(blockerid string) error {
+	ret := _m.Called(blockerid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearBlocker")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(blockerid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountTasks provides a mock function with given fields: userid, filter
+func (_m *TaskRepositoryInterface) CountTasks(userid string, filter domain.TaskFilter) (int64, error) {
+	ret := _m.Called(userid, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountTasks")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.TaskFilter) (int64, error)); ok {
+		return rf(userid, filter)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.TaskFilter) int64); ok {
+		r0 = rf(userid, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.TaskFilter) error); ok {
+		r1 = rf(userid, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateTask provides a mock function with given fields: newtask, userid
 func (_m *TaskRepositoryInterface) CreateTask(newtask *domain.Task, userid string) error {
 	ret := _m.Called(newtask, userid)
@@ -31,6 +117,366 @@ func (_m *TaskRepositoryInterface) CreateTask(newtask *domain.Task, userid strin
 	return r0
 }
 
+// DenseColumns provides a mock function with given fields: gap
+func (_m *TaskRepositoryInterface) DenseColumns(gap float64) ([]domain.BoardColumn, error) {
+	ret := _m.Called(gap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DenseColumns")
+	}
+
+	var r0 []domain.BoardColumn
+	var r1 error
+	if rf, ok := ret.Get(0).(func(float64) ([]domain.BoardColumn, error)); ok {
+		return rf(gap)
+	}
+	if rf, ok := ret.Get(0).(func(float64) []domain.BoardColumn); ok {
+		r0 = rf(gap)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BoardColumn)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(float64) error); ok {
+		r1 = rf(gap)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilterTasks provides a mock function with given fields: userid, filter
+func (_m *TaskRepositoryInterface) FilterTasks(userid string, filter domain.TaskFilter) (*[]domain.Task, error) {
+	ret := _m.Called(userid, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilterTasks")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.TaskFilter) (*[]domain.Task, error)); ok {
+		return rf(userid, filter)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.TaskFilter) *[]domain.Task); ok {
+		r0 = rf(userid, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.TaskFilter) error); ok {
+		r1 = rf(userid, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAssignedTasks provides a mock function with given fields: userid
+func (_m *TaskRepositoryInterface) GetAssignedTasks(userid string) (*[]domain.Task, error) {
+	ret := _m.Called(userid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignedTasks")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*[]domain.Task, error)); ok {
+		return rf(userid)
+	}
+	if rf, ok := ret.Get(0).(func(string) *[]domain.Task); ok {
+		r0 = rf(userid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockers provides a mock function with given fields: id
+func (_m *TaskRepositoryInterface) GetBlockers(id string) (*[]domain.Task, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockers")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*[]domain.Task, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *[]domain.Task); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetColumn provides a mock function with given fields: column
+func (_m *TaskRepositoryInterface) GetColumn(column domain.BoardColumn) (*[]domain.Task, error) {
+	ret := _m.Called(column)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetColumn")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.BoardColumn) (*[]domain.Task, error)); ok {
+		return rf(column)
+	}
+	if rf, ok := ret.Get(0).(func(domain.BoardColumn) *[]domain.Task); ok {
+		r0 = rf(column)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.BoardColumn) error); ok {
+		r1 = rf(column)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDependencyGraph provides a mock function with given fields: id
+func (_m *TaskRepositoryInterface) GetDependencyGraph(id string) (*[]domain.Task, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDependencyGraph")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*[]domain.Task, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *[]domain.Task); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDueTasks provides a mock function with given fields: userid, window
+func (_m *TaskRepositoryInterface) GetDueTasks(userid string, window domain.DueWindow) (*[]domain.Task, error) {
+	ret := _m.Called(userid, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueTasks")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.DueWindow) (*[]domain.Task, error)); ok {
+		return rf(userid, window)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.DueWindow) *[]domain.Task); ok {
+		r0 = rf(userid, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.DueWindow) error); ok {
+		r1 = rf(userid, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetImportedTasks provides a mock function with given fields: userid, keys
+func (_m *TaskRepositoryInterface) GetImportedTasks(userid string, keys []string) (*[]domain.Task, error) {
+	ret := _m.Called(userid, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImportedTasks")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string) (*[]domain.Task, error)); ok {
+		return rf(userid, keys)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string) *[]domain.Task); ok {
+		r0 = rf(userid, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(userid, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNewlyOverdue provides a mock function with given fields: window
+func (_m *TaskRepositoryInterface) GetNewlyOverdue(window domain.DueWindow) (*[]domain.Task, error) {
+	ret := _m.Called(window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNewlyOverdue")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.DueWindow) (*[]domain.Task, error)); ok {
+		return rf(window)
+	}
+	if rf, ok := ret.Get(0).(func(domain.DueWindow) *[]domain.Task); ok {
+		r0 = rf(window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.DueWindow) error); ok {
+		r1 = rf(window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenTasks provides a mock function with given fields: userid
+func (_m *TaskRepositoryInterface) GetOpenTasks(userid string) (*[]domain.Task, error) {
+	ret := _m.Called(userid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenTasks")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*[]domain.Task, error)); ok {
+		return rf(userid)
+	}
+	if rf, ok := ret.Get(0).(func(string) *[]domain.Task); ok {
+		r0 = rf(userid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectTasks provides a mock function with given fields: projectid
+func (_m *TaskRepositoryInterface) GetProjectTasks(projectid string) (*[]domain.Task, error) {
+	ret := _m.Called(projectid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectTasks")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*[]domain.Task, error)); ok {
+		return rf(projectid)
+	}
+	if rf, ok := ret.Get(0).(func(string) *[]domain.Task); ok {
+		r0 = rf(projectid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStats provides a mock function with given fields: filter
+func (_m *TaskRepositoryInterface) GetStats(filter domain.StatsFilter) (*domain.TaskStats, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 *domain.TaskStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.StatsFilter) (*domain.TaskStats, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(domain.StatsFilter) *domain.TaskStats); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.StatsFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTask provides a mock function with given fields: id
 func (_m *TaskRepositoryInterface) GetTask(id string) (*domain.Task, error) {
 	ret := _m.Called(id)
@@ -91,6 +537,184 @@ func (_m *TaskRepositoryInterface) GetTasks(userid string) (*[]domain.Task, erro
 	return r0, r1
 }
 
+// GetTasksByIDs provides a mock function with given fields: ids
+func (_m *TaskRepositoryInterface) GetTasksByIDs(ids []primitive.ObjectID) (*[]domain.Task, error) {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTasksByIDs")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]primitive.ObjectID) (*[]domain.Task, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]primitive.ObjectID) *[]domain.Task); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]primitive.ObjectID) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InWorkspace provides a mock function with given fields: workspaceid
+func (_m *TaskRepositoryInterface) InWorkspace(workspaceid primitive.ObjectID) domain.TaskRepositoryInterface {
+	ret := _m.Called(workspaceid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InWorkspace")
+	}
+
+	var r0 domain.TaskRepositoryInterface
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) domain.TaskRepositoryInterface); ok {
+		r0 = rf(workspaceid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.TaskRepositoryInterface)
+		}
+	}
+
+	return r0
+}
+
+// LastRank provides a mock function with given fields: column
+func (_m *TaskRepositoryInterface) LastRank(column domain.BoardColumn) (float64, error) {
+	ret := _m.Called(column)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastRank")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.BoardColumn) (float64, error)); ok {
+		return rf(column)
+	}
+	if rf, ok := ret.Get(0).(func(domain.BoardColumn) float64); ok {
+		r0 = rf(column)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.BoardColumn) error); ok {
+		r1 = rf(column)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkNextScheduled provides a mock function with given fields: id
+func (_m *TaskRepositoryInterface) MarkNextScheduled(id string) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkNextScheduled")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkOverdue provides a mock function with given fields: id, duedate
+func (_m *TaskRepositoryInterface) MarkOverdue(id string, duedate time.Time) error {
+	ret := _m.Called(id, duedate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOverdue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(id, duedate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveTask provides a mock function with given fields: id, status, rank, completedat
+func (_m *TaskRepositoryInterface) MoveTask(id string, status string, rank float64, completedat time.Time) error {
+	ret := _m.Called(id, status, rank, completedat)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, float64, time.Time) error); ok {
+		r0 = rf(id, status, rank, completedat)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RebalanceColumn provides a mock function with given fields: column, step
+func (_m *TaskRepositoryInterface) RebalanceColumn(column domain.BoardColumn, step float64) error {
+	ret := _m.Called(column, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebalanceColumn")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.BoardColumn, float64) error); ok {
+		r0 = rf(column, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveBlocker provides a mock function with given fields: id, blockerid
+func (_m *TaskRepositoryInterface) RemoveBlocker(id string, blockerid string) error {
+	ret := _m.Called(id, blockerid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBlocker")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(id, blockerid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveTask provides a mock function with given fields: id
 func (_m *TaskRepositoryInterface) RemoveTask(id string) error {
 	ret := _m.Called(id)
@@ -109,6 +733,42 @@ func (_m *TaskRepositoryInterface) RemoveTask(id string) error {
 	return r0
 }
 
+// StreamTasks provides a mock function with given fields: userid, each
+func (_m *TaskRepositoryInterface) StreamTasks(userid string, each func(*domain.Task) error) error {
+	ret := _m.Called(userid, each)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*domain.Task) error) error); ok {
+		r0 = rf(userid, each)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSeries provides a mock function with given fields: seriesid, ownerid, updatedtask
+func (_m *TaskRepositoryInterface) UpdateSeries(seriesid string, ownerid string, updatedtask *domain.Task) error {
+	ret := _m.Called(seriesid, ownerid, updatedtask)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSeries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, *domain.Task) error); ok {
+		r0 = rf(seriesid, ownerid, updatedtask)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTask provides a mock function with given fields: id, updatedtask
 func (_m *TaskRepositoryInterface) UpdateTask(id string, updatedtask *domain.Task) error {
 	ret := _m.Called(id, updatedtask)
@@ -127,6 +787,24 @@ func (_m *TaskRepositoryInterface) UpdateTask(id string, updatedtask *domain.Tas
 	return r0
 }
 
+// WriteTasks provides a mock function with given fields: writes
+func (_m *TaskRepositoryInterface) WriteTasks(writes []domain.TaskWrite) error {
+	ret := _m.Called(writes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]domain.TaskWrite) error); ok {
+		r0 = rf(writes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTaskRepositoryInterface creates a new instance of TaskRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTaskRepositoryInterface(t interface {
@@ -6,6 +6,10 @@ import (
 	domain "task8/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	time "time"
 )
 
 // TaskUsecaseInterface is an autogenerated mock type for the TaskUsecaseInterface type
@@ -13,6 +17,124 @@ type TaskUsecaseInterface struct {
 	mock.Mock
 }
 
+// AddBlocker provides a mock function with given fields: id, blockerID, userID
+func (_m *TaskUsecaseInterface) AddBlocker(id string, blockerID string, userID string) (*domain.Task, error) {
+	ret := _m.Called(id, blockerID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBlocker")
+	}
+
+	var r0 *domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*domain.Task, error)); ok {
+		return rf(id, blockerID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *domain.Task); ok {
+		r0 = rf(id, blockerID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(id, blockerID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssignTask provides a mock function with given fields: id, assigneeID, userID
+func (_m *TaskUsecaseInterface) AssignTask(id string, assigneeID string, userID string) (*domain.Task, error) {
+	ret := _m.Called(id, assigneeID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignTask")
+	}
+
+	var r0 *domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*domain.Task, error)); ok {
+		return rf(id, assigneeID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *domain.Task); ok {
+		r0 = rf(id, assigneeID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(id, assigneeID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkTasks provides a mock function with given fields: request, userID
+func (_m *TaskUsecaseInterface) BulkTasks(request domain.BulkRequest, userID string) (*domain.BulkResponse, error) {
+	ret := _m.Called(request, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkTasks")
+	}
+
+	var r0 *domain.BulkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.BulkRequest, string) (*domain.BulkResponse, error)); ok {
+		return rf(request, userID)
+	}
+	if rf, ok := ret.Get(0).(func(domain.BulkRequest, string) *domain.BulkResponse); ok {
+		r0 = rf(request, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BulkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.BulkRequest, string) error); ok {
+		r1 = rf(request, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountTasks provides a mock function with given fields: userID, filter
+func (_m *TaskUsecaseInterface) CountTasks(userID string, filter domain.TaskFilter) (int64, error) {
+	ret := _m.Called(userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountTasks")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.TaskFilter) (int64, error)); ok {
+		return rf(userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.TaskFilter) int64); ok {
+		r0 = rf(userID, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.TaskFilter) error); ok {
+		r1 = rf(userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateTask provides a mock function with given fields: newtask, userid
 func (_m *TaskUsecaseInterface) CreateTask(newtask *domain.Task, userid string) error {
 	ret := _m.Called(newtask, userid)
@@ -31,9 +153,297 @@ func (_m *TaskUsecaseInterface) CreateTask(newtask *domain.Task, userid string)
 	return r0
 }
 
-// GetTask provides a mock function with given fields: id
-func (_m *TaskUsecaseInterface) GetTask(id string) (*domain.Task, error) {
-	ret := _m.Called(id)
+// ExportTasks provides a mock function with given fields: userID, each
+func (_m *TaskUsecaseInterface) ExportTasks(userID string, each func(*domain.Task) error) error {
+	ret := _m.Called(userID, each)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*domain.Task) error) error); ok {
+		r0 = rf(userID, each)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FilterTasks provides a mock function with given fields: userID, filter
+func (_m *TaskUsecaseInterface) FilterTasks(userID string, filter domain.TaskFilter) (*[]domain.Task, error) {
+	ret := _m.Called(userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilterTasks")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.TaskFilter) (*[]domain.Task, error)); ok {
+		return rf(userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.TaskFilter) *[]domain.Task); ok {
+		r0 = rf(userID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.TaskFilter) error); ok {
+		r1 = rf(userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllStats provides a mock function with given fields: filter
+func (_m *TaskUsecaseInterface) GetAllStats(filter domain.StatsFilter) (*domain.TaskStats, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllStats")
+	}
+
+	var r0 *domain.TaskStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.StatsFilter) (*domain.TaskStats, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(domain.StatsFilter) *domain.TaskStats); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.StatsFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAssignedTasks provides a mock function with given fields: userID
+func (_m *TaskUsecaseInterface) GetAssignedTasks(userID string) (*[]domain.Task, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignedTasks")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*[]domain.Task, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *[]domain.Task); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDependencyGraph provides a mock function with given fields: id, userID
+func (_m *TaskUsecaseInterface) GetDependencyGraph(id string, userID string) (*domain.TaskGraph, error) {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDependencyGraph")
+	}
+
+	var r0 *domain.TaskGraph
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*domain.TaskGraph, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *domain.TaskGraph); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskGraph)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDueTasks provides a mock function with given fields: userID, view, days
+func (_m *TaskUsecaseInterface) GetDueTasks(userID string, view string, days int) (*[]domain.Task, error) {
+	ret := _m.Called(userID, view, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueTasks")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int) (*[]domain.Task, error)); ok {
+		return rf(userID, view, days)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int) *[]domain.Task); ok {
+		r0 = rf(userID, view, days)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(userID, view, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEditableTask provides a mock function with given fields: id, userID
+func (_m *TaskUsecaseInterface) GetEditableTask(id string, userID string) (*domain.Task, error) {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEditableTask")
+	}
+
+	var r0 *domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*domain.Task, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *domain.Task); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOccurrences provides a mock function with given fields: id, userID, limit
+func (_m *TaskUsecaseInterface) GetOccurrences(id string, userID string, limit int) ([]time.Time, error) {
+	ret := _m.Called(id, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOccurrences")
+	}
+
+	var r0 []time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int) ([]time.Time, error)); ok {
+		return rf(id, userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int) []time.Time); ok {
+		r0 = rf(id, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(id, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectTasks provides a mock function with given fields: projectID, userID
+func (_m *TaskUsecaseInterface) GetProjectTasks(projectID string, userID string) (*[]domain.Task, error) {
+	ret := _m.Called(projectID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectTasks")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*[]domain.Task, error)); ok {
+		return rf(projectID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *[]domain.Task); ok {
+		r0 = rf(projectID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(projectID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStats provides a mock function with given fields: userID, filter
+func (_m *TaskUsecaseInterface) GetStats(userID string, filter domain.StatsFilter) (*domain.TaskStats, error) {
+	ret := _m.Called(userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 *domain.TaskStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.StatsFilter) (*domain.TaskStats, error)); ok {
+		return rf(userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.StatsFilter) *domain.TaskStats); ok {
+		r0 = rf(userID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.StatsFilter) error); ok {
+		r1 = rf(userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTask provides a mock function with given fields: id, userID
+func (_m *TaskUsecaseInterface) GetTask(id string, userID string) (*domain.Task, error) {
+	ret := _m.Called(id, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTask")
@@ -41,19 +451,19 @@ func (_m *TaskUsecaseInterface) GetTask(id string) (*domain.Task, error) {
 
 	var r0 *domain.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*domain.Task, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(string, string) (*domain.Task, error)); ok {
+		return rf(id, userID)
 	}
-	if rf, ok := ret.Get(0).(func(string) *domain.Task); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, string) *domain.Task); ok {
+		r0 = rf(id, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(id, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,17 +501,243 @@ func (_m *TaskUsecaseInterface) GetTasks(userID string) (*[]domain.Task, error)
 	return r0, r1
 }
 
-// RemoveTask provides a mock function with given fields: id
-func (_m *TaskUsecaseInterface) RemoveTask(id string) error {
-	ret := _m.Called(id)
+// GetTasksByIDs provides a mock function with given fields: ids, userID
+func (_m *TaskUsecaseInterface) GetTasksByIDs(ids []primitive.ObjectID, userID string) (*[]domain.Task, error) {
+	ret := _m.Called(ids, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTasksByIDs")
+	}
+
+	var r0 *[]domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]primitive.ObjectID, string) (*[]domain.Task, error)); ok {
+		return rf(ids, userID)
+	}
+	if rf, ok := ret.Get(0).(func([]primitive.ObjectID, string) *[]domain.Task); ok {
+		r0 = rf(ids, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]primitive.ObjectID, string) error); ok {
+		r1 = rf(ids, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportTasks provides a mock function with given fields: records, userID, dryRun
+func (_m *TaskUsecaseInterface) ImportTasks(records []domain.ImportRecord, userID string, dryRun bool) (*domain.ImportResult, error) {
+	ret := _m.Called(records, userID, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportTasks")
+	}
+
+	var r0 *domain.ImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]domain.ImportRecord, string, bool) (*domain.ImportResult, error)); ok {
+		return rf(records, userID, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func([]domain.ImportRecord, string, bool) *domain.ImportResult); ok {
+		r0 = rf(records, userID, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ImportResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]domain.ImportRecord, string, bool) error); ok {
+		r1 = rf(records, userID, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InWorkspace provides a mock function with given fields: workspaceID
+func (_m *TaskUsecaseInterface) InWorkspace(workspaceID string) (domain.TaskUsecaseInterface, error) {
+	ret := _m.Called(workspaceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InWorkspace")
+	}
+
+	var r0 domain.TaskUsecaseInterface
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (domain.TaskUsecaseInterface, error)); ok {
+		return rf(workspaceID)
+	}
+	if rf, ok := ret.Get(0).(func(string) domain.TaskUsecaseInterface); ok {
+		r0 = rf(workspaceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.TaskUsecaseInterface)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(workspaceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveTask provides a mock function with given fields: id, move, userID
+func (_m *TaskUsecaseInterface) MoveTask(id string, move domain.TaskMove, userID string) (*domain.Task, error) {
+	ret := _m.Called(id, move, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveTask")
+	}
+
+	var r0 *domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.TaskMove, string) (*domain.Task, error)); ok {
+		return rf(id, move, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.TaskMove, string) *domain.Task); ok {
+		r0 = rf(id, move, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.TaskMove, string) error); ok {
+		r1 = rf(id, move, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NextTasks provides a mock function with given fields: userID, limit
+func (_m *TaskUsecaseInterface) NextTasks(userID string, limit int) (*[]domain.NextTask, error) {
+	ret := _m.Called(userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextTasks")
+	}
+
+	var r0 *[]domain.NextTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) (*[]domain.NextTask, error)); ok {
+		return rf(userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) *[]domain.NextTask); ok {
+		r0 = rf(userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]domain.NextTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishOverdue provides a mock function with given fields: now
+func (_m *TaskUsecaseInterface) PublishOverdue(now time.Time) (int, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishOverdue")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveBlocker provides a mock function with given fields: id, blockerID, userID
+func (_m *TaskUsecaseInterface) RemoveBlocker(id string, blockerID string, userID string) (*domain.Task, error) {
+	ret := _m.Called(id, blockerID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBlocker")
+	}
+
+	var r0 *domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*domain.Task, error)); ok {
+		return rf(id, blockerID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *domain.Task); ok {
+		r0 = rf(id, blockerID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(id, blockerID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveTask provides a mock function with given fields: id, userID
+func (_m *TaskUsecaseInterface) RemoveTask(id string, userID string) error {
+	ret := _m.Called(id, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RemoveTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSeries provides a mock function with given fields: id, updatedTask, userID
+func (_m *TaskUsecaseInterface) UpdateSeries(id string, updatedTask *domain.Task, userID string) error {
+	ret := _m.Called(id, updatedTask, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSeries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *domain.Task, string) error); ok {
+		r0 = rf(id, updatedTask, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -109,17 +745,17 @@ func (_m *TaskUsecaseInterface) RemoveTask(id string) error {
 	return r0
 }
 
-// UpdateTask provides a mock function with given fields: id, updatedTask
-func (_m *TaskUsecaseInterface) UpdateTask(id string, updatedTask *domain.Task) error {
-	ret := _m.Called(id, updatedTask)
+// UpdateTask provides a mock function with given fields: id, updatedTask, userID
+func (_m *TaskUsecaseInterface) UpdateTask(id string, updatedTask *domain.Task, userID string) error {
+	ret := _m.Called(id, updatedTask, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, *domain.Task) error); ok {
-		r0 = rf(id, updatedTask)
+	if rf, ok := ret.Get(0).(func(string, *domain.Task, string) error); ok {
+		r0 = rf(id, updatedTask, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -127,6 +763,26 @@ func (_m *TaskUsecaseInterface) UpdateTask(id string, updatedTask *domain.Task)
 	return r0
 }
 
+// WithEvents provides a mock function with given fields: events
+func (_m *TaskUsecaseInterface) WithEvents(events domain.EventPublisher) domain.TaskUsecaseInterface {
+	ret := _m.Called(events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithEvents")
+	}
+
+	var r0 domain.TaskUsecaseInterface
+	if rf, ok := ret.Get(0).(func(domain.EventPublisher) domain.TaskUsecaseInterface); ok {
+		r0 = rf(events)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.TaskUsecaseInterface)
+		}
+	}
+
+	return r0
+}
+
 // NewTaskUsecaseInterface creates a new instance of TaskUsecaseInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTaskUsecaseInterface(t interface {
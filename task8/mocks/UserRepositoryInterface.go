@@ -43,6 +43,36 @@ func (_m *UserRepositoryInterface) GetUser(email string) (*domain.User, error) {
 	return r0, r1
 }
 
+// GetUserByID provides a mock function with given fields: id
+func (_m *UserRepositoryInterface) GetUserByID(id string) (*domain.User, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.User, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.User); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUsers provides a mock function with given fields:
 func (_m *UserRepositoryInterface) GetUsers() (*[]domain.User, error) {
 	ret := _m.Called()
@@ -119,6 +149,66 @@ func (_m *UserRepositoryInterface) Register(user *domain.User) error {
 	return r0
 }
 
+// SetNextWeights provides a mock function with given fields: id, weights
+func (_m *UserRepositoryInterface) SetNextWeights(id string, weights domain.NextWeights) (*domain.User, error) {
+	ret := _m.Called(id, weights)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNextWeights")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.NextWeights) (*domain.User, error)); ok {
+		return rf(id, weights)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.NextWeights) *domain.User); ok {
+		r0 = rf(id, weights)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.NextWeights) error); ok {
+		r1 = rf(id, weights)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTimezone provides a mock function with given fields: id, timezone
+func (_m *UserRepositoryInterface) SetTimezone(id string, timezone string) (*domain.User, error) {
+	ret := _m.Called(id, timezone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTimezone")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*domain.User, error)); ok {
+		return rf(id, timezone)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *domain.User); ok {
+		r0 = rf(id, timezone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(id, timezone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserRepositoryInterface creates a new instance of UserRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepositoryInterface(t interface {
@@ -119,6 +119,66 @@ func (_m *UserUsecaseInterface) Register(user *domain.User) error {
 	return r0
 }
 
+// SetNextWeights provides a mock function with given fields: userID, weights
+func (_m *UserUsecaseInterface) SetNextWeights(userID string, weights domain.NextWeights) (*domain.User, error) {
+	ret := _m.Called(userID, weights)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNextWeights")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.NextWeights) (*domain.User, error)); ok {
+		return rf(userID, weights)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.NextWeights) *domain.User); ok {
+		r0 = rf(userID, weights)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.NextWeights) error); ok {
+		r1 = rf(userID, weights)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTimezone provides a mock function with given fields: userID, timezone
+func (_m *UserUsecaseInterface) SetTimezone(userID string, timezone string) (*domain.User, error) {
+	ret := _m.Called(userID, timezone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTimezone")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*domain.User, error)); ok {
+		return rf(userID, timezone)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *domain.User); ok {
+		r0 = rf(userID, timezone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, timezone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserUsecaseInterface creates a new instance of UserUsecaseInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserUsecaseInterface(t interface {
@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"io"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AttachmentRepository keeps attachment metadata in its own collection and
+// the file contents in GridFS. Contents are stored once per SHA-256, so
+// identical uploads share a GridFS file.
+type AttachmentRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewAttachmentRepository(db *mongo.Database) *AttachmentRepository {
+	collection := db.Collection("attachments")
+	return &AttachmentRepository{db: db, collection: collection}
+}
+
+func (ar *AttachmentRepository) CreateAttachment(newattachment *domain.Attachment, content io.Reader) error {
+
+	var existing domain.Attachment
+	err := ar.collection.FindOne(context.TODO(), bson.M{"sha256": newattachment.SHA256}).Decode(&existing)
+
+	switch {
+	case err == nil:
+		newattachment.FileID = existing.FileID
+	case errors.Is(err, mongo.ErrNoDocuments):
+		bucket, err := gridfs.NewBucket(ar.db)
+		if err != nil {
+			return err
+		}
+		opts := options.GridFSUpload().SetMetadata(bson.M{"sha256": newattachment.SHA256, "content_type": newattachment.ContentType})
+		fileID, err := bucket.UploadFromStream(newattachment.Filename, content, opts)
+		if err != nil {
+			return err
+		}
+		newattachment.FileID = fileID
+	default:
+		return err
+	}
+
+	result, err := ar.collection.InsertOne(context.TODO(), newattachment)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+
+	newattachment.ID = oid
+	return nil
+}
+
+func (ar *AttachmentRepository) GetAttachment(id string) (*domain.Attachment, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var attachment domain.Attachment
+
+	err = ar.collection.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(&attachment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (ar *AttachmentRepository) GetAttachments(taskid string) (*[]domain.Attachment, error) {
+	tid, err := primitive.ObjectIDFromHex(taskid)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := ar.collection.Find(context.TODO(), bson.M{"task_id": tid})
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var attachments []domain.Attachment
+
+	if err = cursor.All(context.TODO(), &attachments); err != nil {
+		return nil, err
+	}
+	return &attachments, nil
+}
+
+func (ar *AttachmentRepository) OpenAttachment(attachment *domain.Attachment) (io.ReadSeekCloser, error) {
+
+	bucket, err := gridfs.NewBucket(ar.db)
+	if err != nil {
+		return nil, err
+	}
+	return &gridfsReader{bucket: bucket, fileID: attachment.FileID, size: attachment.Size}, nil
+}
+
+func (ar *AttachmentRepository) RemoveAttachment(id string) error {
+
+	attachment, err := ar.GetAttachment(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.collection.DeleteOne(context.TODO(), bson.M{"_id": attachment.ID})
+
+	if err != nil {
+		return err
+	}
+
+	return ar.releaseFiles([]primitive.ObjectID{attachment.FileID})
+}
+
+func (ar *AttachmentRepository) RemoveTaskAttachments(taskid string) error {
+
+	attachments, err := ar.GetAttachments(taskid)
+	if err != nil {
+		return err
+	}
+	if len(*attachments) == 0 {
+		return nil
+	}
+
+	fileIDs := make([]primitive.ObjectID, 0, len(*attachments))
+	for _, attachment := range *attachments {
+		fileIDs = append(fileIDs, attachment.FileID)
+	}
+
+	_, err = ar.collection.DeleteMany(context.TODO(), bson.M{"task_id": (*attachments)[0].TaskID})
+
+	if err != nil {
+		return err
+	}
+
+	return ar.releaseFiles(fileIDs)
+}
+
+// releaseFiles deletes the GridFS files no attachment refers to any more.
+func (ar *AttachmentRepository) releaseFiles(fileIDs []primitive.ObjectID) error {
+
+	bucket, err := gridfs.NewBucket(ar.db)
+	if err != nil {
+		return err
+	}
+
+	released := make(map[primitive.ObjectID]bool)
+	for _, fileID := range fileIDs {
+		if released[fileID] {
+			continue
+		}
+		count, err := ar.collection.CountDocuments(context.TODO(), bson.M{"file_id": fileID})
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if err := bucket.Delete(fileID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return err
+		}
+		released[fileID] = true
+	}
+	return nil
+}
+
+func (ar *AttachmentRepository) EnsureIndexes() error {
+
+	_, err := ar.collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "task_id", Value: 1}}},
+		{Keys: bson.D{{Key: "sha256", Value: 1}}},
+		{Keys: bson.D{{Key: "file_id", Value: 1}}},
+	})
+
+	return err
+}
+
+// gridfsReader serves a GridFS file as an io.ReadSeeker so downloads can
+// honour range requests. GridFS streams only move forward, so seeking
+// closes the stream and the next read reopens it at the new offset.
+type gridfsReader struct {
+	bucket *gridfs.Bucket
+	fileID primitive.ObjectID
+	size   int64
+	offset int64
+	stream *gridfs.DownloadStream
+}
+
+func (r *gridfsReader) Read(p []byte) (int, error) {
+
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.stream == nil {
+		stream, err := r.bucket.OpenDownloadStream(r.fileID)
+		if err != nil {
+			return 0, err
+		}
+		if r.offset > 0 {
+			if _, err := stream.Skip(r.offset); err != nil {
+				stream.Close()
+				return 0, err
+			}
+		}
+		r.stream = stream
+	}
+
+	n, err := r.stream.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *gridfsReader) Seek(offset int64, whence int) (int64, error) {
+
+	var position int64
+	switch whence {
+	case io.SeekStart:
+		position = offset
+	case io.SeekCurrent:
+		position = r.offset + offset
+	case io.SeekEnd:
+		position = r.size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if position < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if position != r.offset && r.stream != nil {
+		r.stream.Close()
+		r.stream = nil
+	}
+	r.offset = position
+	return position, nil
+}
+
+func (r *gridfsReader) Close() error {
+	if r.stream == nil {
+		return nil
+	}
+	return r.stream.Close()
+}
@@ -0,0 +1,64 @@
+package repositories_test
+
+import (
+	"io"
+	"strings"
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCreateAttachment(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("reuses the stored file for identical content", func(mt *mtest.T) {
+		repo := repositories.NewAttachmentRepository(mt.Coll.Database())
+
+		fileID := primitive.NewObjectID()
+		existing := mtest.CreateCursorResponse(0, "attachments.attachment", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "file_id", Value: fileID},
+			{Key: "sha256", Value: "abc"},
+		})
+		mt.AddMockResponses(existing, mtest.CreateSuccessResponse())
+
+		attachment := &domain.Attachment{Filename: "spec.txt", SHA256: "abc"}
+		err := repo.CreateAttachment(attachment, strings.NewReader("spec"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, fileID, attachment.FileID)
+		assert.False(t, attachment.ID.IsZero())
+		started := mt.GetAllStartedEvents()
+		assert.Len(t, started, 2)
+		assert.Equal(t, "attachments", started[1].Command.Lookup("insert").StringValue())
+	})
+}
+
+func TestOpenAttachment(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("seeks without touching the database", func(mt *mtest.T) {
+		repo := repositories.NewAttachmentRepository(mt.Coll.Database())
+
+		content, err := repo.OpenAttachment(&domain.Attachment{FileID: primitive.NewObjectID(), Size: 42})
+		assert.NoError(t, err)
+
+		size, err := content.Seek(0, io.SeekEnd)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), size)
+
+		n, err := content.Read(make([]byte, 8))
+		assert.Equal(t, 0, n)
+		assert.Equal(t, io.EOF, err)
+
+		_, err = content.Seek(-1, io.SeekStart)
+		assert.Error(t, err)
+		assert.NoError(t, content.Close())
+		assert.Empty(t, mt.GetAllStartedEvents())
+	})
+}
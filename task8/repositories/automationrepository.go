@@ -0,0 +1,224 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AutomationRepository struct {
+	collection *mongo.Collection
+	runs       *mongo.Collection
+	workspace  workspaceScope
+}
+
+func NewAutomationRepository(db *mongo.Database) *AutomationRepository {
+	collection := db.Collection("automations")
+	runs := db.Collection("automation_runs")
+	return &AutomationRepository{collection: collection, runs: runs}
+}
+
+// InWorkspace is the repository limited to the automations of one
+// workspace, the personal one for the zero ID.
+func (ar *AutomationRepository) InWorkspace(workspaceid primitive.ObjectID) domain.AutomationRepositoryInterface {
+	return &AutomationRepository{collection: ar.collection, runs: ar.runs, workspace: inWorkspace(workspaceid)}
+}
+
+func (ar *AutomationRepository) CreateAutomation(newautomation *domain.Automation, userid string) error {
+
+	userObjectID, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	newautomation.UserID = userObjectID
+	ar.workspace.assign(&newautomation.WorkspaceID)
+
+	result, err := ar.collection.InsertOne(context.TODO(), newautomation)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+
+	newautomation.ID = oid
+	return nil
+}
+
+func (ar *AutomationRepository) GetAutomation(id string) (*domain.Automation, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var automation domain.Automation
+
+	err = ar.collection.FindOne(context.TODO(), ar.workspace.filter(bson.M{"_id": oid})).Decode(&automation)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &automation, nil
+}
+
+// GetAutomations lists the automations of the workspace by name. Personal
+// automations are only the user's own.
+func (ar *AutomationRepository) GetAutomations(userid string) (*[]domain.Automation, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+
+	query := bson.M{}
+	if ar.workspace.id.IsZero() {
+		query["user_id"] = uid
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := ar.collection.Find(context.TODO(), ar.workspace.filter(query), opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	automations := []domain.Automation{}
+
+	if err = cursor.All(context.TODO(), &automations); err != nil {
+		return nil, err
+	}
+	return &automations, nil
+}
+
+func (ar *AutomationRepository) UpdateAutomation(id string, updatedautomation *domain.Automation) error {
+
+	automation, err := ar.GetAutomation(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.collection.UpdateOne(context.TODO(), ar.workspace.filter(bson.M{"_id": automation.ID}), bson.D{{Key: "$set", Value: bson.M{
+		"name":       updatedautomation.Name,
+		"trigger":    updatedautomation.Trigger,
+		"conditions": updatedautomation.Conditions,
+		"actions":    updatedautomation.Actions,
+		"disabled":   updatedautomation.Disabled,
+	}}})
+
+	if err != nil {
+		return err
+	}
+
+	updatedautomation.ID = automation.ID
+	updatedautomation.UserID = automation.UserID
+	updatedautomation.WorkspaceID = automation.WorkspaceID
+	updatedautomation.CreatedAt = automation.CreatedAt
+	return nil
+}
+
+// RemoveAutomation deletes an automation along with its execution log.
+func (ar *AutomationRepository) RemoveAutomation(id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := ar.collection.DeleteOne(context.TODO(), ar.workspace.filter(bson.M{"_id": oid}))
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	_, err = ar.runs.DeleteMany(context.TODO(), bson.M{"automation_id": oid})
+	return err
+}
+
+// GetTriggered returns the enabled automations the event on the task
+// triggers: those of its workspace, or of its creator for a personal task.
+// Their conditions are left to the caller.
+func (ar *AutomationRepository) GetTriggered(event string, task *domain.Task) (*[]domain.Automation, error) {
+
+	query := bson.M{"trigger": event, "disabled": bson.M{"$ne": true}, "workspace_id": workspaceValue(task.WorkspaceID)}
+	if task.WorkspaceID.IsZero() {
+		query["user_id"] = task.UserID
+	}
+	cursor, err := ar.collection.Find(context.TODO(), query, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	automations := []domain.Automation{}
+
+	if err = cursor.All(context.TODO(), &automations); err != nil {
+		return nil, err
+	}
+	return &automations, nil
+}
+
+func (ar *AutomationRepository) LogRun(run *domain.AutomationRun) error {
+
+	result, err := ar.runs.InsertOne(context.TODO(), run)
+	if err != nil {
+		return err
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		run.ID = oid
+	}
+	return nil
+}
+
+// GetRuns returns the latest entries of an automation's execution log,
+// newest first.
+func (ar *AutomationRepository) GetRuns(automationid string) (*[]domain.AutomationRun, error) {
+	oid, err := primitive.ObjectIDFromHex(automationid)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: -1}}).SetLimit(100)
+	cursor, err := ar.runs.Find(context.TODO(), bson.M{"automation_id": oid}, opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	runs := []domain.AutomationRun{}
+
+	if err = cursor.All(context.TODO(), &runs); err != nil {
+		return nil, err
+	}
+	return &runs, nil
+}
+
+// EnsureIndexes supports finding the automations an event triggers, and
+// keeps the execution log to a month.
+func (ar *AutomationRepository) EnsureIndexes() error {
+
+	_, err := ar.collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "workspace_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "trigger", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.runs.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "automation_id", Value: 1}, {Key: "at", Value: -1}}},
+		{Keys: bson.D{{Key: "at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(30 * 24 * 60 * 60)},
+	})
+
+	return err
+}
@@ -0,0 +1,147 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCreateAutomation(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("puts the automation into the workspace", func(mt *mtest.T) {
+		workspaceID := primitive.NewObjectID()
+		repo := repositories.NewAutomationRepository(mt.Coll.Database()).InWorkspace(workspaceID)
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		automation := &domain.Automation{Name: "Archive", Trigger: domain.EventTaskCompleted}
+		userID := primitive.NewObjectID()
+
+		err := repo.CreateAutomation(automation, userID.Hex())
+
+		assert.NoError(t, err)
+		assert.False(t, automation.ID.IsZero())
+		assert.Equal(t, userID, automation.UserID)
+		assert.Equal(t, workspaceID, automation.WorkspaceID)
+	})
+
+	mt.Run("fails due to invalid userID", func(mt *mtest.T) {
+		repo := repositories.NewAutomationRepository(mt.Coll.Database())
+
+		err := repo.CreateAutomation(&domain.Automation{Name: "Archive"}, "invalidUserID")
+
+		assert.EqualError(t, err, "user ID is not a valid ObjectID")
+	})
+}
+
+func TestGetAutomations(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("personal automations are the user's own", func(mt *mtest.T) {
+		repo := repositories.NewAutomationRepository(mt.Coll.Database()).InWorkspace(primitive.NilObjectID)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.automations", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "name", Value: "Archive"},
+			{Key: "actions", Value: bson.A{bson.D{{Key: "type", Value: "add_tag"}, {Key: "value", Value: "archived"}}}},
+		}))
+
+		automations, err := repo.GetAutomations(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, *automations, 1)
+		assert.Equal(t, []domain.AutomationAction{{Type: domain.ActionAddTag, Value: "archived"}}, (*automations)[0].Actions)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"user_id"`)
+		assert.Contains(t, command, `"workspace_id": null`)
+	})
+}
+
+func TestGetTriggered(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("automations of the task's workspace", func(mt *mtest.T) {
+		repo := repositories.NewAutomationRepository(mt.Coll.Database())
+		task := &domain.Task{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), WorkspaceID: primitive.NewObjectID()}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.automations", mtest.FirstBatch, bson.D{{Key: "_id", Value: primitive.NewObjectID()}}))
+
+		automations, err := repo.GetTriggered(domain.EventTaskCompleted, task)
+
+		assert.NoError(t, err)
+		assert.Len(t, *automations, 1)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"trigger": "task.completed"`)
+		assert.Contains(t, command, `"disabled": {"$ne": true}`)
+		assert.Contains(t, command, task.WorkspaceID.Hex())
+		assert.NotContains(t, command, "user_id")
+	})
+
+	mt.Run("personal automations of the task's creator", func(mt *mtest.T) {
+		repo := repositories.NewAutomationRepository(mt.Coll.Database())
+		task := &domain.Task{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID()}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.automations", mtest.FirstBatch))
+
+		automations, err := repo.GetTriggered(domain.EventTaskOverdue, task)
+
+		assert.NoError(t, err)
+		assert.Empty(t, *automations)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"workspace_id": null`)
+		assert.Contains(t, command, task.UserID.Hex())
+	})
+}
+
+func TestAutomationRuns(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("logs a run", func(mt *mtest.T) {
+		repo := repositories.NewAutomationRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		run := &domain.AutomationRun{AutomationID: primitive.NewObjectID(), Status: domain.RunSkipped}
+
+		err := repo.LogRun(run)
+
+		assert.NoError(t, err)
+		assert.False(t, run.ID.IsZero())
+		assert.Equal(t, "automation_runs", mt.GetStartedEvent().Command.Lookup("insert").StringValue())
+	})
+
+	mt.Run("lists the latest runs first", func(mt *mtest.T) {
+		repo := repositories.NewAutomationRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.automation_runs", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "status", Value: "failed"}, {Key: "error", Value: "task not found"}},
+		))
+
+		runs, err := repo.GetRuns(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "task not found", (*runs)[0].Error)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"sort": {"at": {"$numberInt":"-1"}}`)
+		assert.Contains(t, command, `"limit": {"$numberLong":"100"}`)
+	})
+}
+
+func TestRemoveAutomation(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("not found", func(mt *mtest.T) {
+		repo := repositories.NewAutomationRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}})
+
+		err := repo.RemoveAutomation(primitive.NewObjectID().Hex())
+
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+	})
+}
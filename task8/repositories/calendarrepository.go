@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CalendarRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCalendarRepository(db *mongo.Database) *CalendarRepository {
+	collection := db.Collection("calendar_feeds")
+	return &CalendarRepository{collection: collection}
+}
+
+// EnsureIndexes keeps one feed per user and makes token lookups unique.
+func (cr *CalendarRepository) EnsureIndexes() error {
+
+	_, err := cr.collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+
+	return err
+}
+
+// SaveToken gives the user's feed a new token, creating the feed on first
+// use. The old token stops working at once.
+func (cr *CalendarRepository) SaveToken(userid string, tokenhash string) (*domain.CalendarFeed, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+
+	update := bson.M{"$set": bson.M{"token_hash": tokenhash, "created_at": time.Now()}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var feed domain.CalendarFeed
+
+	err = cr.collection.FindOneAndUpdate(context.TODO(), bson.M{"user_id": uid}, update, opts).Decode(&feed)
+
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// SetTimezone changes the zone of the user's feed. It returns nil when the
+// user has no feed.
+func (cr *CalendarRepository) SetTimezone(userid string, timezone string) (*domain.CalendarFeed, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var feed domain.CalendarFeed
+
+	err = cr.collection.FindOneAndUpdate(context.TODO(), bson.M{"user_id": uid}, bson.M{"$set": bson.M{"timezone": timezone}}, opts).Decode(&feed)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// GetFeed returns the user's feed, or nil when there is none.
+func (cr *CalendarRepository) GetFeed(userid string) (*domain.CalendarFeed, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	return cr.findFeed(bson.M{"user_id": uid})
+}
+
+// GetFeedByToken returns the feed a token opens, or nil when none does.
+func (cr *CalendarRepository) GetFeedByToken(tokenhash string) (*domain.CalendarFeed, error) {
+	return cr.findFeed(bson.M{"token_hash": tokenhash})
+}
+
+func (cr *CalendarRepository) findFeed(filter bson.M) (*domain.CalendarFeed, error) {
+
+	var feed domain.CalendarFeed
+
+	err := cr.collection.FindOne(context.TODO(), filter).Decode(&feed)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
@@ -0,0 +1,64 @@
+package repositories_test
+
+import (
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCalendarRepository(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("save token upserts the user's feed", func(mt *mtest.T) {
+		repo := repositories.NewCalendarRepository(mt.Coll.Database())
+		userID := primitive.NewObjectID()
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "user_id", Value: userID},
+			{Key: "token_hash", Value: "hash"},
+		}}})
+
+		feed, err := repo.SaveToken(userID.Hex(), "hash")
+
+		assert.NoError(t, err)
+		assert.Empty(t, feed.Timezone)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"upsert": true`)
+		assert.NotContains(t, command, "timezone")
+	})
+
+	mt.Run("set timezone without a feed", func(mt *mtest.T) {
+		repo := repositories.NewCalendarRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: nil}})
+
+		feed, err := repo.SetTimezone(primitive.NewObjectID().Hex(), "Europe/Berlin")
+
+		assert.NoError(t, err)
+		assert.Nil(t, feed)
+	})
+
+	mt.Run("unknown token", func(mt *mtest.T) {
+		repo := repositories.NewCalendarRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.calendar_feeds", mtest.FirstBatch))
+
+		feed, err := repo.GetFeedByToken("hash")
+
+		assert.NoError(t, err)
+		assert.Nil(t, feed)
+	})
+
+	mt.Run("invalid user ID", func(mt *mtest.T) {
+		repo := repositories.NewCalendarRepository(mt.Coll.Database())
+
+		_, err := repo.GetFeed("invalidUserID")
+
+		assert.EqualError(t, err, "user ID is not a valid ObjectID")
+	})
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommentRepository struct {
+	collection *mongo.Collection
+	tasks      *mongo.Collection
+}
+
+func NewCommentRepository(db *mongo.Database) *CommentRepository {
+	collection := db.Collection("comments")
+	tasks := db.Collection("tasks")
+	return &CommentRepository{collection: collection, tasks: tasks}
+}
+
+// CreateComment stores the comment and bumps the comment count kept on the
+// task, so task listings can show it without a lookup.
+func (cr *CommentRepository) CreateComment(newcomment *domain.Comment) error {
+
+	result, err := cr.collection.InsertOne(context.TODO(), newcomment)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+	newcomment.ID = oid
+
+	_, err = cr.tasks.UpdateOne(context.TODO(), bson.M{"_id": newcomment.TaskID}, bson.D{{Key: "$inc", Value: bson.M{"comment_count": 1}}})
+
+	return err
+}
+
+func (cr *CommentRepository) GetComment(id string) (*domain.Comment, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment domain.Comment
+
+	err = cr.collection.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(&comment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (cr *CommentRepository) GetComments(taskid string) (*[]domain.Comment, error) {
+	tid, err := primitive.ObjectIDFromHex(taskid)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := cr.collection.Find(context.TODO(), bson.M{"task_id": tid}, opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var comments []domain.Comment
+
+	if err = cursor.All(context.TODO(), &comments); err != nil {
+		return nil, err
+	}
+	return &comments, nil
+}
+
+func (cr *CommentRepository) UpdateComment(id string, updatedcomment *domain.Comment) error {
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = cr.collection.UpdateOne(context.TODO(), bson.M{"_id": oid}, bson.D{{Key: "$set", Value: bson.M{
+		"body":       updatedcomment.Body,
+		"mentions":   updatedcomment.Mentions,
+		"updated_at": updatedcomment.UpdatedAt,
+	}}})
+
+	return err
+}
+
+// RemoveComments deletes the given comments of a task and lowers the task's
+// comment count by the number actually removed.
+func (cr *CommentRepository) RemoveComments(taskid string, ids []string) error {
+
+	tid, err := primitive.ObjectIDFromHex(taskid)
+	if err != nil {
+		return err
+	}
+
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return err
+		}
+		oids = append(oids, oid)
+	}
+
+	result, err := cr.collection.DeleteMany(context.TODO(), bson.M{"task_id": tid, "_id": bson.M{"$in": oids}})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = cr.tasks.UpdateOne(context.TODO(), bson.M{"_id": tid}, bson.D{{Key: "$inc", Value: bson.M{"comment_count": -result.DeletedCount}}})
+
+	return err
+}
+
+// RemoveTaskComments deletes every comment on a task, which is being
+// deleted itself.
+func (cr *CommentRepository) RemoveTaskComments(taskid string) error {
+
+	tid, err := primitive.ObjectIDFromHex(taskid)
+	if err != nil {
+		return err
+	}
+
+	_, err = cr.collection.DeleteMany(context.TODO(), bson.M{"task_id": tid})
+
+	return err
+}
+
+func (cr *CommentRepository) EnsureIndexes() error {
+
+	_, err := cr.collection.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+
+	return err
+}
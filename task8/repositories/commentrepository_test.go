@@ -0,0 +1,71 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCreateComment(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("stores the comment and counts it on the task", func(mt *mtest.T) {
+		repo := repositories.NewCommentRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		comment := &domain.Comment{TaskID: primitive.NewObjectID(), Body: "hello"}
+		err := repo.CreateComment(comment)
+
+		assert.NoError(t, err)
+		assert.False(t, comment.ID.IsZero())
+		started := mt.GetAllStartedEvents()
+		assert.Equal(t, "tasks", started[1].Command.Lookup("update").StringValue())
+		assert.Contains(t, started[1].Command.String(), `"comment_count": {"$numberInt":"1"}`)
+	})
+}
+
+func TestRemoveComments(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("decrements by the deleted count", func(mt *mtest.T) {
+		repo := repositories.NewCommentRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 2}}, mtest.CreateSuccessResponse())
+
+		err := repo.RemoveComments(primitive.NewObjectID().Hex(), []string{primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()})
+
+		assert.NoError(t, err)
+		started := mt.GetAllStartedEvents()
+		assert.Contains(t, started[1].Command.String(), `"comment_count": {"$numberLong":"-2"}`)
+	})
+
+	mt.Run("fails due to invalid comment id", func(mt *mtest.T) {
+		repo := repositories.NewCommentRepository(mt.Coll.Database())
+
+		err := repo.RemoveComments(primitive.NewObjectID().Hex(), []string{"invalidID"})
+
+		assert.EqualError(t, err, "the provided hex string is not a valid ObjectID")
+	})
+}
+
+func TestRemoveTaskComments(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("deletes the comments of the task", func(mt *mtest.T) {
+		repo := repositories.NewCommentRepository(mt.Coll.Database())
+		taskID := primitive.NewObjectID()
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 3}})
+
+		err := repo.RemoveTaskComments(taskID.Hex())
+
+		assert.NoError(t, err)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"task_id": {"$oid":"`+taskID.Hex()+`"}`)
+	})
+}
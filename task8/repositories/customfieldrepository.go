@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CustomFieldRepository struct {
+	collection *mongo.Collection
+	tasks      *mongo.Collection
+	workspace  workspaceScope
+}
+
+func NewCustomFieldRepository(db *mongo.Database) *CustomFieldRepository {
+	collection := db.Collection("custom_fields")
+	tasks := db.Collection("tasks")
+	return &CustomFieldRepository{collection: collection, tasks: tasks}
+}
+
+// InWorkspace is the repository limited to the fields of one workspace, the
+// personal one for the zero ID.
+func (cr *CustomFieldRepository) InWorkspace(workspaceid primitive.ObjectID) domain.CustomFieldRepositoryInterface {
+	return &CustomFieldRepository{collection: cr.collection, tasks: cr.tasks, workspace: inWorkspace(workspaceid)}
+}
+
+func (cr *CustomFieldRepository) CreateField(newfield *domain.CustomField, userid string) error {
+
+	userObjectID, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	newfield.UserID = userObjectID
+	cr.workspace.assign(&newfield.WorkspaceID)
+
+	result, err := cr.collection.InsertOne(context.TODO(), newfield)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+
+	newfield.ID = oid
+	return nil
+}
+
+func (cr *CustomFieldRepository) GetField(id string) (*domain.CustomField, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var field domain.CustomField
+
+	err = cr.collection.FindOne(context.TODO(), cr.workspace.filter(bson.M{"_id": oid})).Decode(&field)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &field, nil
+}
+
+// GetFields lists the fields of the workspace by name. Personal fields are
+// only the user's own.
+func (cr *CustomFieldRepository) GetFields(userid string) (*[]domain.CustomField, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+
+	query := bson.M{}
+	if cr.workspace.id.IsZero() {
+		query["user_id"] = uid
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := cr.collection.Find(context.TODO(), cr.workspace.filter(query), opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	fields := []domain.CustomField{}
+
+	if err = cursor.All(context.TODO(), &fields); err != nil {
+		return nil, err
+	}
+	return &fields, nil
+}
+
+// UpdateField renames a field and changes its options; the key and type
+// stay, as task values depend on them.
+func (cr *CustomFieldRepository) UpdateField(id string, updatedfield *domain.CustomField) error {
+
+	field, err := cr.GetField(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = cr.collection.UpdateOne(context.TODO(), cr.workspace.filter(bson.M{"_id": field.ID}), bson.D{{Key: "$set", Value: bson.M{
+		"name":    updatedfield.Name,
+		"options": updatedfield.Options,
+	}}})
+
+	if err != nil {
+		return err
+	}
+
+	// Tasks holding an option the enum no longer has lose the value, so
+	// that they can be saved again as they are.
+	if field.Type == domain.FieldEnum {
+		stale := fieldTasks(field)
+		stale["fields."+field.Key] = bson.M{"$exists": true, "$nin": updatedfield.Options}
+		_, err = cr.tasks.UpdateMany(context.TODO(), stale, bson.D{{Key: "$unset", Value: bson.M{"fields." + field.Key: ""}}})
+		if err != nil {
+			return err
+		}
+	}
+
+	updatedfield.ID = field.ID
+	updatedfield.UserID = field.UserID
+	updatedfield.WorkspaceID = field.WorkspaceID
+	updatedfield.Key = field.Key
+	updatedfield.Type = field.Type
+	updatedfield.CreatedAt = field.CreatedAt
+	return nil
+}
+
+// RemoveField deletes a field and its values from the tasks it was
+// defined for.
+func (cr *CustomFieldRepository) RemoveField(id string) error {
+
+	field, err := cr.GetField(id)
+	if err != nil {
+		return err
+	}
+
+	if _, err = cr.collection.DeleteOne(context.TODO(), bson.M{"_id": field.ID}); err != nil {
+		return err
+	}
+
+	_, err = cr.tasks.UpdateMany(context.TODO(), fieldTasks(field), bson.D{{Key: "$unset", Value: bson.M{"fields." + field.Key: ""}}})
+
+	return err
+}
+
+func (cr *CustomFieldRepository) EnsureIndexes() error {
+
+	_, err := cr.collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "workspace_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "key", Value: 1}}},
+	})
+
+	return err
+}
+
+// fieldTasks matches the tasks a field is defined for: those of its
+// workspace, or the owner's personal ones.
+func fieldTasks(field *domain.CustomField) bson.M {
+
+	tasks := bson.M{"workspace_id": workspaceValue(field.WorkspaceID)}
+	if field.WorkspaceID.IsZero() {
+		tasks["user_id"] = field.UserID
+	}
+	return tasks
+}
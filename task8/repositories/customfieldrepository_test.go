@@ -0,0 +1,156 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCreateField(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("puts the field into the workspace", func(mt *mtest.T) {
+		workspaceID := primitive.NewObjectID()
+		repo := repositories.NewCustomFieldRepository(mt.Coll.Database()).InWorkspace(workspaceID)
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		field := &domain.CustomField{Key: "sprint", Name: "Sprint", Type: domain.FieldEnum, Options: []string{"24", "25"}}
+		userID := primitive.NewObjectID()
+
+		err := repo.CreateField(field, userID.Hex())
+
+		assert.NoError(t, err)
+		assert.False(t, field.ID.IsZero())
+		assert.Equal(t, userID, field.UserID)
+		assert.Equal(t, workspaceID, field.WorkspaceID)
+	})
+
+	mt.Run("fails due to invalid userID", func(mt *mtest.T) {
+		repo := repositories.NewCustomFieldRepository(mt.Coll.Database())
+
+		err := repo.CreateField(&domain.CustomField{Key: "sprint"}, "invalidUserID")
+
+		assert.EqualError(t, err, "user ID is not a valid ObjectID")
+	})
+}
+
+func TestGetFields(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("personal fields are the user's own", func(mt *mtest.T) {
+		repo := repositories.NewCustomFieldRepository(mt.Coll.Database()).InWorkspace(primitive.NilObjectID)
+		userID := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.custom_fields", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "key", Value: "estimate"},
+			{Key: "type", Value: "number"},
+		}))
+
+		fields, err := repo.GetFields(userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FieldNumber, (*fields)[0].Type)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, userID.Hex())
+		assert.Contains(t, command, `"workspace_id": null`)
+	})
+}
+
+func TestUpdateField(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("clears options the enum no longer has from tasks", func(mt *mtest.T) {
+		workspaceID := primitive.NewObjectID()
+		repo := repositories.NewCustomFieldRepository(mt.Coll.Database()).InWorkspace(workspaceID)
+		fieldID := primitive.NewObjectID()
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "test.custom_fields", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: fieldID},
+				{Key: "workspace_id", Value: workspaceID},
+				{Key: "key", Value: "stage"},
+				{Key: "type", Value: "enum"},
+				{Key: "options", Value: bson.A{"open", "lost", "won"}},
+			}),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 2}, {Key: "nModified", Value: 2}},
+		)
+
+		err := repo.UpdateField(fieldID.Hex(), &domain.CustomField{Name: "Stage", Options: []string{"open", "won"}})
+
+		assert.NoError(t, err)
+		events := mt.GetAllStartedEvents()
+		assert.Len(t, events, 3)
+		update := events[2].Command.String()
+		assert.Contains(t, update, `"update": "tasks"`)
+		assert.Contains(t, update, `"$nin": ["open","won"]`)
+		assert.Contains(t, update, `"$unset": {"fields.stage": ""}`)
+		assert.Contains(t, update, workspaceID.Hex())
+	})
+
+	mt.Run("other types leave tasks alone", func(mt *mtest.T) {
+		repo := repositories.NewCustomFieldRepository(mt.Coll.Database())
+		fieldID := primitive.NewObjectID()
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "test.custom_fields", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: fieldID},
+				{Key: "key", Value: "customer"},
+				{Key: "type", Value: "text"},
+			}),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+		)
+
+		err := repo.UpdateField(fieldID.Hex(), &domain.CustomField{Name: "Client"})
+
+		assert.NoError(t, err)
+		assert.Len(t, mt.GetAllStartedEvents(), 2)
+	})
+}
+
+func TestRemoveField(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("removes the values from the workspace's tasks", func(mt *mtest.T) {
+		workspaceID := primitive.NewObjectID()
+		repo := repositories.NewCustomFieldRepository(mt.Coll.Database()).InWorkspace(workspaceID)
+		fieldID := primitive.NewObjectID()
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "test.custom_fields", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: fieldID},
+				{Key: "workspace_id", Value: workspaceID},
+				{Key: "key", Value: "customer"},
+			}),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 4}, {Key: "nModified", Value: 4}},
+		)
+
+		err := repo.RemoveField(fieldID.Hex())
+
+		assert.NoError(t, err)
+		events := mt.GetAllStartedEvents()
+		assert.Len(t, events, 3)
+		update := events[2].Command.String()
+		assert.Contains(t, update, `"update": "tasks"`)
+		assert.Contains(t, update, `"$unset": {"fields.customer": ""}`)
+		assert.Contains(t, update, workspaceID.Hex())
+	})
+
+	mt.Run("not found", func(mt *mtest.T) {
+		repo := repositories.NewCustomFieldRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.custom_fields", mtest.FirstBatch))
+
+		err := repo.RemoveField(primitive.NewObjectID().Hex())
+
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+	})
+}
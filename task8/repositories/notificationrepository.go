@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NotificationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewNotificationRepository(db *mongo.Database) *NotificationRepository {
+	collection := db.Collection("notifications")
+	return &NotificationRepository{collection: collection}
+}
+
+// CreateNotification stores an in-app notification. A notification whose ID
+// is already stored is left as it is, so redelivering it is harmless.
+func (nr *NotificationRepository) CreateNotification(notification *domain.Notification) error {
+
+	if notification.ID.IsZero() {
+		notification.ID = primitive.NewObjectID()
+	}
+
+	_, err := nr.collection.UpdateOne(context.TODO(),
+		bson.M{"_id": notification.ID},
+		bson.D{{Key: "$setOnInsert", Value: bson.M{
+			"user_id":    notification.UserID,
+			"task_id":    notification.TaskID,
+			"title":      notification.Title,
+			"message":    notification.Message,
+			"read":       notification.Read,
+			"created_at": notification.CreatedAt,
+		}}},
+		options.Update().SetUpsert(true))
+
+	return err
+}
+
+func (nr *NotificationRepository) GetNotifications(userid string) (*[]domain.Notification, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(100)
+	cursor, err := nr.collection.Find(context.TODO(), bson.M{"user_id": uid}, opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var notifications []domain.Notification
+
+	if err = cursor.All(context.TODO(), &notifications); err != nil {
+		return nil, err
+	}
+	return &notifications, nil
+}
+
+func (nr *NotificationRepository) ReadNotification(id string, userid string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return err
+	}
+
+	result, err := nr.collection.UpdateOne(context.TODO(), bson.M{"_id": oid, "user_id": uid}, bson.D{{Key: "$set", Value: bson.M{"read": true}}})
+
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("notification not found")
+	}
+	return nil
+}
+
+func (nr *NotificationRepository) EnsureIndexes() error {
+
+	_, err := nr.collection.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+	})
+
+	return err
+}
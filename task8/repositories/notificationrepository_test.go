@@ -0,0 +1,44 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCreateNotification(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("upserts by ID so redelivery is a no-op", func(mt *mtest.T) {
+		repo := repositories.NewNotificationRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		id := primitive.NewObjectID()
+		err := repo.CreateNotification(&domain.Notification{ID: id, Title: "Reminder"})
+
+		assert.NoError(t, err)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"$setOnInsert"`)
+		assert.Contains(t, command, `"upsert": true`)
+	})
+}
+
+func TestReadNotification(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("someone else's notification", func(mt *mtest.T) {
+		repo := repositories.NewNotificationRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}})
+
+		err := repo.ReadNotification(primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex())
+
+		assert.EqualError(t, err, "notification not found")
+	})
+}
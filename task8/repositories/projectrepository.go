@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ProjectRepository struct {
+	collection *mongo.Collection
+	tasks      *mongo.Collection
+	workspace  workspaceScope
+}
+
+func NewProjectRepository(db *mongo.Database) *ProjectRepository {
+	collection := db.Collection("projects")
+	tasks := db.Collection("tasks")
+	return &ProjectRepository{collection: collection, tasks: tasks}
+}
+
+// InWorkspace is the repository limited to the projects of one workspace,
+// the personal one for the zero ID. Projects created through it are put
+// there.
+func (pr *ProjectRepository) InWorkspace(workspaceid primitive.ObjectID) domain.ProjectRepositoryInterface {
+	return &ProjectRepository{collection: pr.collection, tasks: pr.tasks, workspace: inWorkspace(workspaceid)}
+}
+
+func (pr *ProjectRepository) CreateProject(newproject *domain.Project) error {
+
+	pr.workspace.assign(&newproject.WorkspaceID)
+	result, err := pr.collection.InsertOne(context.TODO(), newproject)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+
+	newproject.ID = oid
+	return nil
+}
+
+func (pr *ProjectRepository) GetProject(id string) (*domain.Project, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var project domain.Project
+
+	err = pr.collection.FindOne(context.TODO(), pr.workspace.filter(bson.M{"_id": oid})).Decode(&project)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// GetProjects returns every project the user is a member of, whatever the role.
+func (pr *ProjectRepository) GetProjects(userid string) (*[]domain.Project, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := pr.collection.Find(context.TODO(), pr.workspace.filter(bson.M{"members.user_id": uid}))
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var projects []domain.Project
+
+	if err = cursor.All(context.TODO(), &projects); err != nil {
+		return nil, err
+	}
+	return &projects, nil
+}
+
+func (pr *ProjectRepository) UpdateProject(id string, updatedproject *domain.Project) error {
+
+	oid, err := primitive.ObjectIDFromHex(id)
+
+	if err != nil {
+		return err
+	}
+	_, err = pr.collection.UpdateOne(context.TODO(), pr.workspace.filter(bson.M{"_id": oid}), bson.D{{Key: "$set", Value: bson.M{
+		"name":        updatedproject.Name,
+		"description": updatedproject.Description,
+		"members":     updatedproject.Members,
+	}}})
+
+	return err
+}
+
+// RemoveProject deletes the project together with the tasks that belong to it.
+func (pr *ProjectRepository) RemoveProject(id string) error {
+
+	oid, err := primitive.ObjectIDFromHex(id)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = pr.tasks.DeleteMany(context.TODO(), pr.workspace.filter(bson.M{"project_id": oid}))
+
+	if err != nil {
+		return err
+	}
+
+	_, err = pr.collection.DeleteOne(context.TODO(), pr.workspace.filter(bson.M{"_id": oid}))
+
+	return err
+}
+
+func (pr *ProjectRepository) EnsureIndexes() error {
+
+	_, err := pr.collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "members.user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "workspace_id", Value: 1}}},
+	})
+
+	return err
+}
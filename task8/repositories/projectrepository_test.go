@@ -0,0 +1,79 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCreateProject(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("successfully creates a project", func(mt *mtest.T) {
+		repo := repositories.NewProjectRepository(mt.Coll.Database())
+
+		project := &domain.Project{Name: "Website"}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.CreateProject(project)
+
+		assert.NoError(t, err)
+		assert.False(t, project.ID.IsZero())
+	})
+}
+
+func TestGetProjects(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("finds projects by membership", func(mt *mtest.T) {
+		repo := repositories.NewProjectRepository(mt.Coll.Database())
+
+		userID := primitive.NewObjectID()
+		projectID := primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "projects.project", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: projectID},
+			{Key: "name", Value: "Website"},
+			{Key: "members", Value: bson.A{bson.D{{Key: "user_id", Value: userID}, {Key: "role", Value: "owner"}}}},
+		}))
+
+		projects, err := repo.GetProjects(userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, &[]domain.Project{{
+			ID:      projectID,
+			Name:    "Website",
+			Members: []domain.ProjectMember{{UserID: userID, Role: domain.ProjectOwner}},
+		}}, projects)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"members.user_id"`)
+	})
+
+	mt.Run("fails due to invalid userID", func(mt *mtest.T) {
+		repo := repositories.NewProjectRepository(mt.Coll.Database())
+
+		_, err := repo.GetProjects("invalidUserID")
+
+		assert.EqualError(t, err, "the provided hex string is not a valid ObjectID")
+	})
+}
+
+func TestRemoveProject(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("removes the project and its tasks", func(mt *mtest.T) {
+		repo := repositories.NewProjectRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		err := repo.RemoveProject(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		started := mt.GetAllStartedEvents()
+		assert.Len(t, started, 2)
+		assert.Equal(t, "tasks", started[0].Command.Lookup("delete").StringValue())
+	})
+}
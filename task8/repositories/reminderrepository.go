@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReminderRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReminderRepository(db *mongo.Database) *ReminderRepository {
+	collection := db.Collection("reminders")
+	return &ReminderRepository{collection: collection}
+}
+
+func (rr *ReminderRepository) CreateReminder(newreminder *domain.Reminder) error {
+
+	result, err := rr.collection.InsertOne(context.TODO(), newreminder)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+
+	newreminder.ID = oid
+	return nil
+}
+
+func (rr *ReminderRepository) GetReminder(id string) (*domain.Reminder, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var reminder domain.Reminder
+
+	err = rr.collection.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(&reminder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &reminder, nil
+}
+
+// GetReminders lists the reminders a user set on a task, soonest first.
+func (rr *ReminderRepository) GetReminders(taskid string, userid string) (*[]domain.Reminder, error) {
+	tid, err := primitive.ObjectIDFromHex(taskid)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "remind_at", Value: 1}})
+	cursor, err := rr.collection.Find(context.TODO(), bson.M{"task_id": tid, "user_id": uid}, opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var reminders []domain.Reminder
+
+	if err = cursor.All(context.TODO(), &reminders); err != nil {
+		return nil, err
+	}
+	return &reminders, nil
+}
+
+func (rr *ReminderRepository) RemoveReminder(id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = rr.collection.DeleteOne(context.TODO(), bson.M{"_id": oid})
+
+	return err
+}
+
+func (rr *ReminderRepository) RemoveTaskReminders(taskid string) error {
+	tid, err := primitive.ObjectIDFromHex(taskid)
+	if err != nil {
+		return err
+	}
+
+	_, err = rr.collection.DeleteMany(context.TODO(), bson.M{"task_id": tid})
+
+	return err
+}
+
+// RescheduleReminders moves the pending reminders of a task along with its
+// due date, keeping each one's offset. Sent reminders are left alone.
+func (rr *ReminderRepository) RescheduleReminders(taskid string, duedate time.Time) error {
+	tid, err := primitive.ObjectIDFromHex(taskid)
+	if err != nil {
+		return err
+	}
+
+	_, err = rr.collection.UpdateMany(context.TODO(),
+		bson.M{"task_id": tid, "status": domain.ReminderPending},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"remind_at": bson.M{"$subtract": bson.A{duedate, bson.M{"$multiply": bson.A{"$offset", 1000}}}},
+		}}}})
+
+	return err
+}
+
+// ClaimReminder atomically takes the next due reminder for delivery, leasing
+// it until now+lease. Reminders whose lease ran out, because delivery failed
+// or the process died mid-way, are claimed again. It returns nil when nothing
+// is due.
+func (rr *ReminderRepository) ClaimReminder(now time.Time, lease time.Duration) (*domain.Reminder, error) {
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": domain.ReminderPending, "remind_at": bson.M{"$lte": now}},
+		bson.M{"status": domain.ReminderSending, "locked_until": bson.M{"$lte": now}},
+	}}
+	update := bson.D{
+		{Key: "$set", Value: bson.M{"status": domain.ReminderSending, "locked_until": now.Add(lease)}},
+		{Key: "$inc", Value: bson.M{"attempts": 1}},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "remind_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var reminder domain.Reminder
+
+	err := rr.collection.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&reminder)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// FinishReminder records the outcome of a delivery and releases the lease.
+func (rr *ReminderRepository) FinishReminder(id string, status string, failure string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	set := bson.M{"status": status}
+	if status == domain.ReminderSent {
+		set["sent_at"] = time.Now()
+	}
+	if failure != "" {
+		set["error"] = failure
+	}
+
+	_, err = rr.collection.UpdateOne(context.TODO(), bson.M{"_id": oid}, bson.D{
+		{Key: "$set", Value: set},
+		{Key: "$unset", Value: bson.M{"locked_until": ""}},
+	})
+
+	return err
+}
+
+// EnsureIndexes supports the scheduler's scan for due and expired reminders
+// and the per task listing.
+func (rr *ReminderRepository) EnsureIndexes() error {
+
+	_, err := rr.collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "remind_at", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "locked_until", Value: 1}}},
+		{Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "user_id", Value: 1}}},
+	})
+
+	return err
+}
@@ -0,0 +1,81 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestClaimReminder(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("claims a due reminder", func(mt *mtest.T) {
+		repo := repositories.NewReminderRepository(mt.Coll.Database())
+		id := primitive.NewObjectID()
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: bson.D{{Key: "_id", Value: id}, {Key: "status", Value: domain.ReminderSending}, {Key: "attempts", Value: 1}}},
+		})
+
+		reminder, err := repo.ClaimReminder(time.Now(), time.Minute)
+
+		assert.NoError(t, err)
+		assert.Equal(t, id, reminder.ID)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"findAndModify"`)
+		assert.Contains(t, command, `"locked_until"`)
+		assert.Contains(t, command, `"$inc": {"attempts"`)
+	})
+
+	mt.Run("nothing due", func(mt *mtest.T) {
+		repo := repositories.NewReminderRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: nil}})
+
+		reminder, err := repo.ClaimReminder(time.Now(), time.Minute)
+
+		assert.NoError(t, err)
+		assert.Nil(t, reminder)
+	})
+}
+
+func TestRescheduleReminders(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("moves pending reminders with the due date", func(mt *mtest.T) {
+		repo := repositories.NewReminderRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.RescheduleReminders(primitive.NewObjectID().Hex(), time.Now())
+
+		assert.NoError(t, err)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"status": "pending"`)
+		assert.Contains(t, command, `"$subtract"`)
+	})
+}
+
+func TestFinishReminder(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("marks the reminder sent", func(mt *mtest.T) {
+		repo := repositories.NewReminderRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.FinishReminder(primitive.NewObjectID().Hex(), domain.ReminderSent, "")
+
+		assert.NoError(t, err)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"sent_at"`)
+		assert.Contains(t, command, `"$unset": {"locked_until"`)
+	})
+}
@@ -0,0 +1,202 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TagRepository struct {
+	collection *mongo.Collection
+	tasks      *mongo.Collection
+	// workspaces are those whose tasks a change reaches, nil for all.
+	workspaces bson.A
+}
+
+func NewTagRepository(db *mongo.Database) *TagRepository {
+	collection := db.Collection("tags")
+	tasks := db.Collection("tasks")
+	return &TagRepository{collection: collection, tasks: tasks}
+}
+
+// InWorkspaces is the repository whose renames, merges and removals reach
+// the owner's personal tasks and those of the given workspaces only.
+func (tr *TagRepository) InWorkspaces(workspaceids []primitive.ObjectID) domain.TagRepositoryInterface {
+	workspaces := bson.A{nil}
+	for _, id := range workspaceids {
+		workspaces = append(workspaces, id)
+	}
+	return &TagRepository{collection: tr.collection, tasks: tr.tasks, workspaces: workspaces}
+}
+
+func (tr *TagRepository) CreateTag(newtag *domain.Tag, userid string) error {
+
+	userObjectID, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	newtag.UserID = userObjectID
+
+	result, err := tr.collection.InsertOne(context.TODO(), newtag)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+
+	newtag.ID = oid
+	return nil
+}
+
+func (tr *TagRepository) GetTag(id string) (*domain.Tag, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var tag domain.Tag
+
+	err = tr.collection.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(&tag)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (tr *TagRepository) GetTags(userid string) (*[]domain.Tag, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := tr.collection.Find(context.TODO(), bson.M{"user_id": uid}, opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var tags []domain.Tag
+
+	if err = cursor.All(context.TODO(), &tags); err != nil {
+		return nil, err
+	}
+	return &tags, nil
+}
+
+// UpdateTag saves the new name and colour of a tag. When the name changes,
+// every task of the owner carrying the old name is relabelled in one update.
+func (tr *TagRepository) UpdateTag(id string, updatedtag *domain.Tag) error {
+
+	tag, err := tr.GetTag(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.collection.UpdateOne(context.TODO(), bson.M{"_id": tag.ID}, bson.D{{Key: "$set", Value: bson.M{
+		"name":  updatedtag.Name,
+		"color": updatedtag.Color,
+	}}})
+
+	if err != nil {
+		return err
+	}
+
+	if tag.Name != updatedtag.Name {
+		_, err = tr.tasks.UpdateMany(context.TODO(),
+			tr.taskFilter(bson.M{"user_id": tag.UserID, "tags": tag.Name}),
+			bson.D{{Key: "$set", Value: bson.M{"tags.$": updatedtag.Name}}})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	updatedtag.ID = tag.ID
+	updatedtag.UserID = tag.UserID
+	return nil
+}
+
+// RemoveTag deletes a tag from the catalogue and strips it from the owner's tasks.
+func (tr *TagRepository) RemoveTag(id string) error {
+
+	tag, err := tr.GetTag(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.tasks.UpdateMany(context.TODO(),
+		tr.taskFilter(bson.M{"user_id": tag.UserID, "tags": tag.Name}),
+		bson.D{{Key: "$pull", Value: bson.M{"tags": tag.Name}}})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.collection.DeleteOne(context.TODO(), bson.M{"_id": tag.ID})
+
+	return err
+}
+
+// MergeTags folds the source tag into the target: tasks carrying the source
+// get the target (once) instead, and the source leaves the catalogue.
+func (tr *TagRepository) MergeTags(sourceid string, targetid string) error {
+
+	source, err := tr.GetTag(sourceid)
+	if err != nil {
+		return err
+	}
+	target, err := tr.GetTag(targetid)
+	if err != nil {
+		return err
+	}
+
+	filter := tr.taskFilter(bson.M{"user_id": source.UserID, "tags": source.Name})
+
+	_, err = tr.tasks.UpdateMany(context.TODO(), filter, bson.D{{Key: "$addToSet", Value: bson.M{"tags": target.Name}}})
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.tasks.UpdateMany(context.TODO(), filter, bson.D{{Key: "$pull", Value: bson.M{"tags": source.Name}}})
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.collection.DeleteOne(context.TODO(), bson.M{"_id": source.ID})
+
+	return err
+}
+
+// taskFilter limits a query on the owner's tasks to the workspaces changes
+// reach.
+func (tr *TagRepository) taskFilter(query bson.M) bson.M {
+	if tr.workspaces != nil {
+		query["workspace_id"] = bson.M{"$in": tr.workspaces}
+	}
+	return query
+}
+
+// EnsureIndexes keeps tag names unique within a user's catalogue.
+func (tr *TagRepository) EnsureIndexes() error {
+
+	_, err := tr.collection.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	return err
+}
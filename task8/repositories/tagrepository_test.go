@@ -0,0 +1,150 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func tagResponse(id primitive.ObjectID, userID primitive.ObjectID, name string) bson.D {
+	return mtest.CreateCursorResponse(0, "tags.tag", mtest.FirstBatch, bson.D{
+		{Key: "_id", Value: id},
+		{Key: "user_id", Value: userID},
+		{Key: "name", Value: name},
+		{Key: "color", Value: "#ff0000"},
+	})
+}
+
+func TestCreateTag(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("successfully creates a tag", func(mt *mtest.T) {
+		repo := repositories.NewTagRepository(mt.Coll.Database())
+
+		tag := &domain.Tag{Name: "urgent", Color: "#ff0000"}
+		userID := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.CreateTag(tag, userID.Hex())
+
+		assert.NoError(t, err)
+		assert.False(t, tag.ID.IsZero())
+		assert.Equal(t, userID, tag.UserID)
+	})
+
+	mt.Run("fails due to invalid userID", func(mt *mtest.T) {
+		repo := repositories.NewTagRepository(mt.Coll.Database())
+
+		err := repo.CreateTag(&domain.Tag{Name: "urgent"}, "invalidUserID")
+
+		assert.EqualError(t, err, "user ID is not a valid ObjectID")
+	})
+}
+
+func TestGetTags(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("successfully retrieves tags", func(mt *mtest.T) {
+		repo := repositories.NewTagRepository(mt.Coll.Database())
+
+		userID := primitive.NewObjectID()
+		tagID := primitive.NewObjectID()
+		mt.AddMockResponses(tagResponse(tagID, userID, "urgent"))
+
+		tags, err := repo.GetTags(userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, &[]domain.Tag{{ID: tagID, UserID: userID, Name: "urgent", Color: "#ff0000"}}, tags)
+	})
+}
+
+func TestUpdateTag(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("renames the tag on tasks", func(mt *mtest.T) {
+		repo := repositories.NewTagRepository(mt.Coll.Database())
+
+		tagID := primitive.NewObjectID()
+		userID := primitive.NewObjectID()
+		mt.AddMockResponses(tagResponse(tagID, userID, "urgent"), mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		updated := &domain.Tag{Name: "asap", Color: "#00ff00"}
+		err := repo.UpdateTag(tagID.Hex(), updated)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tagID, updated.ID)
+
+		started := mt.GetAllStartedEvents()
+		assert.Equal(t, "update", started[len(started)-1].CommandName)
+		assert.Contains(t, started[len(started)-1].Command.String(), `"tags.$": "asap"`)
+	})
+
+	mt.Run("fails when the tag does not exist", func(mt *mtest.T) {
+		repo := repositories.NewTagRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "tags.tag", mtest.FirstBatch))
+
+		err := repo.UpdateTag(primitive.NewObjectID().Hex(), &domain.Tag{Name: "asap"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoveTag(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("successfully removes a tag", func(mt *mtest.T) {
+		repo := repositories.NewTagRepository(mt.Coll.Database())
+
+		tagID := primitive.NewObjectID()
+		mt.AddMockResponses(tagResponse(tagID, primitive.NewObjectID(), "urgent"), mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		err := repo.RemoveTag(tagID.Hex())
+
+		assert.NoError(t, err)
+	})
+
+	mt.Run("strips the tag from the reached workspaces only", func(mt *mtest.T) {
+		workspaceID := primitive.NewObjectID()
+		repo := repositories.NewTagRepository(mt.Coll.Database()).InWorkspaces([]primitive.ObjectID{workspaceID})
+
+		tagID := primitive.NewObjectID()
+		mt.AddMockResponses(tagResponse(tagID, primitive.NewObjectID(), "urgent"), mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		err := repo.RemoveTag(tagID.Hex())
+
+		assert.NoError(t, err)
+		started := mt.GetAllStartedEvents()
+		assert.Equal(t, "update", started[1].CommandName)
+		assert.Contains(t, started[1].Command.String(), `"$in": [null,{"$oid":"`+workspaceID.Hex()+`"}]`)
+	})
+}
+
+func TestMergeTags(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("successfully merges tags", func(mt *mtest.T) {
+		repo := repositories.NewTagRepository(mt.Coll.Database())
+
+		userID := primitive.NewObjectID()
+		sourceID := primitive.NewObjectID()
+		targetID := primitive.NewObjectID()
+		mt.AddMockResponses(
+			tagResponse(sourceID, userID, "client-x"),
+			tagResponse(targetID, userID, "clientx"),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+		)
+
+		err := repo.MergeTags(sourceID.Hex(), targetID.Hex())
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// boardOrder is the order of cards in a column. Equal ranks, left by two
+// moves into the same gap at once, are broken by ID so every reader sees the
+// same order.
+var boardOrder = bson.D{{Key: "rank", Value: 1}, {Key: "_id", Value: 1}}
+
+func columnFilter(column domain.BoardColumn) bson.M {
+	if !column.ProjectID.IsZero() {
+		return bson.M{"project_id": column.ProjectID, "status": column.Status}
+	}
+	return bson.M{"user_id": column.UserID, "workspace_id": workspaceValue(column.WorkspaceID), "project_id": bson.M{"$exists": false}, "status": column.Status}
+}
+
+// GetColumn lists the tasks of a board column in board order.
+func (ts *TaskRepository) GetColumn(column domain.BoardColumn) (*[]domain.Task, error) {
+
+	opts := options.Find().SetSort(boardOrder)
+	cursor, err := ts.collection.Find(context.TODO(), ts.workspace.filter(columnFilter(column)), opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var tasks []domain.Task
+
+	if err = cursor.All(context.TODO(), &tasks); err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+}
+
+// LastRank is the rank of the last card of a board column, 0 when the
+// column is empty.
+func (ts *TaskRepository) LastRank(column domain.BoardColumn) (float64, error) {
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "rank", Value: -1}}).SetProjection(bson.M{"rank": 1})
+
+	var task domain.Task
+
+	err := ts.collection.FindOne(context.TODO(), ts.workspace.filter(columnFilter(column)), opts).Decode(&task)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return task.Rank, nil
+}
+
+// MoveTask puts a task in a status column at the given rank.
+func (ts *TaskRepository) MoveTask(id string, status string, rank float64, completedat time.Time) error {
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.D{{Key: "$set", Value: bson.M{"status": status, "rank": rank, "completed_at": completedat}}}
+	if completedat.IsZero() {
+		update = bson.D{
+			{Key: "$set", Value: bson.M{"status": status, "rank": rank}},
+			{Key: "$unset", Value: bson.M{"completed_at": ""}},
+		}
+	}
+	_, err = ts.collection.UpdateOne(context.TODO(), ts.workspace.filter(bson.M{"_id": oid}), update)
+
+	return err
+}
+
+// RebalanceColumn spreads the ranks of a column evenly, step apart, keeping
+// the order. Each card is only renumbered if its rank is still the one read,
+// so a card moved meanwhile keeps the place it was moved to.
+func (ts *TaskRepository) RebalanceColumn(column domain.BoardColumn, step float64) error {
+
+	tasks, err := ts.GetColumn(column)
+	if err != nil {
+		return err
+	}
+
+	var models []mongo.WriteModel
+	for i, task := range *tasks {
+		var current interface{} = task.Rank
+		if task.Rank == 0 {
+			current = bson.M{"$in": bson.A{0, nil}}
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(ts.workspace.filter(bson.M{"_id": task.ID, "rank": current})).
+			SetUpdate(bson.M{"$set": bson.M{"rank": float64(i+1) * step}}))
+	}
+	if len(models) == 0 {
+		return nil
+	}
+
+	_, err = ts.collection.BulkWrite(context.TODO(), models, options.BulkWrite().SetOrdered(false))
+
+	return err
+}
+
+// DenseColumns finds the columns where two neighbouring cards are less than
+// gap apart, so that inserting between them will soon run out of precision.
+func (ts *TaskRepository) DenseColumns(gap float64) ([]domain.BoardColumn, error) {
+
+	column := bson.M{
+		"project_id":   "$project_id",
+		"user_id":      bson.M{"$cond": bson.A{bson.M{"$ifNull": bson.A{"$project_id", false}}, nil, "$user_id"}},
+		"workspace_id": bson.M{"$cond": bson.A{bson.M{"$ifNull": bson.A{"$project_id", false}}, nil, "$workspace_id"}},
+		"status":       "$status",
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: ts.workspace.filter(bson.M{"rank": bson.M{"$exists": true}})}},
+		{{Key: "$setWindowFields", Value: bson.M{
+			"partitionBy": column,
+			"sortBy":      bson.M{"rank": 1},
+			"output":      bson.M{"previous": bson.M{"$shift": bson.M{"output": "$rank", "by": -1}}},
+		}}},
+		{{Key: "$match", Value: bson.M{
+			"previous": bson.M{"$ne": nil},
+			"$expr":    bson.M{"$lt": bson.A{bson.M{"$subtract": bson.A{"$rank", "$previous"}}, gap}},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": column}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$_id"}}},
+	}
+
+	cursor, err := ts.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var columns []domain.BoardColumn
+
+	if err = cursor.All(context.TODO(), &columns); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
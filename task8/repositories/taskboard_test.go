@@ -0,0 +1,130 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestGetColumn(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("personal board", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "rank", Value: 1024.0}},
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "rank", Value: 2048.0}},
+		))
+
+		tasks, err := repo.GetColumn(domain.BoardColumn{UserID: primitive.NewObjectID(), Status: "todo"})
+
+		assert.NoError(t, err)
+		assert.Len(t, *tasks, 2)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"project_id": {"$exists": false}`)
+		assert.Contains(t, command, `"sort": {"rank": {"$numberInt":"1"},"_id": {"$numberInt":"1"}}`)
+	})
+}
+
+func TestLastRank(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("last card of the column", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+		projectID := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "rank", Value: 3072.0}},
+		))
+
+		rank, err := repo.LastRank(domain.BoardColumn{ProjectID: projectID, Status: "todo"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3072.0, rank)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"project_id": {"$oid":"`+projectID.Hex()+`"}`)
+		assert.Contains(t, command, `"sort": {"rank": {"$numberInt":"-1"}}`)
+	})
+
+	mt.Run("empty column", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
+
+		rank, err := repo.LastRank(domain.BoardColumn{UserID: primitive.NewObjectID(), Status: "todo"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, rank)
+	})
+}
+
+func TestMoveTask(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("reopens a task", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.MoveTask(primitive.NewObjectID().Hex(), "todo", 1536, time.Time{})
+
+		assert.NoError(t, err)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"rank": {"$numberDouble":"1536.0"}`)
+		assert.Contains(t, command, `"$unset": {"completed_at"`)
+	})
+}
+
+func TestRebalanceColumn(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("respaces the column in order", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+		first, second := primitive.NewObjectID(), primitive.NewObjectID()
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch,
+				bson.D{{Key: "_id", Value: first}},
+				bson.D{{Key: "_id", Value: second}, {Key: "rank", Value: 1.0000001}},
+			),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 2}, bson.E{Key: "nModified", Value: 2}),
+		)
+
+		err := repo.RebalanceColumn(domain.BoardColumn{ProjectID: primitive.NewObjectID(), Status: "todo"}, 1024)
+
+		assert.NoError(t, err)
+		mt.GetStartedEvent()
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"rank": {"$in": [{"$numberInt":"0"},null]}`)
+		assert.Contains(t, command, `"$set": {"rank": {"$numberDouble":"2048.0"}}`)
+	})
+}
+
+func TestDenseColumns(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("finds crowded columns", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+		projectID, userID := primitive.NewObjectID(), primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch,
+			bson.D{{Key: "project_id", Value: projectID}, {Key: "user_id", Value: nil}, {Key: "status", Value: "todo"}},
+			bson.D{{Key: "user_id", Value: userID}, {Key: "status", Value: "doing"}},
+		))
+
+		columns, err := repo.DenseColumns(1e-3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BoardColumn{{ProjectID: projectID, Status: "todo"}, {UserID: userID, Status: "doing"}}, columns)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"$setWindowFields"`)
+		assert.Contains(t, command, `"$shift"`)
+	})
+}
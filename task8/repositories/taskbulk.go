@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// WriteTasks applies a batch of task changes in one transaction, so either
+// all of them are stored or none is. Created tasks must come with their user
+// and get their ID here when they have none. Transactions need MongoDB to run
+// as a replica set.
+func (ts *TaskRepository) WriteTasks(writes []domain.TaskWrite) error {
+
+	session, err := ts.collection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.TODO())
+
+	_, err = session.WithTransaction(context.TODO(), func(sc mongo.SessionContext) (interface{}, error) {
+		for _, write := range writes {
+			if err := ts.writeTask(sc, write); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+
+	return err
+}
+
+func (ts *TaskRepository) writeTask(sc mongo.SessionContext, write domain.TaskWrite) error {
+
+	if write.Create != nil {
+		if write.Create.UserID.IsZero() {
+			return errors.New("user ID is not a valid ObjectID")
+		}
+		if write.Create.ID.IsZero() {
+			write.Create.ID = primitive.NewObjectID()
+		}
+		ts.workspace.assign(&write.Create.WorkspaceID)
+		_, err := ts.collection.InsertOne(sc, write.Create)
+		return err
+	}
+
+	oid, err := primitive.ObjectIDFromHex(write.ID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case write.Delete:
+		if _, err := ts.collection.DeleteOne(sc, ts.workspace.filter(bson.M{"_id": oid})); err != nil {
+			return err
+		}
+		_, err = ts.collection.UpdateMany(sc, ts.workspace.filter(bson.M{"blocked_by": oid}), bson.M{"$pull": bson.M{"blocked_by": oid}})
+		return err
+	case write.Update != nil:
+		if _, err := ts.collection.UpdateOne(sc, ts.workspace.filter(bson.M{"_id": oid}), taskUpdate(write.Update)); err != nil {
+			return err
+		}
+		if write.Assignment == nil {
+			return nil
+		}
+		update, err := assignmentUpdate(write.Assignee, *write.Assignment)
+		if err != nil {
+			return err
+		}
+		_, err = ts.collection.UpdateOne(sc, ts.workspace.filter(bson.M{"_id": oid}), update)
+		return err
+	}
+	return nil
+}
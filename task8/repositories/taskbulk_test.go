@@ -0,0 +1,79 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestWriteTasks(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("writes every task in one transaction", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+		created := &domain.Task{Title: "new", UserID: primitive.NewObjectID()}
+		updated := &domain.Task{Title: "renamed", Status: "done"}
+
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+		)
+
+		err := repo.WriteTasks([]domain.TaskWrite{
+			{Create: created},
+			{ID: primitive.NewObjectID().Hex(), Update: updated},
+			{ID: primitive.NewObjectID().Hex(), Delete: true},
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, created.ID.IsZero())
+
+		insert := mt.GetStartedEvent()
+		assert.Equal(t, "insert", insert.CommandName)
+		assert.Contains(t, insert.Command.String(), `"startTransaction": true`)
+		update := mt.GetStartedEvent()
+		assert.Equal(t, "update", update.CommandName)
+		assert.Contains(t, update.Command.String(), `"$unset": {"completed_at"`)
+		assert.NotContains(t, update.Command.String(), "startTransaction")
+		assert.Equal(t, "delete", mt.GetStartedEvent().CommandName)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"$pull": {"blocked_by"`)
+		assert.Equal(t, "commitTransaction", mt.GetStartedEvent().CommandName)
+	})
+
+	mt.Run("aborts on a failed write", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 2, Message: "bad update"}),
+			mtest.CreateSuccessResponse(),
+		)
+
+		err := repo.WriteTasks([]domain.TaskWrite{
+			{Create: &domain.Task{Title: "new", UserID: primitive.NewObjectID()}},
+			{ID: primitive.NewObjectID().Hex(), Update: &domain.Task{Title: "renamed"}},
+		})
+
+		assert.ErrorContains(t, err, "bad update")
+		mt.GetStartedEvent()
+		mt.GetStartedEvent()
+		assert.Equal(t, "abortTransaction", mt.GetStartedEvent().CommandName)
+	})
+
+	mt.Run("created task without user", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.WriteTasks([]domain.TaskWrite{{Create: &domain.Task{Title: "new"}}})
+
+		assert.EqualError(t, err, "user ID is not a valid ObjectID")
+	})
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (ts *TaskRepository) GetTasksByIDs(ids []primitive.ObjectID) (*[]domain.Task, error) {
+
+	cursor, err := ts.collection.Find(context.TODO(), ts.workspace.filter(bson.M{"_id": bson.M{"$in": ids}}))
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var tasks []domain.Task
+
+	if err = cursor.All(context.TODO(), &tasks); err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+}
+
+// AddBlocker records that the task cannot be done before blockerid is.
+func (ts *TaskRepository) AddBlocker(id string, blockerid string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	bid, err := primitive.ObjectIDFromHex(blockerid)
+	if err != nil {
+		return err
+	}
+
+	_, err = ts.collection.UpdateOne(context.TODO(), ts.workspace.filter(bson.M{"_id": oid}), bson.M{"$addToSet": bson.M{"blocked_by": bid}})
+
+	return err
+}
+
+func (ts *TaskRepository) RemoveBlocker(id string, blockerid string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	bid, err := primitive.ObjectIDFromHex(blockerid)
+	if err != nil {
+		return err
+	}
+
+	_, err = ts.collection.UpdateOne(context.TODO(), ts.workspace.filter(bson.M{"_id": oid}), bson.M{"$pull": bson.M{"blocked_by": bid}})
+
+	return err
+}
+
+// ClearBlocker drops a deleted task from the blockers of every task.
+func (ts *TaskRepository) ClearBlocker(blockerid string) error {
+	bid, err := primitive.ObjectIDFromHex(blockerid)
+	if err != nil {
+		return err
+	}
+
+	_, err = ts.collection.UpdateMany(context.TODO(), ts.workspace.filter(bson.M{"blocked_by": bid}), bson.M{"$pull": bson.M{"blocked_by": bid}})
+
+	return err
+}
+
+// GetBlockers returns every task the given one waits on, directly or through
+// other blockers.
+func (ts *TaskRepository) GetBlockers(id string) (*[]domain.Task, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.aggregateTasks(mongo.Pipeline{
+		{{Key: "$match", Value: ts.workspace.filter(bson.M{"_id": oid})}},
+		{{Key: "$graphLookup", Value: ts.blockersLookup("blockers")}},
+		{{Key: "$unwind", Value: "$blockers"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$blockers"}}},
+	})
+}
+
+// GetDependencyGraph returns the task with everything it waits on and
+// everything waiting on it, transitively.
+func (ts *TaskRepository) GetDependencyGraph(id string) (*[]domain.Task, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.aggregateTasks(mongo.Pipeline{
+		{{Key: "$match", Value: ts.workspace.filter(bson.M{"_id": oid})}},
+		{{Key: "$set", Value: bson.M{"root": "$$ROOT"}}},
+		{{Key: "$graphLookup", Value: ts.blockersLookup("blockers")}},
+		{{Key: "$graphLookup", Value: ts.graphLookup(bson.M{
+			"from":             ts.collection.Name(),
+			"startWith":        "$_id",
+			"connectFromField": "_id",
+			"connectToField":   "blocked_by",
+			"as":               "dependents",
+		})}},
+		{{Key: "$project", Value: bson.M{"tasks": bson.M{"$concatArrays": bson.A{bson.A{"$root"}, "$blockers", "$dependents"}}}}},
+		{{Key: "$unwind", Value: "$tasks"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$tasks"}}},
+	})
+}
+
+func (ts *TaskRepository) blockersLookup(as string) bson.M {
+	return ts.graphLookup(bson.M{
+		"from":             ts.collection.Name(),
+		"startWith":        "$blocked_by",
+		"connectFromField": "blocked_by",
+		"connectToField":   "_id",
+		"as":               as,
+	})
+}
+
+// graphLookup keeps a traversal inside the repository's workspace.
+func (ts *TaskRepository) graphLookup(lookup bson.M) bson.M {
+	if restrict := ts.workspace.filter(bson.M{}); len(restrict) > 0 {
+		lookup["restrictSearchWithMatch"] = restrict
+	}
+	return lookup
+}
+
+func (ts *TaskRepository) aggregateTasks(pipeline mongo.Pipeline) (*[]domain.Task, error) {
+
+	cursor, err := ts.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var tasks []domain.Task
+
+	if err = cursor.All(context.TODO(), &tasks); err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+}
@@ -0,0 +1,75 @@
+package repositories_test
+
+import (
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestAddBlocker(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("adds the blocker once", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.AddBlocker(primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"$addToSet": {"blocked_by"`)
+	})
+
+	mt.Run("invalid blocker ID", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		err := repo.AddBlocker(primitive.NewObjectID().Hex(), "invalidBlockerID")
+
+		assert.EqualError(t, err, "the provided hex string is not a valid ObjectID")
+	})
+}
+
+func TestClearBlocker(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("pulls the blocker everywhere", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.ClearBlocker(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"multi": true`)
+		assert.Contains(t, command, `"$pull": {"blocked_by"`)
+	})
+}
+
+func TestGetDependencyGraph(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("walks both directions", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+		root, blocker, dependent := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: root}, {Key: "blocked_by", Value: bson.A{blocker}}},
+			bson.D{{Key: "_id", Value: blocker}},
+			bson.D{{Key: "_id", Value: dependent}, {Key: "blocked_by", Value: bson.A{root}}},
+		))
+
+		tasks, err := repo.GetDependencyGraph(root.Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, *tasks, 3)
+		assert.Equal(t, []primitive.ObjectID{blocker}, (*tasks)[0].BlockedBy)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"connectToField": "_id"`)
+		assert.Contains(t, command, `"connectToField": "blocked_by"`)
+	})
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetDueTasks returns the open tasks the user created or is assigned whose
+// due date falls in the window, soonest first.
+func (ts *TaskRepository) GetDueTasks(userid string, window domain.DueWindow) (*[]domain.Task, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	filter := bson.M{"$and": bson.A{
+		bson.M{"$or": bson.A{bson.M{"user_id": uid}, bson.M{"assignee_id": uid}}},
+		bson.M{"status": openStatus()},
+		bson.M{"$or": bson.A{
+			bson.M{"due_date_only": bson.M{"$ne": true}, "duedate": dueRange(window.From, window.To)},
+			bson.M{"due_date_only": true, "duedate": dueRange(window.FromDay, window.ToDay)},
+		}},
+	}}
+
+	cursor, err := ts.collection.Find(context.TODO(), ts.workspace.filter(filter), options.Find().SetSort(bson.D{{Key: "duedate", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	tasks := []domain.Task{}
+	if err = cursor.All(context.TODO(), &tasks); err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+}
+
+// GetNewlyOverdue returns the open tasks of every user that fell overdue in
+// the window and have not had task.overdue published for their due date.
+func (ts *TaskRepository) GetNewlyOverdue(window domain.DueWindow) (*[]domain.Task, error) {
+	filter := bson.M{
+		"status": openStatus(),
+		"$or": bson.A{
+			bson.M{"due_date_only": bson.M{"$ne": true}, "duedate": dueRange(window.From, window.To)},
+			bson.M{"due_date_only": true, "duedate": dueRange(window.FromDay, window.ToDay)},
+		},
+		"$expr": bson.M{"$ne": bson.A{"$overdue_for", "$duedate"}},
+	}
+
+	cursor, err := ts.collection.Find(context.TODO(), ts.workspace.filter(filter), options.Find().SetSort(bson.D{{Key: "duedate", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	tasks := []domain.Task{}
+	if err = cursor.All(context.TODO(), &tasks); err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+}
+
+// MarkOverdue records that task.overdue was published for the due date.
+func (ts *TaskRepository) MarkOverdue(id string, duedate time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = ts.collection.UpdateOne(context.TODO(), ts.workspace.filter(bson.M{"_id": oid}), bson.D{{Key: "$set", Value: bson.M{"overdue_for": duedate}}})
+	return err
+}
+
+// dueRange matches due dates from from up to to, either of which may be
+// left open. An open start still leaves out the zero due date of tasks
+// without one.
+func dueRange(from time.Time, to time.Time) bson.M {
+	query := bson.M{"$gt": time.Time{}}
+	if !from.IsZero() {
+		query = bson.M{"$gte": from}
+	}
+	if !to.IsZero() {
+		query["$lt"] = to
+	}
+	return query
+}
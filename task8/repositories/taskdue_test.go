@@ -0,0 +1,110 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestGetDueTasks(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	window := domain.DueWindow{
+		From:    time.Date(2024, time.March, 31, 0, 0, 0, 0, berlin),
+		To:      time.Date(2024, time.April, 1, 0, 0, 0, 0, berlin),
+		FromDay: day(31, 0),
+		ToDay:   time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+		userID := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "title", Value: "Release"}, {Key: "duedate", Value: day(31, 0)}, {Key: "due_date_only", Value: true}},
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "title", Value: "Call"}, {Key: "duedate", Value: day(31, 9)}},
+		))
+
+		tasks, err := repo.GetDueTasks(userID.Hex(), window)
+
+		assert.NoError(t, err)
+		assert.Len(t, *tasks, 2)
+		assert.True(t, (*tasks)[0].DueDateOnly)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"due_date_only": {"$ne": true}`)
+		// Summer time starts that night, so the day in Berlin is 23 hours long.
+		assert.Contains(t, command, `"$gte": {"$date":{"$numberLong":"1711839600000"}}`)
+		assert.Contains(t, command, `"$lt": {"$date":{"$numberLong":"1711922400000"}}`)
+		assert.Contains(t, command, `"status": {"$not": {"$regularExpression":{"pattern":"^(done|completed)$","options":"i"}}}`)
+	})
+
+	mt.Run("overdue leaves out undated tasks", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
+
+		tasks, err := repo.GetDueTasks(primitive.NewObjectID().Hex(), domain.DueWindow{To: day(10, 12), ToDay: day(10, 0)})
+
+		assert.NoError(t, err)
+		assert.Empty(t, *tasks)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"$gt": {"$date":{"$numberLong":"-62135596800000"}}`)
+	})
+
+	mt.Run("invalid user ID", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		_, err := repo.GetDueTasks("invalidUserID", window)
+
+		assert.EqualError(t, err, "user ID is not a valid ObjectID")
+	})
+}
+
+func TestGetNewlyOverdue(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("open tasks not published for their due date", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "title", Value: "Call"}, {Key: "duedate", Value: day(31, 9)}},
+		))
+
+		tasks, err := repo.GetNewlyOverdue(domain.DueWindow{From: day(30, 12), To: day(31, 12), FromDay: day(30, 0), ToDay: day(31, 0)})
+
+		assert.NoError(t, err)
+		assert.Len(t, *tasks, 1)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"$expr": {"$ne": ["$overdue_for","$duedate"]}`)
+		assert.Contains(t, command, `"status": {"$not": {"$regularExpression":{"pattern":"^(done|completed)$","options":"i"}}}`)
+		assert.NotContains(t, command, "user_id")
+	})
+}
+
+func TestMarkOverdue(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+
+		err := repo.MarkOverdue(primitive.NewObjectID().Hex(), day(31, 9))
+
+		assert.NoError(t, err)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"overdue_for": {"$date"`)
+	})
+
+	mt.Run("invalid ID", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		err := repo.MarkOverdue("invalidID", day(31, 9))
+
+		assert.Error(t, err)
+	})
+}
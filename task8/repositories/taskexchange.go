@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// StreamTasks calls each with every task of the user, oldest first, reading
+// them from the cursor one batch at a time. It stops at the first error each
+// returns.
+func (ts *TaskRepository) StreamTasks(userid string, each func(*domain.Task) error) error {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+
+	cursor, err := ts.collection.Find(context.TODO(), ts.workspace.filter(bson.M{"user_id": uid}), options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+
+	for cursor.Next(context.TODO()) {
+		var task domain.Task
+		if err := cursor.Decode(&task); err != nil {
+			return err
+		}
+		if err := each(&task); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// GetImportedTasks returns the user's tasks an import refers to by keys,
+// matched against their external ID or, for tasks exported without one,
+// their own ID.
+func (ts *TaskRepository) GetImportedTasks(userid string, keys []string) (*[]domain.Task, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	ids := []primitive.ObjectID{}
+	for _, key := range keys {
+		if id, err := primitive.ObjectIDFromHex(key); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	cursor, err := ts.collection.Find(context.TODO(), ts.workspace.filter(bson.M{
+		"user_id": uid,
+		"$or": bson.A{
+			bson.M{"external_id": bson.M{"$in": keys}},
+			bson.M{"_id": bson.M{"$in": ids}},
+		},
+	}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var tasks []domain.Task
+
+	if err = cursor.All(context.TODO(), &tasks); err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+}
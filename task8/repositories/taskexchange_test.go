@@ -0,0 +1,83 @@
+package repositories_test
+
+import (
+	"errors"
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestStreamTasks(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("reads every batch", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(42, "test.tasks", mtest.FirstBatch, bson.D{{Key: "title", Value: "first"}}),
+			mtest.CreateCursorResponse(0, "test.tasks", mtest.NextBatch, bson.D{{Key: "title", Value: "second"}}),
+		)
+
+		titles := []string{}
+		err := repo.StreamTasks(primitive.NewObjectID().Hex(), func(task *domain.Task) error {
+			titles = append(titles, task.Title)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"first", "second"}, titles)
+	})
+
+	mt.Run("stops at the first error", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(42, "test.tasks", mtest.FirstBatch, bson.D{{Key: "title", Value: "first"}}, bson.D{{Key: "title", Value: "second"}}),
+			mtest.CreateSuccessResponse(),
+		)
+
+		calls := 0
+		err := repo.StreamTasks(primitive.NewObjectID().Hex(), func(task *domain.Task) error {
+			calls++
+			return errors.New("client went away")
+		})
+
+		assert.EqualError(t, err, "client went away")
+		assert.Equal(t, 1, calls)
+	})
+
+	mt.Run("invalid user ID", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		err := repo.StreamTasks("invalidUserID", func(task *domain.Task) error { return nil })
+
+		assert.EqualError(t, err, "user ID is not a valid ObjectID")
+	})
+}
+
+func TestGetImportedTasks(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("matches external and own IDs", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+		id := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: id}, {Key: "external_id", Value: "jira-1"}},
+		))
+
+		tasks, err := repo.GetImportedTasks(primitive.NewObjectID().Hex(), []string{"jira-1", id.Hex()})
+
+		assert.NoError(t, err)
+		assert.Len(t, *tasks, 1)
+		assert.Equal(t, "jira-1", (*tasks)[0].ExternalID)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"external_id": {"$in": ["jira-1","`+id.Hex()+`"]}`)
+		assert.Contains(t, command, `"_id": {"$in": [{"$oid":"`+id.Hex()+`"}]}`)
+	})
+}
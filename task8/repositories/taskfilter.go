@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// taskSortKeys are the task fields GET /tasks sorts by, by their names in
+// the query.
+var taskSortKeys = map[string]string{"title": "title", "due_date": "duedate", "created_at": "created_at"}
+
+// FilterTasks returns the page of the user's tasks the filter matches, in
+// the order it asks for.
+func (ts *TaskRepository) FilterTasks(userid string, filter domain.TaskFilter) (*[]domain.Task, error) {
+	query, err := taskFilterQuery(userid, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(taskOrder(filter.Sort))
+	if filter.Limit > 0 {
+		opts.SetSkip(int64(filter.Offset)).SetLimit(int64(filter.Limit))
+	}
+	cursor, err := ts.collection.Find(context.TODO(), ts.workspace.filter(query), opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var tasks []domain.Task
+
+	if err = cursor.All(context.TODO(), &tasks); err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+
+}
+
+// CountTasks counts every task the filter matches, regardless of the page.
+func (ts *TaskRepository) CountTasks(userid string, filter domain.TaskFilter) (int64, error) {
+	query, err := taskFilterQuery(userid, filter)
+	if err != nil {
+		return 0, err
+	}
+	return ts.collection.CountDocuments(context.TODO(), ts.workspace.filter(query))
+}
+
+// taskFilterQuery matches the tasks of the user the filter narrows down to.
+// The due range and the assignee are expected resolved by the usecase.
+func taskFilterQuery(userid string, filter domain.TaskFilter) (bson.M, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+
+	query := bson.M{"user_id": uid}
+	if len(filter.Statuses) > 0 {
+		query["status"] = bson.M{"$in": filter.Statuses}
+	}
+	if len(filter.Priorities) > 0 {
+		query["priority"] = bson.M{"$in": filter.Priorities}
+	}
+	if len(filter.Tags) > 0 {
+		operator := "$in"
+		if filter.MatchAll {
+			operator = "$all"
+		}
+		query["tags"] = bson.M{operator: filter.Tags}
+	}
+	switch filter.AssigneeID {
+	case "":
+	case domain.AssigneeNone:
+		query["assignee_id"] = nil
+	default:
+		aid, err := primitive.ObjectIDFromHex(filter.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+		query["assignee_id"] = aid
+	}
+
+	for key, value := range filter.FieldValues {
+		query["fields."+key] = value
+	}
+
+	and := bson.A{}
+	due := filter.Due
+	if !due.From.IsZero() || !due.To.IsZero() {
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"due_date_only": bson.M{"$ne": true}, "duedate": dueRange(due.From, due.To)},
+			bson.M{"due_date_only": true, "duedate": dueRange(due.FromDay, due.ToDay)},
+		}})
+	}
+	if filter.Text != "" {
+		text := primitive.Regex{Pattern: regexp.QuoteMeta(filter.Text), Options: "i"}
+		and = append(and, bson.M{"$or": bson.A{bson.M{"title": text}, bson.M{"description": text}}})
+	}
+	if len(and) > 0 {
+		query["$and"] = and
+	}
+	return query, nil
+}
+
+// taskOrder is the sort of a filter, board order when it has none. Ties
+// are broken by ID so that pages do not overlap.
+func taskOrder(sort string) bson.D {
+	if sort == "" {
+		return boardOrder
+	}
+	direction := 1
+	if strings.HasPrefix(sort, "-") {
+		direction = -1
+		sort = sort[1:]
+	}
+	key := taskSortKeys[sort]
+	if field, ok := strings.CutPrefix(sort, "field."); ok {
+		key = "fields." + field
+	}
+	return bson.D{{Key: key, Value: direction}, {Key: "_id", Value: 1}}
+}
@@ -0,0 +1,118 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestFilterTasks_Expression(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("runs every part of the filter on one page", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+		userID := primitive.NewObjectID()
+		assigneeID := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
+		from := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+
+		_, err := repo.FilterTasks(userID.Hex(), domain.TaskFilter{
+			Statuses:   []string{"pending"},
+			Priorities: []string{"high", "urgent"},
+			Tags:       []string{"ops"},
+			AssigneeID: assigneeID.Hex(),
+			Text:       "a.b",
+			Due:        domain.DueWindow{From: from, FromDay: from},
+			Limit:      20,
+			Offset:     40,
+		})
+
+		assert.NoError(t, err)
+		command := mt.GetStartedEvent().Command
+		assert.Contains(t, command.String(), `"status": {"$in": ["pending"]}`)
+		assert.Contains(t, command.String(), `"priority": {"$in": ["high","urgent"]}`)
+		assert.Contains(t, command.String(), `"assignee_id": {"$oid":"`+assigneeID.Hex()+`"}`)
+		assert.Contains(t, command.String(), `"$regularExpression":{"pattern":"a\\.b","options":"i"}`)
+		assert.Contains(t, command.String(), `"due_date_only": true`)
+		assert.NotContains(t, command.String(), `"$lt"`)
+		assert.Equal(t, int64(40), command.Lookup("skip").Int64())
+		assert.Equal(t, int64(20), command.Lookup("limit").Int64())
+	})
+
+	mt.Run("tasks without an assignee", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
+
+		_, err := repo.FilterTasks(primitive.NewObjectID().Hex(), domain.TaskFilter{AssigneeID: domain.AssigneeNone})
+
+		assert.NoError(t, err)
+		command := mt.GetStartedEvent().Command
+		assert.Contains(t, command.String(), `"assignee_id": null`)
+		_, paged := command.Lookup("limit").Int64OK()
+		assert.False(t, paged)
+	})
+}
+
+func TestCountTasks(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("counts the tasks the filter matches", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch, bson.D{{Key: "n", Value: int32(12)}}))
+
+		count, err := repo.CountTasks(primitive.NewObjectID().Hex(), domain.TaskFilter{Tags: []string{"ops"}, Limit: 5})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), count)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"tags": {"$in": ["ops"]}`)
+	})
+
+	mt.Run("fails due to invalid userID", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		_, err := repo.CountTasks("invalidUserID", domain.TaskFilter{})
+
+		assert.EqualError(t, err, "the provided hex string is not a valid ObjectID")
+	})
+}
+
+func TestFilterTasks_CustomFields(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("matches field values and sorts by a field", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
+
+		_, err := repo.FilterTasks(primitive.NewObjectID().Hex(), domain.TaskFilter{
+			FieldValues: map[string]interface{}{"estimate": 3.0, "customer": primitive.Regex{Pattern: "acme", Options: "i"}},
+			Sort:        "-field.estimate",
+		})
+
+		assert.NoError(t, err)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"fields.estimate": {"$numberDouble":"3.0"}`)
+		assert.Contains(t, command, `"fields.customer": {"$regularExpression":{"pattern":"acme","options":"i"}}`)
+		assert.Contains(t, command, `"sort": {"fields.estimate": {"$numberInt":"-1"},"_id": {"$numberInt":"1"}}`)
+	})
+
+	mt.Run("sorts by a task field", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
+
+		_, err := repo.FilterTasks(primitive.NewObjectID().Hex(), domain.TaskFilter{Sort: "due_date"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"sort": {"duedate": {"$numberInt":"1"},"_id": {"$numberInt":"1"}}`)
+	})
+}
@@ -0,0 +1,41 @@
+package repositories_test
+
+import (
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestGetOpenTasks(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("created or assigned and not done", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+		userID := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "title", Value: "Call"}, {Key: "priority", Value: "high"}},
+		))
+
+		tasks, err := repo.GetOpenTasks(userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, *tasks, 1)
+		assert.Equal(t, "high", (*tasks)[0].Priority)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"$or": [{"user_id": {"$oid":"`+userID.Hex()+`"}},{"assignee_id": {"$oid":"`+userID.Hex()+`"}}]`)
+		assert.Contains(t, command, `"status": {"$not": {"$regularExpression":{"pattern":"^(done|completed)$","options":"i"}}}`)
+	})
+
+	mt.Run("invalid user ID", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		_, err := repo.GetOpenTasks("invalidUserID")
+
+		assert.Error(t, err)
+	})
+}
@@ -3,15 +3,19 @@ package repositories
 import (
 	"context"
 	"errors"
+	"regexp"
+	"strings"
 	"task8/domain"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type TaskRepository struct {
 	collection *mongo.Collection
+	workspace  workspaceScope
 }
 
 func NewTaskRepository(db *mongo.Database) *TaskRepository {
@@ -20,16 +24,24 @@ func NewTaskRepository(db *mongo.Database) *TaskRepository {
 
 }
 
+// InWorkspace is the repository limited to the tasks of one workspace, the
+// personal one for the zero ID. Tasks created through it are put there.
+func (ts *TaskRepository) InWorkspace(workspaceid primitive.ObjectID) domain.TaskRepositoryInterface {
+	return &TaskRepository{collection: ts.collection, workspace: inWorkspace(workspaceid)}
+}
+
 func (ts *TaskRepository) CreateTask(newtask *domain.Task, userid string) error {
 
 	userObjectID, err := primitive.ObjectIDFromHex(userid)
 	if err != nil {
 		return errors.New("user ID is not a valid ObjectID")
 	}
+	newtask.UserID = userObjectID
+	ts.workspace.assign(&newtask.WorkspaceID)
 	result, err := ts.collection.InsertOne(context.TODO(), newtask)
 
 	if err != nil {
-		return errors.New(err.Error())
+		return err
 	}
 
 	oid, ok := result.InsertedID.(primitive.ObjectID)
@@ -39,7 +51,6 @@ func (ts *TaskRepository) CreateTask(newtask *domain.Task, userid string) error
 	}
 
 	newtask.ID = oid
-	newtask.UserID = userObjectID
 	return nil
 }
 
@@ -51,7 +62,7 @@ func (ts *TaskRepository) GetTask(id string) (*domain.Task, error) {
 
 	var task domain.Task
 
-	err = ts.collection.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(&task)
+	err = ts.collection.FindOne(context.TODO(), ts.workspace.filter(bson.M{"_id": oid})).Decode(&task)
 
 	if err != nil {
 		return nil, err
@@ -65,7 +76,7 @@ func (ts *TaskRepository) GetTasks(userid string) (*[]domain.Task, error) {
 	if err != nil {
 		return nil, err
 	}
-	cursor, err := ts.collection.Find(context.TODO(), bson.M{"user_id": uid})
+	cursor, err := ts.collection.Find(context.TODO(), ts.workspace.filter(bson.M{"user_id": uid}), options.Find().SetSort(boardOrder))
 
 	if err != nil {
 		return nil, err
@@ -80,6 +91,157 @@ func (ts *TaskRepository) GetTasks(userid string) (*[]domain.Task, error) {
 	return &tasks, nil
 
 }
+func (ts *TaskRepository) GetProjectTasks(projectid string) (*[]domain.Task, error) {
+	pid, err := primitive.ObjectIDFromHex(projectid)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := ts.collection.Find(context.TODO(), ts.workspace.filter(bson.M{"project_id": pid}), options.Find().SetSort(boardOrder))
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var tasks []domain.Task
+
+	if err = cursor.All(context.TODO(), &tasks); err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+
+}
+
+func (ts *TaskRepository) GetAssignedTasks(userid string) (*[]domain.Task, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := ts.collection.Find(context.TODO(), ts.workspace.filter(bson.M{"assignee_id": uid}), options.Find().SetSort(boardOrder))
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var tasks []domain.Task
+
+	if err = cursor.All(context.TODO(), &tasks); err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+
+}
+
+// openStatus matches the statuses that are not done, ignoring case as
+// domain.IsDone does.
+func openStatus() bson.M {
+
+	quoted := make([]string, len(domain.DoneStatuses))
+	for i, status := range domain.DoneStatuses {
+		quoted[i] = regexp.QuoteMeta(status)
+	}
+	return bson.M{"$not": primitive.Regex{Pattern: "^(" + strings.Join(quoted, "|") + ")$", Options: "i"}}
+}
+
+// GetOpenTasks returns the open tasks the user created or is assigned.
+func (ts *TaskRepository) GetOpenTasks(userid string) (*[]domain.Task, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{
+		"$or":    bson.A{bson.M{"user_id": uid}, bson.M{"assignee_id": uid}},
+		"status": openStatus(),
+	}
+	cursor, err := ts.collection.Find(context.TODO(), ts.workspace.filter(filter), options.Find().SetSort(boardOrder))
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	tasks := []domain.Task{}
+
+	if err = cursor.All(context.TODO(), &tasks); err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+}
+
+// AssignTask sets (or, for an empty assigneeid, clears) the assignee and
+// appends the event to the task history in the same update.
+func (ts *TaskRepository) AssignTask(id string, assigneeid string, event domain.TaskEvent) error {
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update, err := assignmentUpdate(assigneeid, event)
+	if err != nil {
+		return err
+	}
+
+	_, err = ts.collection.UpdateOne(context.TODO(), ts.workspace.filter(bson.M{"_id": oid}), update)
+
+	return err
+}
+
+func assignmentUpdate(assigneeid string, event domain.TaskEvent) (bson.M, error) {
+
+	update := bson.M{"$push": bson.M{"history": event}}
+	if assigneeid == "" {
+		update["$unset"] = bson.M{"assignee_id": ""}
+	} else {
+		aid, err := primitive.ObjectIDFromHex(assigneeid)
+		if err != nil {
+			return nil, err
+		}
+		update["$set"] = bson.M{"assignee_id": aid}
+	}
+	return update, nil
+}
+
+// indexNotFound is the server's code for dropping an index that is not there.
+const indexNotFound = 27
+
+// EnsureIndexes creates the indexes the task queries rely on. The tags index
+// is multikey, so a tag filter only touches the matching tasks of one user.
+func (ts *TaskRepository) EnsureIndexes() error {
+
+	// unique_external_id was keyed by user only, which kept a user from
+	// importing the same file into two workspaces.
+	_, err := ts.collection.Indexes().DropOne(context.TODO(), "unique_external_id")
+	var commandErr mongo.CommandError
+	if err != nil && !(errors.As(err, &commandErr) && commandErr.Code == indexNotFound) {
+		return err
+	}
+
+	_, err = ts.collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "tags", Value: 1}}},
+		{Keys: bson.D{{Key: "workspace_id", Value: 1}}},
+		{Keys: bson.D{{Key: "project_id", Value: 1}}},
+		{Keys: bson.D{{Key: "assignee_id", Value: 1}}},
+		{Keys: bson.D{{Key: "series_id", Value: 1}}},
+		{Keys: bson.D{{Key: "duedate", Value: 1}}},
+		{Keys: bson.D{{Key: "blocked_by", Value: 1}}},
+		{Keys: bson.D{{Key: "project_id", Value: 1}, {Key: "status", Value: 1}, {Key: "rank", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "status", Value: 1}, {Key: "rank", Value: 1}}},
+		// Imports rely on an external ID naming one task per user and
+		// workspace.
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "workspace_id", Value: 1}, {Key: "external_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"external_id": bson.M{"$type": "string"}}).
+				SetName("unique_workspace_external_id"),
+		},
+	})
+
+	return err
+}
+
 func (ts *TaskRepository) UpdateTask(id string, updatedtask *domain.Task) error {
 
 	oid, err := primitive.ObjectIDFromHex(id)
@@ -87,7 +249,7 @@ func (ts *TaskRepository) UpdateTask(id string, updatedtask *domain.Task) error
 	if err != nil {
 		return err
 	}
-	_, err = ts.collection.UpdateOne(context.TODO(), bson.M{"_id": oid}, bson.D{{Key: "$set", Value: updatedtask}})
+	_, err = ts.collection.UpdateOne(context.TODO(), ts.workspace.filter(bson.M{"_id": oid}), taskUpdate(updatedtask))
 
 	if err != nil {
 		return err
@@ -96,6 +258,71 @@ func (ts *TaskRepository) UpdateTask(id string, updatedtask *domain.Task) error
 	return nil
 
 }
+
+func taskUpdate(updatedtask *domain.Task) bson.D {
+
+	update := bson.D{{Key: "$set", Value: updatedtask}}
+	unset := bson.D{}
+	if updatedtask.CompletedAt.IsZero() {
+		unset = append(unset, bson.E{Key: "completed_at", Value: ""})
+	}
+	if !updatedtask.DueDateOnly {
+		unset = append(unset, bson.E{Key: "due_date_only", Value: ""})
+	}
+	if updatedtask.Fields != nil && len(updatedtask.Fields) == 0 {
+		unset = append(unset, bson.E{Key: "fields", Value: ""})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+	return update
+}
+
+// UpdateSeries applies series level fields to the first task of a series,
+// which future occurrences are copied from, and to its open occurrences.
+// Completed occurrences keep what they were done with. Only tasks of the
+// series' owner are touched.
+func (ts *TaskRepository) UpdateSeries(seriesid string, ownerid string, updatedtask *domain.Task) error {
+
+	sid, err := primitive.ObjectIDFromHex(seriesid)
+
+	if err != nil {
+		return err
+	}
+	oid, err := primitive.ObjectIDFromHex(ownerid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	filter := bson.M{"user_id": oid, "$or": bson.A{
+		bson.M{"_id": sid},
+		bson.M{"series_id": sid, "status": openStatus()},
+	}}
+	_, err = ts.collection.UpdateMany(context.TODO(), ts.workspace.filter(filter), bson.D{{Key: "$set", Value: bson.M{
+		"title":       updatedtask.Title,
+		"description": updatedtask.Description,
+		"tags":        updatedtask.Tags,
+		"rrule":       updatedtask.RRule,
+	}}})
+
+	return err
+}
+
+// MarkNextScheduled records that the next occurrence after a task was
+// created. It reports false when that was recorded already.
+func (ts *TaskRepository) MarkNextScheduled(id string) (bool, error) {
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	filter := bson.M{"_id": oid, "next_scheduled": bson.M{"$ne": true}}
+	result, err := ts.collection.UpdateOne(context.TODO(), ts.workspace.filter(filter), bson.D{{Key: "$set", Value: bson.M{"next_scheduled": true}}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
 func (ts *TaskRepository) RemoveTask(id string) error {
 
 	oid, err := primitive.ObjectIDFromHex(id)
@@ -104,7 +331,7 @@ func (ts *TaskRepository) RemoveTask(id string) error {
 		return err
 	}
 
-	_, err = ts.collection.DeleteOne(context.TODO(), bson.M{"_id": oid})
+	_, err = ts.collection.DeleteOne(context.TODO(), ts.workspace.filter(bson.M{"_id": oid}))
 
 	if err != nil {
 		return nil
@@ -4,6 +4,7 @@ import (
 	"task8/domain"
 	"task8/repositories"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
@@ -164,6 +165,34 @@ func TestGetTasks(t *testing.T) {
 	})
 }
 
+func TestEnsureIndexes(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("replaces the external ID index keyed by user only", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		err := repo.EnsureIndexes()
+
+		assert.NoError(t, err)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"index": "unique_external_id"`)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"key": {"user_id": {"$numberInt":"1"},"workspace_id": {"$numberInt":"1"},"external_id": {"$numberInt":"1"}}`)
+	})
+
+	mt.Run("already migrated", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 27, Name: "IndexNotFound", Message: "index not found with name [unique_external_id]"}),
+			mtest.CreateSuccessResponse(),
+		)
+
+		assert.NoError(t, repo.EnsureIndexes())
+	})
+}
+
 func TestUpdateTask(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
@@ -185,6 +214,28 @@ func TestUpdateTask(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	mt.Run("clears the custom fields", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.UpdateTask(primitive.NewObjectID().Hex(), &domain.Task{Title: "Updated Task", Fields: map[string]interface{}{}})
+
+		assert.NoError(t, err)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"$unset": {"completed_at": "","due_date_only": "","fields": ""}`)
+	})
+
+	mt.Run("clears date-only when the due date has a time again", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.UpdateTask(primitive.NewObjectID().Hex(), &domain.Task{Title: "Updated Task", DueDate: time.Now(), CompletedAt: time.Now()})
+
+		assert.NoError(t, err)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"$unset": {"due_date_only": ""}`)
+	})
+
 	mt.Run("fails due to invalid ObjectID", func(mt *mtest.T) {
 		mockCollection := mt.Coll
 		repo := repositories.NewTaskRepository(mockCollection.Database())
@@ -228,3 +279,121 @@ func TestRemoveTask(t *testing.T) {
 		assert.EqualError(t, err, "the provided hex string is not a valid ObjectID")
 	})
 }
+
+func TestFilterTasks(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("filters by all tags", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		userID := primitive.NewObjectID()
+		taskID := primitive.NewObjectID()
+		first := mtest.CreateCursorResponse(1, "tasks.task", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: taskID},
+			{Key: "user_id", Value: userID},
+			{Key: "title", Value: "Task 1"},
+			{Key: "tags", Value: bson.A{"urgent", "client-x"}},
+		})
+		killCursors := mtest.CreateCursorResponse(0, "tasks.task", mtest.NextBatch)
+		mt.AddMockResponses(first, killCursors)
+
+		tasks, err := repo.FilterTasks(userID.Hex(), domain.TaskFilter{Tags: []string{"urgent", "client-x"}, MatchAll: true})
+
+		assert.NoError(t, err)
+		assert.Len(t, *tasks, 1)
+		assert.Equal(t, []string{"urgent", "client-x"}, (*tasks)[0].Tags)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"$all"`)
+	})
+
+	mt.Run("fails due to invalid userID", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		_, err := repo.FilterTasks("invalidUserID", domain.TaskFilter{})
+
+		assert.EqualError(t, err, "the provided hex string is not a valid ObjectID")
+	})
+}
+
+func TestAssignTask(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("sets the assignee and pushes history", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		event := domain.TaskEvent{Action: "assigned", UserID: primitive.NewObjectID()}
+		err := repo.AssignTask(primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), event)
+
+		assert.NoError(t, err)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"$set": {"assignee_id"`)
+		assert.Contains(t, command, `"$push": {"history"`)
+	})
+
+	mt.Run("unsets the assignee", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.AssignTask(primitive.NewObjectID().Hex(), "", domain.TaskEvent{Action: "unassigned"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"$unset"`)
+	})
+}
+
+func TestUpdateSeries(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("updates the template and open occurrences", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		ownerID := primitive.NewObjectID()
+		err := repo.UpdateSeries(primitive.NewObjectID().Hex(), ownerID.Hex(), &domain.Task{Title: "Series", RRule: "FREQ=DAILY"})
+
+		assert.NoError(t, err)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, ownerID.Hex())
+		assert.Contains(t, command, `"series_id"`)
+		assert.Contains(t, command, `"pattern":"^(done|completed)$","options":"i"`)
+		assert.Contains(t, command, `"rrule": "FREQ=DAILY"`)
+	})
+
+	mt.Run("invalid series ID", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		err := repo.UpdateSeries("invalidID", primitive.NewObjectID().Hex(), &domain.Task{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMarkNextScheduled(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("first time", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+
+		first, err := repo.MarkNextScheduled(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.True(t, first)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"next_scheduled": {"$ne": true}`)
+	})
+
+	mt.Run("already scheduled", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}})
+
+		first, err := repo.MarkNextScheduled(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.False(t, first)
+	})
+}
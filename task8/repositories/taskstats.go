@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetStats computes the dashboard figures for one user's tasks, or for all
+// tasks when filter.UserID is empty, in a single $facet aggregation.
+// Completion buckets are cut in UTC, weeks starting on Monday. Date-only due
+// dates are overdue once filter.Today is past them.
+func (ts *TaskRepository) GetStats(filter domain.StatsFilter) (*domain.TaskStats, error) {
+
+	match := bson.M{}
+	if filter.UserID != "" {
+		uid, err := primitive.ObjectIDFromHex(filter.UserID)
+		if err != nil {
+			return nil, err
+		}
+		match["user_id"] = uid
+	}
+	completedInRange := bson.M{"completed_at": bson.M{"$gte": filter.From, "$lt": filter.To}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: ts.workspace.filter(match)}},
+		{{Key: "$facet", Value: bson.M{
+			"by_status": bson.A{
+				bson.M{"$group": bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}},
+			},
+			"overdue": bson.A{
+				bson.M{"$match": bson.M{
+					"status": openStatus(),
+					"$or": bson.A{
+						bson.M{"due_date_only": bson.M{"$ne": true}, "duedate": dueRange(time.Time{}, time.Now())},
+						bson.M{"due_date_only": true, "duedate": dueRange(time.Time{}, filter.Today)},
+					},
+				}},
+				bson.M{"$count": "count"},
+			},
+			"completed": bson.A{
+				bson.M{"$match": completedInRange},
+				bson.M{"$group": bson.M{
+					"_id": bson.M{"$dateTrunc": bson.M{
+						"date":        "$completed_at",
+						"unit":        filter.Interval,
+						"startOfWeek": "monday",
+					}},
+					"count": bson.M{"$sum": 1},
+				}},
+				bson.M{"$sort": bson.M{"_id": 1}},
+			},
+			"completion": bson.A{
+				bson.M{"$match": bson.M{"$and": bson.A{completedInRange, bson.M{"created_at": bson.M{"$type": "date"}}}}},
+				bson.M{"$group": bson.M{
+					"_id": nil,
+					"avg": bson.M{"$avg": bson.M{"$subtract": bson.A{"$completed_at", "$created_at"}}},
+				}},
+			},
+		}}},
+	}
+
+	cursor, err := ts.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var result []struct {
+		ByStatus []struct {
+			Status string `bson:"_id"`
+			Count  int    `bson:"count"`
+		} `bson:"by_status"`
+		Overdue []struct {
+			Count int `bson:"count"`
+		} `bson:"overdue"`
+		Completed  []domain.StatsBucket `bson:"completed"`
+		Completion []struct {
+			Avg float64 `bson:"avg"`
+		} `bson:"completion"`
+	}
+	if err = cursor.All(context.TODO(), &result); err != nil {
+		return nil, err
+	}
+
+	stats := &domain.TaskStats{ByStatus: map[string]int{}}
+	if len(result) == 0 {
+		stats.Completed = fillBuckets(nil, filter)
+		return stats, nil
+	}
+	facets := result[0]
+	for _, status := range facets.ByStatus {
+		stats.ByStatus[status.Status] = status.Count
+		stats.Total += status.Count
+	}
+	if len(facets.Overdue) > 0 {
+		stats.Overdue = facets.Overdue[0].Count
+	}
+	if len(facets.Completion) > 0 {
+		stats.AvgCompletionSeconds = facets.Completion[0].Avg / 1000
+	}
+	stats.Completed = fillBuckets(facets.Completed, filter)
+	return stats, nil
+}
+
+// fillBuckets returns one bucket per day or week of the range, in order,
+// with zero counts where nothing was completed.
+func fillBuckets(buckets []domain.StatsBucket, filter domain.StatsFilter) []domain.StatsBucket {
+
+	counts := map[time.Time]int{}
+	for _, bucket := range buckets {
+		counts[bucket.Start.UTC()] += bucket.Count
+	}
+	filled := []domain.StatsBucket{}
+	for start := bucketStart(filter.From, filter.Interval); start.Before(filter.To); start = nextBucket(start, filter.Interval) {
+		filled = append(filled, domain.StatsBucket{Start: start, Count: counts[start]})
+	}
+	return filled
+}
+
+func bucketStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if strings.EqualFold(interval, "week") {
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+	return day
+}
+
+func nextBucket(start time.Time, interval string) time.Time {
+	if strings.EqualFold(interval, "week") {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
@@ -0,0 +1,91 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func day(d int, hour int) time.Time {
+	return time.Date(2024, time.March, d, hour, 0, 0, 0, time.UTC)
+}
+
+func TestGetStats(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("decodes the facets", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch, bson.D{
+			{Key: "by_status", Value: bson.A{
+				bson.D{{Key: "_id", Value: "pending"}, {Key: "count", Value: 3}},
+				bson.D{{Key: "_id", Value: "done"}, {Key: "count", Value: 2}},
+			}},
+			{Key: "overdue", Value: bson.A{bson.D{{Key: "count", Value: 1}}}},
+			{Key: "completed", Value: bson.A{bson.D{{Key: "_id", Value: day(5, 0)}, {Key: "count", Value: 2}}}},
+			{Key: "completion", Value: bson.A{bson.D{{Key: "_id", Value: nil}, {Key: "avg", Value: 7200000.0}}}},
+		}))
+
+		filter := domain.StatsFilter{UserID: primitive.NewObjectID().Hex(), From: day(4, 0), To: day(7, 0), Interval: "day"}
+		stats, err := repo.GetStats(filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, stats.Total)
+		assert.Equal(t, map[string]int{"pending": 3, "done": 2}, stats.ByStatus)
+		assert.Equal(t, 1, stats.Overdue)
+		assert.Equal(t, float64(7200), stats.AvgCompletionSeconds)
+		assert.Equal(t, []domain.StatsBucket{{Start: day(4, 0)}, {Start: day(5, 0), Count: 2}, {Start: day(6, 0)}}, stats.Completed)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"$facet"`)
+		assert.Contains(t, command, `"$dateTrunc"`)
+	})
+
+	mt.Run("matches the user and counts date-only tasks late from the day after", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+		uid := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
+
+		filter := domain.StatsFilter{UserID: uid.Hex(), From: day(1, 0), To: day(11, 0), Interval: "week", Today: day(10, 0)}
+		_, err := repo.GetStats(filter)
+
+		assert.NoError(t, err)
+		pipeline := mt.GetStartedEvent().Command.Lookup("pipeline").Array()
+		match := pipeline.Index(0).Value().Document().Lookup("$match").Document()
+		assert.Equal(t, uid, match.Lookup("user_id").ObjectID())
+		facet := pipeline.Index(1).Value().Document().Lookup("$facet").Document()
+		overdue := facet.Lookup("overdue", "0", "$match").Document()
+		dateOnly := overdue.Lookup("$or", "1").Document()
+		assert.True(t, dateOnly.Lookup("due_date_only").Boolean())
+		assert.Equal(t, day(10, 0).UnixMilli(), int64(dateOnly.Lookup("duedate", "$lt").DateTime()))
+		trunc := facet.Lookup("completed", "1", "$group", "_id", "$dateTrunc").Document()
+		assert.Equal(t, "week", trunc.Lookup("unit").StringValue())
+		assert.Equal(t, "monday", trunc.Lookup("startOfWeek").StringValue())
+	})
+
+	mt.Run("fills empty buckets when nothing matches", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
+
+		stats, err := repo.GetStats(domain.StatsFilter{From: day(1, 0), To: day(12, 0), Interval: "week"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, stats.Total)
+		assert.Equal(t, []domain.StatsBucket{{Start: time.Date(2024, time.February, 26, 0, 0, 0, 0, time.UTC)}, {Start: day(4, 0)}, {Start: day(11, 0)}}, stats.Completed)
+	})
+
+	mt.Run("invalid user ID", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		_, err := repo.GetStats(domain.StatsFilter{UserID: "invalidUserID"})
+
+		assert.EqualError(t, err, "the provided hex string is not a valid ObjectID")
+	})
+}
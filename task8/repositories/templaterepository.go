@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TemplateRepository struct {
+	collection *mongo.Collection
+	workspace  workspaceScope
+}
+
+func NewTemplateRepository(db *mongo.Database) *TemplateRepository {
+	collection := db.Collection("templates")
+	return &TemplateRepository{collection: collection}
+}
+
+// InWorkspace is the repository limited to the templates of one workspace,
+// the personal one for the zero ID.
+func (tr *TemplateRepository) InWorkspace(workspaceid primitive.ObjectID) domain.TemplateRepositoryInterface {
+	return &TemplateRepository{collection: tr.collection, workspace: inWorkspace(workspaceid)}
+}
+
+func (tr *TemplateRepository) CreateTemplate(newtemplate *domain.Template, userid string) error {
+
+	userObjectID, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	newtemplate.UserID = userObjectID
+	tr.workspace.assign(&newtemplate.WorkspaceID)
+
+	result, err := tr.collection.InsertOne(context.TODO(), newtemplate)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+
+	newtemplate.ID = oid
+	return nil
+}
+
+func (tr *TemplateRepository) GetTemplate(id string) (*domain.Template, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var template domain.Template
+
+	err = tr.collection.FindOne(context.TODO(), tr.workspace.filter(bson.M{"_id": oid})).Decode(&template)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// GetTemplates lists the templates of the workspace by name. Personal
+// templates are only the user's own.
+func (tr *TemplateRepository) GetTemplates(userid string) (*[]domain.Template, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+
+	query := bson.M{}
+	if tr.workspace.id.IsZero() {
+		query["user_id"] = uid
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := tr.collection.Find(context.TODO(), tr.workspace.filter(query), opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	templates := []domain.Template{}
+
+	if err = cursor.All(context.TODO(), &templates); err != nil {
+		return nil, err
+	}
+	return &templates, nil
+}
+
+func (tr *TemplateRepository) UpdateTemplate(id string, updatedtemplate *domain.Template) error {
+
+	template, err := tr.GetTemplate(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.collection.UpdateOne(context.TODO(), tr.workspace.filter(bson.M{"_id": template.ID}), bson.D{{Key: "$set", Value: bson.M{
+		"name":        updatedtemplate.Name,
+		"title":       updatedtemplate.Title,
+		"description": updatedtemplate.Description,
+		"status":      updatedtemplate.Status,
+		"tags":        updatedtemplate.Tags,
+		"due_offset":  updatedtemplate.DueOffset,
+		"checklist":   updatedtemplate.Checklist,
+	}}})
+
+	if err != nil {
+		return err
+	}
+
+	updatedtemplate.ID = template.ID
+	updatedtemplate.UserID = template.UserID
+	updatedtemplate.WorkspaceID = template.WorkspaceID
+	updatedtemplate.CreatedAt = template.CreatedAt
+	return nil
+}
+
+func (tr *TemplateRepository) RemoveTemplate(id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := tr.collection.DeleteOne(context.TODO(), tr.workspace.filter(bson.M{"_id": oid}))
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// EnsureIndexes supports listing the templates of a workspace or a user.
+func (tr *TemplateRepository) EnsureIndexes() error {
+
+	_, err := tr.collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "workspace_id", Value: 1}, {Key: "name", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "name", Value: 1}}},
+	})
+
+	return err
+}
@@ -0,0 +1,95 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCreateTemplate(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("puts the template into the workspace", func(mt *mtest.T) {
+		workspaceID := primitive.NewObjectID()
+		repo := repositories.NewTemplateRepository(mt.Coll.Database()).InWorkspace(workspaceID)
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		template := &domain.Template{Name: "Onboarding", Title: "Onboard {{name}}"}
+		userID := primitive.NewObjectID()
+
+		err := repo.CreateTemplate(template, userID.Hex())
+
+		assert.NoError(t, err)
+		assert.False(t, template.ID.IsZero())
+		assert.Equal(t, userID, template.UserID)
+		assert.Equal(t, workspaceID, template.WorkspaceID)
+	})
+
+	mt.Run("fails due to invalid userID", func(mt *mtest.T) {
+		repo := repositories.NewTemplateRepository(mt.Coll.Database())
+
+		err := repo.CreateTemplate(&domain.Template{Name: "Onboarding"}, "invalidUserID")
+
+		assert.EqualError(t, err, "user ID is not a valid ObjectID")
+	})
+}
+
+func TestGetTemplates(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("lists the templates of the workspace", func(mt *mtest.T) {
+		workspaceID := primitive.NewObjectID()
+		repo := repositories.NewTemplateRepository(mt.Coll.Database()).InWorkspace(workspaceID)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.templates", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "name", Value: "Release"},
+			{Key: "checklist", Value: bson.A{bson.D{{Key: "title", Value: "Tag {{version}}"}, {Key: "due_offset", Value: "-1d"}}}},
+		}))
+
+		templates, err := repo.GetTemplates(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, *templates, 1)
+		assert.Equal(t, []domain.TemplateItem{{Title: "Tag {{version}}", DueOffset: "-1d"}}, (*templates)[0].Checklist)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"workspace_id": {"$oid":"`+workspaceID.Hex()+`"}`)
+		assert.NotContains(t, command, "user_id")
+	})
+
+	mt.Run("personal templates are the user's own", func(mt *mtest.T) {
+		repo := repositories.NewTemplateRepository(mt.Coll.Database()).InWorkspace(primitive.NilObjectID)
+		userID := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.templates", mtest.FirstBatch))
+
+		templates, err := repo.GetTemplates(userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Empty(t, *templates)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"workspace_id": null`)
+		assert.Contains(t, command, `"user_id": {"$oid":"`+userID.Hex()+`"}`)
+	})
+}
+
+func TestRemoveTemplate(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("a template of another workspace is not found", func(mt *mtest.T) {
+		repo := repositories.NewTemplateRepository(mt.Coll.Database()).InWorkspace(primitive.NewObjectID())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}})
+
+		err := repo.RemoveTemplate(primitive.NewObjectID().Hex())
+
+		assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), "workspace_id")
+	})
+}
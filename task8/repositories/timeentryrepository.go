@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TimeEntryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTimeEntryRepository(db *mongo.Database) *TimeEntryRepository {
+	collection := db.Collection("time_entries")
+	return &TimeEntryRepository{collection: collection}
+}
+
+// CreateTimeEntry stores a finished entry or starts a timer. Starting a
+// second timer for the same user fails with a duplicate key error, see
+// EnsureIndexes.
+func (tr *TimeEntryRepository) CreateTimeEntry(newentry *domain.TimeEntry) error {
+
+	result, err := tr.collection.InsertOne(context.TODO(), newentry)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+
+	newentry.ID = oid
+	return nil
+}
+
+func (tr *TimeEntryRepository) GetTimeEntry(id string) (*domain.TimeEntry, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry domain.TimeEntry
+
+	err = tr.collection.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(&entry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// GetTimeEntries lists a user's entries, oldest first. With a range it keeps
+// the entries overlapping it, running timers included.
+func (tr *TimeEntryRepository) GetTimeEntries(filter domain.TimeEntryFilter) (*[]domain.TimeEntry, error) {
+	uid, err := primitive.ObjectIDFromHex(filter.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := bson.M{"user_id": uid}
+	if filter.TaskID != "" {
+		tid, err := primitive.ObjectIDFromHex(filter.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		query["task_id"] = tid
+	}
+	if !filter.To.IsZero() {
+		query["start"] = bson.M{"$lt": filter.To}
+	}
+	if !filter.From.IsZero() {
+		query["$or"] = bson.A{
+			bson.M{"running": true},
+			bson.M{"end": bson.M{"$gt": filter.From}},
+		}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "start", Value: 1}})
+	cursor, err := tr.collection.Find(context.TODO(), query, opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var entries []domain.TimeEntry
+
+	if err = cursor.All(context.TODO(), &entries); err != nil {
+		return nil, err
+	}
+	return &entries, nil
+}
+
+// GetRunningTimer returns the user's running timer, or nil when none is.
+func (tr *TimeEntryRepository) GetRunningTimer(userid string) (*domain.TimeEntry, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry domain.TimeEntry
+
+	err = tr.collection.FindOne(context.TODO(), bson.M{"user_id": uid, "running": true}).Decode(&entry)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// StopTimer atomically ends the user's running timer on a task at end,
+// recording its length. It returns nil when no such timer is running.
+func (tr *TimeEntryRepository) StopTimer(userid string, taskid string, end time.Time) (*domain.TimeEntry, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+	tid, err := primitive.ObjectIDFromHex(taskid)
+	if err != nil {
+		return nil, err
+	}
+
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"end":     end,
+		"running": false,
+		"seconds": bson.M{"$toLong": bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{end, "$start"}}, 1000}}},
+	}}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var entry domain.TimeEntry
+
+	err = tr.collection.FindOneAndUpdate(context.TODO(), bson.M{"user_id": uid, "task_id": tid, "running": true}, update, opts).Decode(&entry)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (tr *TimeEntryRepository) RemoveTimeEntry(id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.collection.DeleteOne(context.TODO(), bson.M{"_id": oid})
+
+	return err
+}
+
+// RemoveTaskEntries deletes the time logged on a task, which is being
+// deleted itself.
+func (tr *TimeEntryRepository) RemoveTaskEntries(taskid string) error {
+	tid, err := primitive.ObjectIDFromHex(taskid)
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.collection.DeleteMany(context.TODO(), bson.M{"task_id": tid})
+
+	return err
+}
+
+// EnsureIndexes backs the timesheet range scans and makes the one running
+// timer per user rule hold under concurrent starts.
+func (tr *TimeEntryRepository) EnsureIndexes() error {
+
+	_, err := tr.collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "start", Value: 1}}},
+		{Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "user_id", Value: 1}}},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"running": true}).
+				SetName("one_running_timer"),
+		},
+	})
+
+	return err
+}
@@ -0,0 +1,147 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestStartTimer(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("starts a timer", func(mt *mtest.T) {
+		repo := repositories.NewTimeEntryRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		entry := &domain.TimeEntry{TaskID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), Start: time.Now(), Running: true}
+		err := repo.CreateTimeEntry(entry)
+
+		assert.NoError(t, err)
+		assert.False(t, entry.ID.IsZero())
+	})
+
+	mt.Run("second running timer", func(mt *mtest.T) {
+		repo := repositories.NewTimeEntryRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"}))
+
+		err := repo.CreateTimeEntry(&domain.TimeEntry{UserID: primitive.NewObjectID(), Running: true})
+
+		assert.True(t, mongo.IsDuplicateKeyError(err))
+	})
+}
+
+func TestGetRunningTimer(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("running", func(mt *mtest.T) {
+		repo := repositories.NewTimeEntryRepository(mt.Coll.Database())
+		id := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.time_entries", mtest.FirstBatch, bson.D{{Key: "_id", Value: id}, {Key: "running", Value: true}}))
+
+		entry, err := repo.GetRunningTimer(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, id, entry.ID)
+	})
+
+	mt.Run("none", func(mt *mtest.T) {
+		repo := repositories.NewTimeEntryRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.time_entries", mtest.FirstBatch))
+
+		entry, err := repo.GetRunningTimer(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.Nil(t, entry)
+	})
+}
+
+func TestStopTimer(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("stops the running timer", func(mt *mtest.T) {
+		repo := repositories.NewTimeEntryRepository(mt.Coll.Database())
+		id := primitive.NewObjectID()
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: bson.D{{Key: "_id", Value: id}, {Key: "running", Value: false}, {Key: "seconds", Value: int64(90)}}},
+		})
+
+		entry, err := repo.StopTimer(primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), time.Now())
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(90), entry.Seconds)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"findAndModify"`)
+		assert.Contains(t, command, `"running": true`)
+		assert.Contains(t, command, `"$subtract"`)
+	})
+
+	mt.Run("no timer", func(mt *mtest.T) {
+		repo := repositories.NewTimeEntryRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: nil}})
+
+		entry, err := repo.StopTimer(primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), time.Now())
+
+		assert.NoError(t, err)
+		assert.Nil(t, entry)
+	})
+
+	mt.Run("invalid task ID", func(mt *mtest.T) {
+		repo := repositories.NewTimeEntryRepository(mt.Coll.Database())
+
+		_, err := repo.StopTimer(primitive.NewObjectID().Hex(), "invalidTaskID", time.Now())
+
+		assert.EqualError(t, err, "the provided hex string is not a valid ObjectID")
+	})
+}
+
+func TestGetTimeEntries(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("entries overlapping a range", func(mt *mtest.T) {
+		repo := repositories.NewTimeEntryRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.time_entries", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}},
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "running", Value: true}},
+		))
+
+		from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		entries, err := repo.GetTimeEntries(domain.TimeEntryFilter{UserID: primitive.NewObjectID().Hex(), From: from, To: from.AddDate(0, 1, 0)})
+
+		assert.NoError(t, err)
+		assert.Len(t, *entries, 2)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"start": {"$lt"`)
+		assert.Contains(t, command, `"end": {"$gt"`)
+	})
+}
+
+func TestRemoveTaskEntries(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("deletes the time logged on the task", func(mt *mtest.T) {
+		repo := repositories.NewTimeEntryRepository(mt.Coll.Database())
+		taskID := primitive.NewObjectID()
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 2}})
+
+		err := repo.RemoveTaskEntries(taskID.Hex())
+
+		assert.NoError(t, err)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"task_id": {"$oid":"`+taskID.Hex()+`"}`)
+	})
+}
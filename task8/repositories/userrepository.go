@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type UserRepository struct {
@@ -59,6 +60,7 @@ func (us *UserRepository) Login(user *domain.User) (string, error) {
 	if err != nil {
 		return u.Role, err
 	}
+	user.ID = u.ID
 	return u.Role, nil
 
 }
@@ -75,6 +77,67 @@ func (us *UserRepository) GetUser(email string) (*domain.User, error) {
 	return &user, nil
 }
 
+func (us *UserRepository) GetUserByID(id string) (*domain.User, error) {
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var user domain.User
+
+	err = us.collection.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(&user)
+
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetTimezone stores the user's timezone and returns the user, or nil when
+// there is no such user.
+func (us *UserRepository) SetTimezone(id string, timezone string) (*domain.User, error) {
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var user domain.User
+
+	err = us.collection.FindOneAndUpdate(context.TODO(), bson.M{"_id": oid}, bson.M{"$set": bson.M{"timezone": timezone}}, opts).Decode(&user)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (us *UserRepository) SetNextWeights(id string, weights domain.NextWeights) (*domain.User, error) {
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var user domain.User
+
+	err = us.collection.FindOneAndUpdate(context.TODO(), bson.M{"_id": oid}, bson.M{"$set": bson.M{"next_weights": weights}}, opts).Decode(&user)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (us *UserRepository) GetUsers() (*[]domain.User, error) {
 
 	cursor, err := us.collection.Find(context.TODO(), bson.D{{}})
@@ -86,6 +86,7 @@ func TestLogin(t *testing.T) {
 			Password: plainPassword, // Plaintext password
 		}
 		storedUser := domain.User{
+			ID:       primitive.NewObjectID(),
 			Email:    "test@example.com",
 			Password: string(hashedPassword), // Use the hashed password
 			Role:     "user",
@@ -94,6 +95,7 @@ func TestLogin(t *testing.T) {
 		mockps.On("Compare", mock.Anything, mock.Anything).Return(nil)
 
 		mt.AddMockResponses(mtest.CreateCursorResponse(1, "test.users", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: storedUser.ID},
 			{Key: "email", Value: storedUser.Email},
 			{Key: "password", Value: storedUser.Password},
 			{Key: "role", Value: storedUser.Role},
@@ -107,6 +109,7 @@ func TestLogin(t *testing.T) {
 		}
 		assert.NoError(t, err)
 		assert.Equal(t, "user", role)
+		assert.Equal(t, storedUser.ID, mockUser.ID)
 
 		mockps.AssertExpectations(t)
 	})
@@ -249,3 +252,67 @@ func TestGetUsers(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSetTimezone(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("sets the timezone", func(mt *mtest.T) {
+		repo := repositories.NewUserRepository(mt.Coll.Database(), new(mocks.PasswordService))
+		id := primitive.NewObjectID()
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: bson.D{
+			{Key: "_id", Value: id},
+			{Key: "email", Value: "test@example.com"},
+			{Key: "timezone", Value: "Europe/Berlin"},
+		}}})
+
+		user, err := repo.SetTimezone(id.Hex(), "Europe/Berlin")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Europe/Berlin", user.Timezone)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"$set": {"timezone": "Europe/Berlin"}`)
+	})
+
+	mt.Run("unknown user", func(mt *mtest.T) {
+		repo := repositories.NewUserRepository(mt.Coll.Database(), new(mocks.PasswordService))
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: nil}})
+
+		user, err := repo.SetTimezone(primitive.NewObjectID().Hex(), "Europe/Berlin")
+
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+	})
+}
+
+func TestSetNextWeights(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("sets the weights", func(mt *mtest.T) {
+		repo := repositories.NewUserRepository(mt.Coll.Database(), new(mocks.PasswordService))
+		id := primitive.NewObjectID()
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: bson.D{
+			{Key: "_id", Value: id},
+			{Key: "email", Value: "test@example.com"},
+			{Key: "next_weights", Value: bson.D{{Key: "priority", Value: 5.0}, {Key: "due", Value: 2.0}, {Key: "blocked", Value: 0.0}, {Key: "age", Value: 1.0}}},
+		}}})
+
+		user, err := repo.SetNextWeights(id.Hex(), domain.NextWeights{Priority: 5, Due: 2, Age: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.NextWeights{Priority: 5, Due: 2, Age: 1}, user.NextWeights)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"$set": {"next_weights": {"priority": {"$numberDouble":"5.0"}`)
+	})
+
+	mt.Run("unknown user", func(mt *mtest.T) {
+		repo := repositories.NewUserRepository(mt.Coll.Database(), new(mocks.PasswordService))
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: nil}})
+
+		user, err := repo.SetNextWeights(primitive.NewObjectID().Hex(), domain.DefaultNextWeights)
+
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+	})
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ViewRepository struct {
+	collection *mongo.Collection
+	workspace  workspaceScope
+}
+
+func NewViewRepository(db *mongo.Database) *ViewRepository {
+	collection := db.Collection("views")
+	return &ViewRepository{collection: collection}
+}
+
+// InWorkspace is the repository limited to the views of one workspace, the
+// personal one for the zero ID.
+func (vr *ViewRepository) InWorkspace(workspaceid primitive.ObjectID) domain.ViewRepositoryInterface {
+	return &ViewRepository{collection: vr.collection, workspace: inWorkspace(workspaceid)}
+}
+
+func (vr *ViewRepository) CreateView(newview *domain.View, userid string) error {
+
+	userObjectID, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	newview.UserID = userObjectID
+	vr.workspace.assign(&newview.WorkspaceID)
+
+	result, err := vr.collection.InsertOne(context.TODO(), newview)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+
+	newview.ID = oid
+	return nil
+}
+
+func (vr *ViewRepository) GetView(id string) (*domain.View, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var view domain.View
+
+	err = vr.collection.FindOne(context.TODO(), vr.workspace.filter(bson.M{"_id": oid})).Decode(&view)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &view, nil
+}
+
+// GetViews lists the user's views, pinned ones first, then by name.
+func (vr *ViewRepository) GetViews(userid string) (*[]domain.View, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "pinned", Value: -1}, {Key: "name", Value: 1}})
+	cursor, err := vr.collection.Find(context.TODO(), vr.workspace.filter(bson.M{"user_id": uid}), opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	views := []domain.View{}
+
+	if err = cursor.All(context.TODO(), &views); err != nil {
+		return nil, err
+	}
+	return &views, nil
+}
+
+func (vr *ViewRepository) UpdateView(id string, updatedview *domain.View) error {
+
+	view, err := vr.GetView(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = vr.collection.UpdateOne(context.TODO(), vr.workspace.filter(bson.M{"_id": view.ID}), bson.D{{Key: "$set", Value: bson.M{
+		"name":   updatedview.Name,
+		"filter": updatedview.Filter,
+	}}})
+
+	if err != nil {
+		return err
+	}
+
+	updatedview.ID = view.ID
+	updatedview.UserID = view.UserID
+	updatedview.WorkspaceID = view.WorkspaceID
+	updatedview.Pinned = view.Pinned
+	updatedview.CreatedAt = view.CreatedAt
+	return nil
+}
+
+func (vr *ViewRepository) PinView(id string, pinned bool) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := vr.collection.UpdateOne(context.TODO(), vr.workspace.filter(bson.M{"_id": oid}), bson.D{{Key: "$set", Value: bson.M{"pinned": pinned}}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (vr *ViewRepository) RemoveView(id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := vr.collection.DeleteOne(context.TODO(), vr.workspace.filter(bson.M{"_id": oid}))
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// EnsureIndexes supports listing a user's views in their order.
+func (vr *ViewRepository) EnsureIndexes() error {
+
+	_, err := vr.collection.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "workspace_id", Value: 1}, {Key: "pinned", Value: -1}, {Key: "name", Value: 1}},
+	})
+
+	return err
+}
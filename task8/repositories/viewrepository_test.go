@@ -0,0 +1,82 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCreateView(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("stores the filter as given", func(mt *mtest.T) {
+		repo := repositories.NewViewRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		view := &domain.View{Name: "This week", Filter: domain.TaskFilter{DueFrom: "today", DueTo: "in 7 days", Limit: 10}}
+
+		err := repo.CreateView(view, primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.False(t, view.ID.IsZero())
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"due_to": "in 7 days"`)
+		assert.NotContains(t, command, "limit")
+	})
+}
+
+func TestGetViews(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("pinned views come first", func(mt *mtest.T) {
+		workspaceID := primitive.NewObjectID()
+		repo := repositories.NewViewRepository(mt.Coll.Database()).InWorkspace(workspaceID)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.views", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "name", Value: "Urgent"},
+			{Key: "pinned", Value: true},
+			{Key: "filter", Value: bson.D{{Key: "tags", Value: bson.A{"urgent"}}}},
+		}))
+
+		views, err := repo.GetViews(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, *views, 1)
+		assert.Equal(t, []string{"urgent"}, (*views)[0].Filter.Tags)
+		command := mt.GetStartedEvent().Command
+		assert.Equal(t, `{"pinned": {"$numberInt":"-1"},"name": {"$numberInt":"1"}}`, command.Lookup("sort").String())
+		assert.Contains(t, command.String(), `"workspace_id": {"$oid":"`+workspaceID.Hex()+`"}`)
+	})
+}
+
+func TestPinView(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("pins a view", func(mt *mtest.T) {
+		repo := repositories.NewViewRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+
+		err := repo.PinView(primitive.NewObjectID().Hex(), true)
+
+		assert.NoError(t, err)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"pinned": true`)
+	})
+
+	mt.Run("view not found", func(mt *mtest.T) {
+		repo := repositories.NewViewRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}})
+
+		err := repo.PinView(primitive.NewObjectID().Hex(), true)
+
+		assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+	})
+}
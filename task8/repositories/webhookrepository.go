@@ -0,0 +1,253 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookRepository struct {
+	collection *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+func NewWebhookRepository(db *mongo.Database) *WebhookRepository {
+	collection := db.Collection("webhooks")
+	deliveries := db.Collection("webhook_deliveries")
+	return &WebhookRepository{collection: collection, deliveries: deliveries}
+}
+
+func (wr *WebhookRepository) CreateWebhook(newwebhook *domain.Webhook) error {
+
+	result, err := wr.collection.InsertOne(context.TODO(), newwebhook)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+
+	newwebhook.ID = oid
+	return nil
+}
+
+func (wr *WebhookRepository) GetWebhook(id string) (*domain.Webhook, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook domain.Webhook
+
+	err = wr.collection.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(&webhook)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (wr *WebhookRepository) GetWebhooks(userid string) (*[]domain.Webhook, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := wr.collection.Find(context.TODO(), bson.M{"user_id": uid})
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var webhooks []domain.Webhook
+
+	if err = cursor.All(context.TODO(), &webhooks); err != nil {
+		return nil, err
+	}
+	return &webhooks, nil
+}
+
+func (wr *WebhookRepository) RemoveWebhook(id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = wr.collection.DeleteOne(context.TODO(), bson.M{"_id": oid})
+
+	return err
+}
+
+// FindSubscribers returns the webhooks of the given users that listen to the event.
+func (wr *WebhookRepository) FindSubscribers(event string, userids []primitive.ObjectID) (*[]domain.Webhook, error) {
+
+	cursor, err := wr.collection.Find(context.TODO(), bson.M{"user_id": bson.M{"$in": userids}, "events": event})
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var webhooks []domain.Webhook
+
+	if err = cursor.All(context.TODO(), &webhooks); err != nil {
+		return nil, err
+	}
+	return &webhooks, nil
+}
+
+func (wr *WebhookRepository) CreateDeliveries(deliveries []domain.WebhookDelivery) error {
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+	documents := make([]interface{}, len(deliveries))
+	for i := range deliveries {
+		documents[i] = deliveries[i]
+	}
+
+	result, err := wr.deliveries.InsertMany(context.TODO(), documents)
+
+	if err != nil {
+		return err
+	}
+	for i, id := range result.InsertedIDs {
+		if oid, ok := id.(primitive.ObjectID); ok {
+			deliveries[i].ID = oid
+		}
+	}
+	return nil
+}
+
+func (wr *WebhookRepository) GetDelivery(id string) (*domain.WebhookDelivery, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var delivery domain.WebhookDelivery
+
+	err = wr.deliveries.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(&delivery)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveries returns the latest deliveries, newest first, optionally
+// narrowed to one status or webhook.
+func (wr *WebhookRepository) GetDeliveries(filter domain.DeliveryFilter) (*[]domain.WebhookDelivery, error) {
+
+	query := bson.M{}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.WebhookID != "" {
+		wid, err := primitive.ObjectIDFromHex(filter.WebhookID)
+		if err != nil {
+			return nil, err
+		}
+		query["webhook_id"] = wid
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(200)
+	cursor, err := wr.deliveries.Find(context.TODO(), query, opts)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var deliveries []domain.WebhookDelivery
+
+	if err = cursor.All(context.TODO(), &deliveries); err != nil {
+		return nil, err
+	}
+	return &deliveries, nil
+}
+
+// ClaimDelivery atomically takes the next delivery that is due, leasing it
+// until now+lease, the same way reminders are claimed. It returns nil when
+// nothing is due.
+func (wr *WebhookRepository) ClaimDelivery(now time.Time, lease time.Duration) (*domain.WebhookDelivery, error) {
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": domain.DeliveryPending, "next_attempt": bson.M{"$lte": now}},
+		bson.M{"status": domain.DeliverySending, "locked_until": bson.M{"$lte": now}},
+	}}
+	update := bson.D{
+		{Key: "$set", Value: bson.M{"status": domain.DeliverySending, "locked_until": now.Add(lease)}},
+		{Key: "$inc", Value: bson.M{"attempts": 1}},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var delivery domain.WebhookDelivery
+
+	err := wr.deliveries.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&delivery)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// UpdateDelivery saves the state of a delivery after an attempt or a replay
+// and releases its lease.
+func (wr *WebhookRepository) UpdateDelivery(id string, delivery *domain.WebhookDelivery) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = wr.deliveries.UpdateOne(context.TODO(), bson.M{"_id": oid}, bson.D{
+		{Key: "$set", Value: bson.M{
+			"status":        delivery.Status,
+			"attempts":      delivery.Attempts,
+			"next_attempt":  delivery.NextAttempt,
+			"response_code": delivery.ResponseCode,
+			"last_error":    delivery.LastError,
+			"delivered_at":  delivery.DeliveredAt,
+		}},
+		{Key: "$unset", Value: bson.M{"locked_until": ""}},
+	})
+
+	return err
+}
+
+func (wr *WebhookRepository) EnsureIndexes() error {
+
+	_, err := wr.collection.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "events", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = wr.deliveries.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "locked_until", Value: 1}}},
+		{Keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+	})
+
+	return err
+}
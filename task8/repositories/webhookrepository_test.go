@@ -0,0 +1,65 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestFindSubscribers(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("matches users and event", func(mt *mtest.T) {
+		repo := repositories.NewWebhookRepository(mt.Coll.Database())
+		id := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.webhooks", mtest.FirstBatch, bson.D{{Key: "_id", Value: id}, {Key: "url", Value: "https://example.com"}}))
+
+		webhooks, err := repo.FindSubscribers(domain.EventTaskCreated, []primitive.ObjectID{primitive.NewObjectID()})
+
+		assert.NoError(t, err)
+		assert.Len(t, *webhooks, 1)
+		command := mt.GetStartedEvent().Command.String()
+		assert.Contains(t, command, `"events": "task.created"`)
+		assert.Contains(t, command, `"$in"`)
+	})
+}
+
+func TestCreateDeliveries(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("inserts all deliveries at once", func(mt *mtest.T) {
+		repo := repositories.NewWebhookRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		deliveries := []domain.WebhookDelivery{{Event: domain.EventTaskCreated}, {Event: domain.EventTaskCreated}}
+		err := repo.CreateDeliveries(deliveries)
+
+		assert.NoError(t, err)
+		assert.False(t, deliveries[1].ID.IsZero())
+		assert.Equal(t, "webhook_deliveries", mt.GetStartedEvent().Command.Lookup("insert").StringValue())
+	})
+}
+
+func TestClaimDelivery(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("nothing due", func(mt *mtest.T) {
+		repo := repositories.NewWebhookRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: nil}})
+
+		delivery, err := repo.ClaimDelivery(time.Now(), time.Minute)
+
+		assert.NoError(t, err)
+		assert.Nil(t, delivery)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"next_attempt"`)
+	})
+}
@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// workspaceScope limits a repository to the documents of one workspace.
+// The zero scope is not limited, for the work the server does on its own
+// behalf, such as firing reminders.
+type workspaceScope struct {
+	scoped bool
+	id     primitive.ObjectID
+}
+
+func inWorkspace(workspaceid primitive.ObjectID) workspaceScope {
+	return workspaceScope{scoped: true, id: workspaceid}
+}
+
+// filter adds the workspace to a query.
+func (s workspaceScope) filter(query bson.M) bson.M {
+	if s.scoped {
+		query["workspace_id"] = workspaceValue(s.id)
+	}
+	return query
+}
+
+// assign puts a new document into the workspace.
+func (s workspaceScope) assign(workspaceid *primitive.ObjectID) {
+	if s.scoped {
+		*workspaceid = s.id
+	}
+}
+
+// workspaceValue matches the workspace in a query. Documents of the personal
+// workspace have no workspace_id, which null matches.
+func workspaceValue(workspaceid primitive.ObjectID) interface{} {
+	if workspaceid.IsZero() {
+		return nil
+	}
+	return workspaceid
+}
+
+type WorkspaceRepository struct {
+	collection *mongo.Collection
+	tasks      *mongo.Collection
+	projects   *mongo.Collection
+}
+
+func NewWorkspaceRepository(db *mongo.Database) *WorkspaceRepository {
+	collection := db.Collection("workspaces")
+	tasks := db.Collection("tasks")
+	projects := db.Collection("projects")
+	return &WorkspaceRepository{collection: collection, tasks: tasks, projects: projects}
+}
+
+func (wr *WorkspaceRepository) CreateWorkspace(newworkspace *domain.Workspace) error {
+
+	result, err := wr.collection.InsertOne(context.TODO(), newworkspace)
+
+	if err != nil {
+		return err
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+
+	if !ok {
+		return errors.New("failed to retrive the inserted ID")
+	}
+
+	newworkspace.ID = oid
+	return nil
+}
+
+func (wr *WorkspaceRepository) GetWorkspace(id string) (*domain.Workspace, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var workspace domain.Workspace
+
+	err = wr.collection.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(&workspace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &workspace, nil
+}
+
+// GetWorkspaces returns every workspace the user is a member of.
+func (wr *WorkspaceRepository) GetWorkspaces(userid string) (*[]domain.Workspace, error) {
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := wr.collection.Find(context.TODO(), bson.M{"members.user_id": uid})
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	workspaces := []domain.Workspace{}
+
+	if err = cursor.All(context.TODO(), &workspaces); err != nil {
+		return nil, err
+	}
+	return &workspaces, nil
+}
+
+func (wr *WorkspaceRepository) UpdateWorkspace(id string, updatedworkspace *domain.Workspace) error {
+
+	oid, err := primitive.ObjectIDFromHex(id)
+
+	if err != nil {
+		return err
+	}
+	_, err = wr.collection.UpdateOne(context.TODO(), bson.M{"_id": oid}, bson.D{{Key: "$set", Value: bson.M{
+		"name":    updatedworkspace.Name,
+		"members": updatedworkspace.Members,
+	}}})
+
+	return err
+}
+
+// RemoveWorkspace deletes the workspace together with its projects and tasks.
+func (wr *WorkspaceRepository) RemoveWorkspace(id string) error {
+
+	oid, err := primitive.ObjectIDFromHex(id)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = wr.tasks.DeleteMany(context.TODO(), bson.M{"workspace_id": oid}); err != nil {
+		return err
+	}
+	if _, err = wr.projects.DeleteMany(context.TODO(), bson.M{"workspace_id": oid}); err != nil {
+		return err
+	}
+
+	_, err = wr.collection.DeleteOne(context.TODO(), bson.M{"_id": oid})
+
+	return err
+}
+
+func (wr *WorkspaceRepository) EnsureIndexes() error {
+
+	_, err := wr.collection.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{{Key: "members.user_id", Value: 1}},
+	})
+
+	return err
+}
@@ -0,0 +1,190 @@
+package repositories_test
+
+import (
+	"task8/domain"
+	"task8/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCreateWorkspace(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("successfully creates a workspace", func(mt *mtest.T) {
+		repo := repositories.NewWorkspaceRepository(mt.Coll.Database())
+
+		workspace := &domain.Workspace{Name: "Acme"}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.CreateWorkspace(workspace)
+
+		assert.NoError(t, err)
+		assert.False(t, workspace.ID.IsZero())
+	})
+}
+
+func TestGetWorkspaces(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("finds workspaces by membership", func(mt *mtest.T) {
+		repo := repositories.NewWorkspaceRepository(mt.Coll.Database())
+
+		userID := primitive.NewObjectID()
+		workspaceID := primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.workspaces", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: workspaceID},
+			{Key: "name", Value: "Acme"},
+			{Key: "members", Value: bson.A{bson.D{{Key: "user_id", Value: userID}, {Key: "role", Value: "admin"}}}},
+		}))
+
+		workspaces, err := repo.GetWorkspaces(userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, *workspaces, 1)
+		assert.Equal(t, []domain.WorkspaceMembership{{UserID: userID, Role: domain.WorkspaceAdmin}}, (*workspaces)[0].Members)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"members.user_id"`)
+	})
+
+	mt.Run("fails due to invalid userID", func(mt *mtest.T) {
+		repo := repositories.NewWorkspaceRepository(mt.Coll.Database())
+
+		_, err := repo.GetWorkspaces("invalidUserID")
+
+		assert.EqualError(t, err, "the provided hex string is not a valid ObjectID")
+	})
+}
+
+func TestRemoveWorkspace(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("removes the workspace with its tasks and projects", func(mt *mtest.T) {
+		repo := repositories.NewWorkspaceRepository(mt.Coll.Database())
+		workspaceID := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		err := repo.RemoveWorkspace(workspaceID.Hex())
+
+		assert.NoError(t, err)
+		started := mt.GetAllStartedEvents()
+		assert.Len(t, started, 3)
+		assert.Equal(t, "tasks", started[0].Command.Lookup("delete").StringValue())
+		assert.Contains(t, started[0].Command.String(), `"workspace_id": {"$oid":"`+workspaceID.Hex()+`"}`)
+		assert.Equal(t, "projects", started[1].Command.Lookup("delete").StringValue())
+		assert.Equal(t, "workspaces", started[2].Command.Lookup("delete").StringValue())
+	})
+}
+
+// The scoped repositories must put the workspace into every query, so that
+// a task or project of another tenant is never read or written.
+func TestWorkspaceIsolation(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	acme := primitive.NewObjectID()
+	inAcme := `"workspace_id": {"$oid":"` + acme.Hex() + `"}`
+
+	mt.Run("a task of another workspace is not found", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database()).InWorkspace(acme)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
+
+		_, err := repo.GetTask(primitive.NewObjectID().Hex())
+
+		assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), inAcme)
+	})
+
+	mt.Run("task reads and writes are scoped", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database()).InWorkspace(acme)
+		userID := primitive.NewObjectID().Hex()
+		taskID := primitive.NewObjectID().Hex()
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch),
+		)
+
+		_, err := repo.GetTasks(userID)
+		assert.NoError(t, err)
+		_, err = repo.GetAssignedTasks(userID)
+		assert.NoError(t, err)
+		assert.NoError(t, repo.UpdateTask(taskID, &domain.Task{Title: "Moved"}))
+		assert.NoError(t, repo.RemoveTask(taskID))
+		_, err = repo.GetStats(domain.StatsFilter{UserID: userID, Interval: "day"})
+		assert.NoError(t, err)
+		_, err = repo.GetDependencyGraph(taskID)
+		assert.NoError(t, err)
+
+		started := mt.GetAllStartedEvents()
+		assert.Len(t, started, 6)
+		for _, event := range started {
+			assert.Contains(t, event.Command.String(), inAcme, event.CommandName)
+		}
+		assert.Contains(t, started[5].Command.String(), `"restrictSearchWithMatch": {`+inAcme+`}`)
+	})
+
+	mt.Run("new tasks are put into the workspace", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database()).InWorkspace(acme)
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		task := &domain.Task{Title: "Ship", WorkspaceID: primitive.NewObjectID()}
+
+		err := repo.CreateTask(task, primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, acme, task.WorkspaceID)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), inAcme)
+	})
+
+	mt.Run("the personal workspace holds tasks without one", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database()).InWorkspace(primitive.NilObjectID)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
+
+		_, err := repo.GetTasks(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.Contains(t, mt.GetStartedEvent().Command.String(), `"workspace_id": null`)
+	})
+
+	mt.Run("projects are scoped", func(mt *mtest.T) {
+		repo := repositories.NewProjectRepository(mt.Coll.Database()).InWorkspace(acme)
+
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCursorResponse(0, "test.projects", mtest.FirstBatch),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+		)
+		project := &domain.Project{Name: "Website"}
+
+		assert.NoError(t, repo.CreateProject(project))
+		_, err := repo.GetProjects(primitive.NewObjectID().Hex())
+		assert.NoError(t, err)
+		assert.NoError(t, repo.RemoveProject(primitive.NewObjectID().Hex()))
+
+		assert.Equal(t, acme, project.WorkspaceID)
+		for _, event := range mt.GetAllStartedEvents() {
+			assert.Contains(t, event.Command.String(), inAcme, event.CommandName)
+		}
+	})
+
+	mt.Run("unscoped repositories see every workspace", func(mt *mtest.T) {
+		repo := repositories.NewTaskRepository(mt.Coll.Database())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.tasks", mtest.FirstBatch))
+
+		_, err := repo.GetTasks(primitive.NewObjectID().Hex())
+
+		assert.NoError(t, err)
+		assert.NotContains(t, mt.GetStartedEvent().Command.String(), "workspace_id")
+	})
+}
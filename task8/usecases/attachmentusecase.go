@@ -0,0 +1,170 @@
+package usecases
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxAttachmentSize = 10 << 20
+
+var allowedAttachmentTypes = map[string]bool{
+	"image/png":          true,
+	"image/jpeg":         true,
+	"image/gif":          true,
+	"image/webp":         true,
+	"application/pdf":    true,
+	"application/zip":    true,
+	"application/json":   true,
+	"text/plain":         true,
+	"text/markdown":      true,
+	"text/csv":           true,
+	"application/x-gzip": true,
+}
+
+// textAttachmentTypes sniff as text/plain, so for them the declared type is kept.
+var textAttachmentTypes = map[string]bool{
+	"text/markdown":    true,
+	"text/csv":         true,
+	"application/json": true,
+}
+
+type AttachmentUsecase struct {
+	repository domain.AttachmentRepositoryInterface
+	tasks      domain.TaskUsecaseInterface
+}
+
+func NewAttachmentUsecase(repository domain.AttachmentRepositoryInterface, tasks domain.TaskUsecaseInterface) *AttachmentUsecase {
+	return &AttachmentUsecase{repository: repository, tasks: tasks}
+}
+
+// InWorkspace is the usecase limited to the tasks of one workspace.
+func (au *AttachmentUsecase) InWorkspace(workspaceID string) (domain.AttachmentUsecaseInterface, error) {
+
+	tasks, err := au.tasks.InWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	scoped := *au
+	scoped.tasks = tasks
+	return &scoped, nil
+}
+
+func (au *AttachmentUsecase) UploadAttachment(taskid string, filename string, contenttype string, content io.Reader, userid string) (*domain.Attachment, error) {
+
+	task, err := au.tasks.GetEditableTask(taskid, userid)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, maxAttachmentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("attachment is empty")
+	}
+	if len(data) > maxAttachmentSize {
+		return nil, errors.New("attachment exceeds the 10 MB limit")
+	}
+
+	detected := attachmentType(data, contenttype)
+	if !allowedAttachmentTypes[detected] {
+		return nil, errors.New("attachment type " + detected + " is not allowed")
+	}
+
+	sum := sha256.Sum256(data)
+	attachment := &domain.Attachment{
+		TaskID:      task.ID,
+		UserID:      uid,
+		Filename:    attachmentName(filename),
+		ContentType: detected,
+		Size:        int64(len(data)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		CreatedAt:   time.Now(),
+	}
+	if err := au.repository.CreateAttachment(attachment, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (au *AttachmentUsecase) GetAttachments(taskid string, userid string) (*[]domain.Attachment, error) {
+
+	if _, err := au.tasks.GetTask(taskid, userid); err != nil {
+		return nil, err
+	}
+	return au.repository.GetAttachments(taskid)
+}
+
+func (au *AttachmentUsecase) OpenAttachment(taskid string, id string, userid string) (*domain.Attachment, io.ReadSeekCloser, error) {
+
+	attachment, _, err := au.authorize(taskid, id, userid)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := au.repository.OpenAttachment(attachment)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// RemoveAttachment is open to the uploader and to the creator of the task.
+func (au *AttachmentUsecase) RemoveAttachment(taskid string, id string, userid string) error {
+
+	attachment, task, err := au.authorize(taskid, id, userid)
+	if err != nil {
+		return err
+	}
+	if attachment.UserID.Hex() != userid && task.UserID.Hex() != userid {
+		return errors.New("only the uploader or the task owner can delete an attachment")
+	}
+	return au.repository.RemoveAttachment(id)
+}
+
+func (au *AttachmentUsecase) authorize(taskid string, id string, userid string) (*domain.Attachment, *domain.Task, error) {
+
+	task, err := au.tasks.GetTask(taskid, userid)
+	if err != nil {
+		return nil, nil, err
+	}
+	attachment, err := au.repository.GetAttachment(id)
+	if err != nil || attachment.TaskID != task.ID {
+		return nil, nil, errors.New("attachment not found")
+	}
+	return attachment, task, nil
+}
+
+// attachmentType sniffs the content type instead of trusting the client.
+func attachmentType(data []byte, declared string) string {
+
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	declared, _, _ = mime.ParseMediaType(declared)
+	if detected == "text/plain" && textAttachmentTypes[declared] {
+		return declared
+	}
+	return detected
+}
+
+func attachmentName(filename string) string {
+	name := filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if name == "." || name == "/" || name == "" {
+		return "attachment"
+	}
+	return name
+}
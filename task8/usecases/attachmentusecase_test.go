@@ -0,0 +1,76 @@
+package usecases_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUploadAttachment(t *testing.T) {
+	mockRepo := new(mocks.AttachmentRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	attachmentUsecase := usecases.NewAttachmentUsecase(mockRepo, mockTasks)
+
+	userID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	mockTasks.On("GetEditableTask", taskID.Hex(), userID.Hex()).Return(&domain.Task{ID: taskID, UserID: userID}, nil)
+
+	t.Run("stores a sniffed and hashed file", func(t *testing.T) {
+		content := "name,estimate\nlogin,3\n"
+		sum := sha256.Sum256([]byte(content))
+		mockRepo.On("CreateAttachment", mock.MatchedBy(func(a *domain.Attachment) bool {
+			return a.SHA256 == hex.EncodeToString(sum[:]) && a.ContentType == "text/csv" && a.Size == int64(len(content)) && a.Filename == "spec.csv"
+		}), mock.Anything).Return(nil).Once()
+
+		attachment, err := attachmentUsecase.UploadAttachment(taskID.Hex(), "../../spec.csv", "text/csv", strings.NewReader(content), userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, taskID, attachment.TaskID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a disallowed type", func(t *testing.T) {
+		_, err := attachmentUsecase.UploadAttachment(taskID.Hex(), "run.exe", "image/png", bytes.NewReader([]byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00\x00\x00\xff\xff\x00\x00")), userID.Hex())
+
+		assert.ErrorContains(t, err, "is not allowed")
+	})
+
+	t.Run("rejects a file over the limit", func(t *testing.T) {
+		big := bytes.Repeat([]byte("a"), 10<<20+1)
+
+		_, err := attachmentUsecase.UploadAttachment(taskID.Hex(), "big.txt", "text/plain", bytes.NewReader(big), userID.Hex())
+
+		assert.EqualError(t, err, "attachment exceeds the 10 MB limit")
+	})
+}
+
+func TestRemoveAttachment(t *testing.T) {
+	mockRepo := new(mocks.AttachmentRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	attachmentUsecase := usecases.NewAttachmentUsecase(mockRepo, mockTasks)
+
+	ownerID := primitive.NewObjectID()
+	uploaderID := primitive.NewObjectID()
+	otherID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	attachmentID := primitive.NewObjectID()
+
+	mockTasks.On("GetTask", taskID.Hex(), mock.Anything).Return(&domain.Task{ID: taskID, UserID: ownerID}, nil)
+	mockRepo.On("GetAttachment", attachmentID.Hex()).Return(&domain.Attachment{ID: attachmentID, TaskID: taskID, UserID: uploaderID}, nil)
+
+	err := attachmentUsecase.RemoveAttachment(taskID.Hex(), attachmentID.Hex(), otherID.Hex())
+	assert.EqualError(t, err, "only the uploader or the task owner can delete an attachment")
+
+	mockRepo.On("RemoveAttachment", attachmentID.Hex()).Return(nil).Once()
+	err = attachmentUsecase.RemoveAttachment(taskID.Hex(), attachmentID.Hex(), ownerID.Hex())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,367 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxAutomationDepth is how many automations may trigger each other in a
+// chain before the rest are skipped.
+const maxAutomationDepth = 5
+
+var automationActions = []string{domain.ActionAddTag, domain.ActionRemoveTag, domain.ActionSetStatus, domain.ActionAssign, domain.ActionCreateTask, domain.ActionSetPriority}
+
+// AutomationUsecase manages automations and, as an event publisher, runs
+// them. The task usecase it applies actions with must not publish to it,
+// the events of the changes it makes are passed back through a chain
+// instead so loops can be stopped.
+type AutomationUsecase struct {
+	repository domain.AutomationRepositoryInterface
+	tasks      domain.TaskUsecaseInterface
+	users      domain.UserRepositoryInterface
+	events     domain.EventPublisher
+}
+
+func NewAutomationUsecase(repository domain.AutomationRepositoryInterface, tasks domain.TaskUsecaseInterface, users domain.UserRepositoryInterface, events domain.EventPublisher) *AutomationUsecase {
+	return &AutomationUsecase{repository: repository, tasks: tasks, users: users, events: events}
+}
+
+// InWorkspace is the usecase limited to the automations of one workspace.
+func (au *AutomationUsecase) InWorkspace(workspaceID string) (domain.AutomationUsecaseInterface, error) {
+
+	wid, err := workspaceObjectID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	scoped := *au
+	scoped.repository = au.repository.InWorkspace(wid)
+	return &scoped, nil
+}
+
+func (au *AutomationUsecase) CreateAutomation(newautomation *domain.Automation, userid string) error {
+
+	if err := validateAutomation(newautomation); err != nil {
+		return err
+	}
+	newautomation.ID = primitive.NilObjectID
+	newautomation.CreatedAt = time.Now()
+	return au.repository.CreateAutomation(newautomation, userid)
+}
+
+func (au *AutomationUsecase) GetAutomations(userid string) (*[]domain.Automation, error) {
+	return au.repository.GetAutomations(userid)
+}
+
+func (au *AutomationUsecase) UpdateAutomation(id string, updatedautomation *domain.Automation, userid string) error {
+
+	if _, err := au.ownedAutomation(id, userid); err != nil {
+		return err
+	}
+	if err := validateAutomation(updatedautomation); err != nil {
+		return err
+	}
+	return au.repository.UpdateAutomation(id, updatedautomation)
+}
+
+func (au *AutomationUsecase) RemoveAutomation(id string, userid string) error {
+
+	if _, err := au.ownedAutomation(id, userid); err != nil {
+		return err
+	}
+	return au.repository.RemoveAutomation(id)
+}
+
+// GetRuns returns the execution log of an automation the user can see.
+func (au *AutomationUsecase) GetRuns(id string, userid string) (*[]domain.AutomationRun, error) {
+
+	automation, err := au.visibleAutomation(id, userid)
+	if err != nil {
+		return nil, err
+	}
+	return au.repository.GetRuns(automation.ID.Hex())
+}
+
+// Publish runs the automations an event of the task usecase triggers.
+func (au *AutomationUsecase) Publish(event string, task *domain.Task) {
+
+	chain := &automationChain{usecase: au, fired: make(map[string]bool)}
+	chain.run(event, task)
+}
+
+// automationChain follows the events automations cause. Each automation
+// runs at most once on a task within a chain, and a chain is cut short
+// after maxAutomationDepth steps.
+type automationChain struct {
+	usecase *AutomationUsecase
+	depth   int
+	fired   map[string]bool
+}
+
+// Publish passes an event caused by an automation on to the other
+// publishers, then runs the automations it triggers one step deeper.
+func (c *automationChain) Publish(event string, task *domain.Task) {
+
+	c.usecase.events.Publish(event, task)
+	next := &automationChain{usecase: c.usecase, depth: c.depth + 1, fired: c.fired}
+	next.run(event, task)
+}
+
+func (c *automationChain) run(event string, task *domain.Task) {
+
+	au := c.usecase
+	automations, err := au.repository.GetTriggered(event, task)
+	if err != nil {
+		log.Printf("automations: %s for task %s: %v", event, task.ID.Hex(), err)
+		return
+	}
+	for _, automation := range *automations {
+		if !automationMatches(&automation, task) {
+			continue
+		}
+		run := &domain.AutomationRun{AutomationID: automation.ID, TaskID: task.ID, Event: event, Depth: c.depth, At: time.Now()}
+		key := automation.ID.Hex() + "/" + task.ID.Hex()
+		switch {
+		case c.depth >= maxAutomationDepth:
+			run.Status = domain.RunSkipped
+			run.Error = fmt.Sprintf("loop protection: more than %d automations in a chain", maxAutomationDepth)
+		case c.fired[key]:
+			run.Status = domain.RunSkipped
+			run.Error = "loop protection: already ran on this task in the chain"
+		default:
+			c.fired[key] = true
+			run.Status = domain.RunSucceeded
+			if err := c.apply(&automation, task); err != nil {
+				run.Status = domain.RunFailed
+				run.Error = err.Error()
+			}
+		}
+		if err := au.repository.LogRun(run); err != nil {
+			log.Printf("automations: logging run of %s: %v", automation.ID.Hex(), err)
+		}
+	}
+}
+
+// apply performs the actions of an automation on the task as its creator.
+// Tag, status and priority changes are saved together in one update.
+func (c *automationChain) apply(automation *domain.Automation, task *domain.Task) error {
+
+	au := c.usecase
+	userid := automation.UserID.Hex()
+	workspace := ""
+	if !task.WorkspaceID.IsZero() {
+		workspace = task.WorkspaceID.Hex()
+	}
+	scoped, err := au.tasks.InWorkspace(workspace)
+	if err != nil {
+		return err
+	}
+	tasks := scoped.WithEvents(c)
+
+	var updated *domain.Task
+	changed := false
+	for _, action := range automation.Actions {
+		switch action.Type {
+		case domain.ActionAddTag, domain.ActionRemoveTag, domain.ActionSetStatus, domain.ActionSetPriority:
+			if updated == nil {
+				current, err := tasks.GetTask(task.ID.Hex(), userid)
+				if err != nil {
+					return err
+				}
+				updated = current
+			}
+			changed = applyFieldAction(updated, action) || changed
+		}
+	}
+	if changed {
+		if err := tasks.UpdateTask(task.ID.Hex(), updated, userid); err != nil {
+			return err
+		}
+	}
+
+	for _, action := range automation.Actions {
+		switch action.Type {
+		case domain.ActionAssign:
+			if _, err := tasks.AssignTask(task.ID.Hex(), action.Value, userid); err != nil {
+				return err
+			}
+		case domain.ActionCreateTask:
+			followup, err := au.followUp(action, task, userid)
+			if err != nil {
+				return err
+			}
+			if err := tasks.CreateTask(followup, userid); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyFieldAction changes the task as a tag, status or priority action says,
+// reporting whether anything changed.
+func applyFieldAction(task *domain.Task, action domain.AutomationAction) bool {
+
+	switch action.Type {
+	case domain.ActionAddTag:
+		if slices.Contains(task.Tags, action.Value) {
+			return false
+		}
+		task.Tags = append(task.Tags, action.Value)
+		return true
+	case domain.ActionRemoveTag:
+		index := slices.Index(task.Tags, action.Value)
+		if index < 0 {
+			return false
+		}
+		task.Tags = slices.Delete(task.Tags, index, index+1)
+		return true
+	case domain.ActionSetStatus:
+		if task.Status == action.Value {
+			return false
+		}
+		task.Status = action.Value
+		return true
+	case domain.ActionSetPriority:
+		if task.Priority == action.Value {
+			return false
+		}
+		task.Priority = action.Value
+		return true
+	}
+	return false
+}
+
+// followUp is the task a create_task action makes, in the project of the
+// task the automation runs on and due an offset from now.
+func (au *AutomationUsecase) followUp(action domain.AutomationAction, task *domain.Task, userid string) (*domain.Task, error) {
+
+	render := &templateRender{variables: map[string]string{"title": task.Title}}
+	followup := &domain.Task{
+		Title:       render.text(action.Title),
+		Description: render.text(action.Description),
+		Status:      defaultTemplateStatus,
+		ProjectID:   task.ProjectID,
+	}
+	if followup.Description == "" {
+		followup.Description = fmt.Sprintf("Follow-up of %s", task.Title)
+	}
+	if err := render.check(); err != nil {
+		return nil, err
+	}
+	start := time.Now().In(userLocation(au.users, userid))
+	if err := applyDueOffset(followup, action.DueOffset, start); err != nil {
+		return nil, err
+	}
+	return followup, nil
+}
+
+// automationMatches tells whether the task meets the conditions of the
+// automation.
+func automationMatches(automation *domain.Automation, task *domain.Task) bool {
+
+	conditions := automation.Conditions
+	if len(conditions.Statuses) > 0 && !slices.Contains(conditions.Statuses, task.Status) {
+		return false
+	}
+	for _, tag := range conditions.Tags {
+		if !slices.Contains(task.Tags, tag) {
+			return false
+		}
+	}
+	return conditions.ProjectID.IsZero() || conditions.ProjectID == task.ProjectID
+}
+
+// visibleAutomation finds an automation of the workspace, or a personal
+// one of the user.
+func (au *AutomationUsecase) visibleAutomation(id string, userid string) (*domain.Automation, error) {
+
+	automation, err := au.repository.GetAutomation(id)
+	if err != nil {
+		return nil, err
+	}
+	if automation.WorkspaceID.IsZero() && automation.UserID.Hex() != userid {
+		return nil, errors.New("automation not found")
+	}
+	return automation, nil
+}
+
+// ownedAutomation is a visible automation the user created; the actions of
+// an automation are taken as its creator, so only they change it.
+func (au *AutomationUsecase) ownedAutomation(id string, userid string) (*domain.Automation, error) {
+
+	automation, err := au.visibleAutomation(id, userid)
+	if err != nil {
+		return nil, err
+	}
+	if automation.UserID.Hex() != userid {
+		return nil, errors.New("only the creator can change an automation")
+	}
+	return automation, nil
+}
+
+func validateAutomation(automation *domain.Automation) error {
+
+	automation.Name = strings.TrimSpace(automation.Name)
+	if automation.Name == "" {
+		return errors.New("incomplete information")
+	}
+	if !slices.Contains(domain.TaskEvents, automation.Trigger) {
+		return errors.New("unknown trigger " + automation.Trigger)
+	}
+	if len(automation.Actions) == 0 {
+		return errors.New("at least one action is required")
+	}
+	automation.Conditions.Tags = normalizeTags(automation.Conditions.Tags)
+	for i := range automation.Actions {
+		action := &automation.Actions[i]
+		action.Value = strings.TrimSpace(action.Value)
+		if !slices.Contains(automationActions, action.Type) {
+			return errors.New("unknown action " + action.Type)
+		}
+		if automation.Trigger == domain.EventTaskDeleted && action.Type != domain.ActionCreateTask {
+			return errors.New("a deleted task can only be followed by create_task")
+		}
+		switch action.Type {
+		case domain.ActionAddTag, domain.ActionRemoveTag:
+			tags := normalizeTags([]string{action.Value})
+			if len(tags) == 0 {
+				return errors.New(action.Type + " needs a value")
+			}
+			action.Value = tags[0]
+		case domain.ActionSetStatus:
+			if action.Value == "" {
+				return errors.New(action.Type + " needs a value")
+			}
+		case domain.ActionSetPriority:
+			action.Value = normalizePriority(action.Value)
+			if !slices.Contains(domain.Priorities, action.Value) {
+				return errPriority
+			}
+		case domain.ActionAssign:
+			if _, err := primitive.ObjectIDFromHex(action.Value); err != nil {
+				return errors.New("assign needs a user ID")
+			}
+		case domain.ActionCreateTask:
+			if strings.TrimSpace(action.Title) == "" {
+				return errors.New("create_task needs a title")
+			}
+			if _, _, err := parseDueOffset(action.DueOffset); err != nil {
+				return err
+			}
+			render := &templateRender{variables: map[string]string{"title": ""}}
+			render.text(action.Title)
+			render.text(action.Description)
+			if err := render.check(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateAutomation(t *testing.T) {
+	mockRepo := new(mocks.AutomationRepositoryInterface)
+	automationUsecase := usecases.NewAutomationUsecase(mockRepo, new(mocks.TaskUsecaseInterface), new(mocks.UserRepositoryInterface), new(mocks.EventPublisher))
+	userID := primitive.NewObjectID().Hex()
+
+	t.Run("normalizes the tags", func(t *testing.T) {
+		mockRepo.On("CreateAutomation", mock.AnythingOfType("*domain.Automation"), userID).Return(nil).Once()
+
+		automation := &domain.Automation{
+			Name:       " Archive ",
+			Trigger:    domain.EventTaskCompleted,
+			Conditions: domain.AutomationConditions{Tags: []string{"Release"}},
+			Actions: []domain.AutomationAction{
+				{Type: domain.ActionAddTag, Value: " Archived "},
+				{Type: domain.ActionCreateTask, Title: "Announce {{title}}", DueOffset: "+1d"},
+			},
+		}
+		err := automationUsecase.CreateAutomation(automation, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Archive", automation.Name)
+		assert.Equal(t, []string{"release"}, automation.Conditions.Tags)
+		assert.Equal(t, "archived", automation.Actions[0].Value)
+		assert.False(t, automation.CreatedAt.IsZero())
+	})
+
+	tests := []struct {
+		name       string
+		automation domain.Automation
+		err        string
+	}{
+		{"no name", domain.Automation{Trigger: domain.EventTaskCreated}, "incomplete information"},
+		{"unknown trigger", domain.Automation{Name: "x", Trigger: "task.moved"}, "unknown trigger task.moved"},
+		{"no actions", domain.Automation{Name: "x", Trigger: domain.EventTaskCreated}, "at least one action is required"},
+		{"unknown action", domain.Automation{Name: "x", Trigger: domain.EventTaskCreated, Actions: []domain.AutomationAction{{Type: "delete"}}}, "unknown action delete"},
+		{"no tag", domain.Automation{Name: "x", Trigger: domain.EventTaskCreated, Actions: []domain.AutomationAction{{Type: domain.ActionAddTag}}}, "add_tag needs a value"},
+		{"bad assignee", domain.Automation{Name: "x", Trigger: domain.EventTaskCreated, Actions: []domain.AutomationAction{{Type: domain.ActionAssign, Value: "bob"}}}, "assign needs a user ID"},
+		{"unknown variable", domain.Automation{Name: "x", Trigger: domain.EventTaskCreated, Actions: []domain.AutomationAction{{Type: domain.ActionCreateTask, Title: "{{owner}}"}}}, "missing variables: owner"},
+		{"bad priority", domain.Automation{Name: "x", Trigger: domain.EventTaskCreated, Actions: []domain.AutomationAction{{Type: domain.ActionSetPriority, Value: "asap"}}}, "priority must be low, medium, high or urgent"},
+		{"deleted task", domain.Automation{Name: "x", Trigger: domain.EventTaskDeleted, Actions: []domain.AutomationAction{{Type: domain.ActionSetStatus, Value: "done"}}}, "a deleted task can only be followed by create_task"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := automationUsecase.CreateAutomation(&test.automation, userID)
+
+			assert.EqualError(t, err, test.err)
+		})
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAutomationVisibility(t *testing.T) {
+	mockRepo := new(mocks.AutomationRepositoryInterface)
+	automationUsecase := usecases.NewAutomationUsecase(mockRepo, new(mocks.TaskUsecaseInterface), new(mocks.UserRepositoryInterface), new(mocks.EventPublisher))
+
+	ownerID := primitive.NewObjectID()
+	memberID := primitive.NewObjectID().Hex()
+	automationID := primitive.NewObjectID()
+
+	t.Run("personal automations are private", func(t *testing.T) {
+		mockRepo.On("GetAutomation", automationID.Hex()).Return(&domain.Automation{ID: automationID, UserID: ownerID}, nil).Once()
+
+		_, err := automationUsecase.GetRuns(automationID.Hex(), memberID)
+
+		assert.EqualError(t, err, "automation not found")
+	})
+
+	t.Run("workspace members read the log", func(t *testing.T) {
+		mockRepo.On("GetAutomation", automationID.Hex()).Return(&domain.Automation{ID: automationID, UserID: ownerID, WorkspaceID: primitive.NewObjectID()}, nil).Once()
+		mockRepo.On("GetRuns", automationID.Hex()).Return(&[]domain.AutomationRun{{Status: domain.RunSucceeded}}, nil).Once()
+
+		runs, err := automationUsecase.GetRuns(automationID.Hex(), memberID)
+
+		assert.NoError(t, err)
+		assert.Len(t, *runs, 1)
+	})
+
+	t.Run("but only the creator changes an automation", func(t *testing.T) {
+		mockRepo.On("GetAutomation", automationID.Hex()).Return(&domain.Automation{ID: automationID, UserID: ownerID, WorkspaceID: primitive.NewObjectID()}, nil).Once()
+
+		err := automationUsecase.RemoveAutomation(automationID.Hex(), memberID)
+
+		assert.EqualError(t, err, "only the creator can change an automation")
+	})
+	mockRepo.AssertExpectations(t)
+}
+
+// automationSetup is an automation usecase whose task usecase hands the
+// chain it publishes to over to the test.
+func automationSetup() (*usecases.AutomationUsecase, *mocks.AutomationRepositoryInterface, *mocks.TaskUsecaseInterface, *mocks.EventPublisher, *domain.EventPublisher) {
+
+	mockRepo := new(mocks.AutomationRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	chain := new(domain.EventPublisher)
+
+	mockTasks.On("InWorkspace", mock.Anything).Return(mockTasks, nil).Maybe()
+	mockTasks.On("WithEvents", mock.Anything).Run(func(args mock.Arguments) {
+		*chain = args.Get(0).(domain.EventPublisher)
+	}).Return(mockTasks).Maybe()
+	mockUsers.On("GetUserByID", mock.Anything).Return(&domain.User{}, nil).Maybe()
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+
+	return usecases.NewAutomationUsecase(mockRepo, mockTasks, mockUsers, mockEvents), mockRepo, mockTasks, mockEvents, chain
+}
+
+func TestAutomationActions(t *testing.T) {
+	automationUsecase, mockRepo, mockTasks, _, _ := automationSetup()
+
+	ownerID := primitive.NewObjectID()
+	task := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Title: "Release 1.2", Status: "done", Tags: []string{"release"}, ProjectID: primitive.NewObjectID()}
+	automation := domain.Automation{
+		ID:         primitive.NewObjectID(),
+		UserID:     ownerID,
+		Trigger:    domain.EventTaskCompleted,
+		Conditions: domain.AutomationConditions{Tags: []string{"release"}},
+		Actions: []domain.AutomationAction{
+			{Type: domain.ActionAddTag, Value: "archived"},
+			{Type: domain.ActionSetPriority, Value: "low"},
+			{Type: domain.ActionCreateTask, Title: "Announce {{title}}", DueOffset: "+1d"},
+		},
+	}
+	skipped := domain.Automation{ID: primitive.NewObjectID(), UserID: ownerID, Trigger: domain.EventTaskCompleted, Conditions: domain.AutomationConditions{Statuses: []string{"pending"}}}
+
+	mockRepo.On("GetTriggered", domain.EventTaskCompleted, task).Return(&[]domain.Automation{automation, skipped}, nil).Once()
+	mockTasks.On("GetTask", task.ID.Hex(), ownerID.Hex()).Return(&domain.Task{ID: task.ID, Title: task.Title, Status: "done", Tags: []string{"release"}}, nil).Once()
+	mockTasks.On("UpdateTask", task.ID.Hex(), mock.MatchedBy(func(updated *domain.Task) bool {
+		return assert.ObjectsAreEqual([]string{"release", "archived"}, updated.Tags) && updated.Priority == domain.PriorityLow
+	}), ownerID.Hex()).Return(nil).Once()
+	mockTasks.On("CreateTask", mock.MatchedBy(func(followup *domain.Task) bool {
+		return followup.Title == "Announce Release 1.2" && followup.Description == "Follow-up of Release 1.2" &&
+			followup.ProjectID == task.ProjectID && followup.DueDateOnly
+	}), ownerID.Hex()).Return(nil).Once()
+	mockRepo.On("LogRun", mock.MatchedBy(func(run *domain.AutomationRun) bool {
+		return run.AutomationID == automation.ID && run.Status == domain.RunSucceeded && run.Depth == 0
+	})).Return(nil).Once()
+
+	automationUsecase.Publish(domain.EventTaskCompleted, task)
+
+	mockRepo.AssertExpectations(t)
+	mockTasks.AssertExpectations(t)
+}
+
+func TestAutomationLoopProtection(t *testing.T) {
+
+	t.Run("an automation runs once on a task in a chain", func(t *testing.T) {
+		automationUsecase, mockRepo, mockTasks, mockEvents, chain := automationSetup()
+
+		ownerID := primitive.NewObjectID()
+		task := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Status: "pending"}
+		automation := domain.Automation{ID: primitive.NewObjectID(), UserID: ownerID, Trigger: domain.EventTaskUpdated,
+			Actions: []domain.AutomationAction{{Type: domain.ActionSetStatus, Value: "review"}}}
+
+		mockRepo.On("GetTriggered", domain.EventTaskUpdated, mock.Anything).Return(&[]domain.Automation{automation}, nil)
+		mockTasks.On("GetTask", task.ID.Hex(), ownerID.Hex()).Return(&domain.Task{ID: task.ID, Status: "pending"}, nil).Once()
+		mockTasks.On("UpdateTask", task.ID.Hex(), mock.Anything, ownerID.Hex()).Run(func(args mock.Arguments) {
+			(*chain).Publish(domain.EventTaskUpdated, args.Get(1).(*domain.Task))
+		}).Return(nil).Once()
+		mockRepo.On("LogRun", mock.MatchedBy(func(run *domain.AutomationRun) bool {
+			return run.Status == domain.RunSkipped && run.Depth == 1 && run.Error == "loop protection: already ran on this task in the chain"
+		})).Return(nil).Once()
+		mockRepo.On("LogRun", mock.MatchedBy(func(run *domain.AutomationRun) bool {
+			return run.Status == domain.RunSucceeded && run.Depth == 0
+		})).Return(nil).Once()
+
+		automationUsecase.Publish(domain.EventTaskUpdated, task)
+
+		mockRepo.AssertExpectations(t)
+		mockTasks.AssertExpectations(t)
+		mockEvents.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("chains stop after a few steps", func(t *testing.T) {
+		automationUsecase, mockRepo, mockTasks, _, chain := automationSetup()
+
+		ownerID := primitive.NewObjectID()
+		automation := domain.Automation{ID: primitive.NewObjectID(), UserID: ownerID, Trigger: domain.EventTaskCreated,
+			Actions: []domain.AutomationAction{{Type: domain.ActionCreateTask, Title: "Follow up {{title}}"}}}
+
+		mockRepo.On("GetTriggered", domain.EventTaskCreated, mock.Anything).Return(&[]domain.Automation{automation}, nil)
+		mockTasks.On("CreateTask", mock.Anything, ownerID.Hex()).Run(func(args mock.Arguments) {
+			followup := args.Get(0).(*domain.Task)
+			followup.ID = primitive.NewObjectID()
+			(*chain).Publish(domain.EventTaskCreated, followup)
+		}).Return(nil).Times(5)
+		mockRepo.On("LogRun", mock.MatchedBy(func(run *domain.AutomationRun) bool {
+			return run.Status == domain.RunSkipped && run.Depth == 5
+		})).Return(nil).Once()
+		mockRepo.On("LogRun", mock.MatchedBy(func(run *domain.AutomationRun) bool {
+			return run.Status == domain.RunSucceeded
+		})).Return(nil).Times(5)
+
+		automationUsecase.Publish(domain.EventTaskCreated, &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Title: "Plan"})
+
+		mockRepo.AssertExpectations(t)
+		mockTasks.AssertExpectations(t)
+	})
+}
+
+func TestAutomationFailure(t *testing.T) {
+	automationUsecase, mockRepo, mockTasks, _, _ := automationSetup()
+
+	ownerID := primitive.NewObjectID()
+	task := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID}
+	assignee := primitive.NewObjectID().Hex()
+	automation := domain.Automation{ID: primitive.NewObjectID(), UserID: ownerID, Trigger: domain.EventTaskOverdue,
+		Actions: []domain.AutomationAction{{Type: domain.ActionAssign, Value: assignee}}}
+
+	mockRepo.On("GetTriggered", domain.EventTaskOverdue, task).Return(&[]domain.Automation{automation}, nil).Once()
+	mockTasks.On("AssignTask", task.ID.Hex(), assignee, ownerID.Hex()).Return(nil, assert.AnError).Once()
+	mockRepo.On("LogRun", mock.MatchedBy(func(run *domain.AutomationRun) bool {
+		return run.Status == domain.RunFailed && run.Error == assert.AnError.Error() && run.Event == domain.EventTaskOverdue
+	})).Return(nil).Once()
+
+	automationUsecase.Publish(domain.EventTaskOverdue, task)
+
+	mockRepo.AssertExpectations(t)
+	mockTasks.AssertExpectations(t)
+}
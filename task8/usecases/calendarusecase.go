@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"task8/domain"
+	"task8/infrastructure"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CalendarUsecase struct {
+	repository domain.CalendarRepositoryInterface
+	tasks      domain.TaskRepositoryInterface
+	users      domain.UserRepositoryInterface
+	workspaces domain.WorkspaceRepositoryInterface
+}
+
+func NewCalendarUsecase(repository domain.CalendarRepositoryInterface, tasks domain.TaskRepositoryInterface, users domain.UserRepositoryInterface, workspaces domain.WorkspaceRepositoryInterface) *CalendarUsecase {
+	return &CalendarUsecase{repository: repository, tasks: tasks, users: users, workspaces: workspaces}
+}
+
+func (cu *CalendarUsecase) GetFeed(userID string) (*domain.CalendarFeed, error) {
+
+	feed, err := cu.repository.GetFeed(userID)
+	if err != nil {
+		return nil, err
+	}
+	if feed == nil {
+		return nil, errors.New("no calendar feed yet, generate a token first")
+	}
+	return feed, nil
+}
+
+// RegenerateToken gives the user a new feed token, which is only returned
+// here. Any URL holding the previous token stops working.
+func (cu *CalendarUsecase) RegenerateToken(userID string) (*domain.CalendarFeed, error) {
+
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(secret)
+
+	feed, err := cu.repository.SaveToken(userID, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	feed.Token = token
+	return feed, nil
+}
+
+func (cu *CalendarUsecase) SetTimezone(userID string, timezone string) (*domain.CalendarFeed, error) {
+
+	location, err := loadTimezone(timezone)
+	if err != nil {
+		return nil, err
+	}
+	feed, err := cu.repository.SetTimezone(userID, location.String())
+	if err != nil {
+		return nil, err
+	}
+	if feed == nil {
+		return nil, errors.New("no calendar feed yet, generate a token first")
+	}
+	return feed, nil
+}
+
+// RenderFeed returns the iCalendar document a token opens: the due tasks the
+// feed's user created or is assigned, narrowed by filter, in the feed's time
+// zone or else the user's. Tasks of workspaces the user has since left are not shown.
+func (cu *CalendarUsecase) RenderFeed(token string, filter domain.CalendarFilter) (string, error) {
+
+	feed, err := cu.repository.GetFeedByToken(hashToken(token))
+	if err != nil {
+		return "", err
+	}
+	if feed == nil {
+		return "", errors.New("calendar feed not found")
+	}
+	userID := feed.UserID.Hex()
+	location := userLocation(cu.users, userID)
+	if feed.Timezone != "" {
+		if zone, err := time.LoadLocation(feed.Timezone); err == nil {
+			location = zone
+		}
+	}
+	created, err := cu.tasks.GetTasks(userID)
+	if err != nil {
+		return "", err
+	}
+	assigned, err := cu.tasks.GetAssignedTasks(userID)
+	if err != nil {
+		return "", err
+	}
+	workspaces, err := cu.workspaces.GetWorkspaces(userID)
+	if err != nil {
+		return "", err
+	}
+	member := map[primitive.ObjectID]bool{primitive.NilObjectID: true}
+	for _, workspace := range *workspaces {
+		member[workspace.ID] = true
+	}
+
+	tags := normalizeTags(filter.Tags)
+	calendar := infrastructure.NewCalendar("Tasks", location, time.Now())
+	seen := map[primitive.ObjectID]bool{}
+	for _, task := range append(*created, *assigned...) {
+		if seen[task.ID] || !member[task.WorkspaceID] || task.DueDate.IsZero() || !calendarMatch(&task, tags, filter.Statuses) {
+			continue
+		}
+		seen[task.ID] = true
+		if filter.As == domain.CalendarTodo {
+			calendar.AddTodo(&task)
+		} else {
+			calendar.AddEvent(&task)
+		}
+	}
+	return calendar.String(), nil
+}
+
+func calendarMatch(task *domain.Task, tags []string, statuses []string) bool {
+
+	if !hasTags(task.Tags, tags, false) {
+		return false
+	}
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, status := range statuses {
+		if strings.EqualFold(task.Status, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// hashToken is what a feed token is stored as, so that a leaked database
+// does not leak working feed URLs.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
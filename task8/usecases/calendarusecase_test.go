@@ -0,0 +1,114 @@
+package usecases_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCalendarUsecase(t *testing.T) {
+	mockRepo := new(mocks.CalendarRepositoryInterface)
+	mockTasks := new(mocks.TaskRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockWorkspaces := new(mocks.WorkspaceRepositoryInterface)
+	calendarUsecase := usecases.NewCalendarUsecase(mockRepo, mockTasks, mockUsers, mockWorkspaces)
+	userID := primitive.NewObjectID()
+
+	t.Run("regenerate stores only a hash", func(t *testing.T) {
+		var stored string
+		mockRepo.On("SaveToken", userID.Hex(), mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+			stored = args.String(1)
+		}).Return(&domain.CalendarFeed{UserID: userID}, nil).Once()
+
+		feed, err := calendarUsecase.RegenerateToken(userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, feed.Token, 64)
+		sum := sha256.Sum256([]byte(feed.Token))
+		assert.Equal(t, hex.EncodeToString(sum[:]), stored)
+	})
+
+	t.Run("rejects an unknown timezone", func(t *testing.T) {
+		_, err := calendarUsecase.SetTimezone(userID.Hex(), "Mars/Olympus")
+		assert.EqualError(t, err, "timezone must be an IANA time zone such as Europe/Berlin")
+
+		_, err = calendarUsecase.SetTimezone(userID.Hex(), "Local")
+		assert.Error(t, err)
+	})
+
+	t.Run("feed renders due tasks once", func(t *testing.T) {
+		token := "secret"
+		sum := sha256.Sum256([]byte(token))
+		mockRepo.On("GetFeedByToken", hex.EncodeToString(sum[:])).Return(&domain.CalendarFeed{UserID: userID}, nil).Once()
+		mockUsers.On("GetUserByID", userID.Hex()).Return(&domain.User{Timezone: "America/New_York"}, nil).Once()
+		shared := domain.Task{ID: primitive.NewObjectID(), Title: "Shared", Status: "todo", Tags: []string{"work"}, DueDate: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), DueDateOnly: true}
+		undated := domain.Task{ID: primitive.NewObjectID(), Title: "Someday", Status: "todo", Tags: []string{"work"}}
+		home := domain.Task{ID: primitive.NewObjectID(), Title: "Home", Status: "todo", Tags: []string{"home"}, DueDate: time.Now()}
+		done := domain.Task{ID: primitive.NewObjectID(), Title: "Done", Status: "done", Tags: []string{"work"}, DueDate: time.Now()}
+		mockTasks.On("GetTasks", userID.Hex()).Return(&[]domain.Task{shared, undated, home, done}, nil).Once()
+		mockTasks.On("GetAssignedTasks", userID.Hex()).Return(&[]domain.Task{shared}, nil).Once()
+		mockWorkspaces.On("GetWorkspaces", userID.Hex()).Return(&[]domain.Workspace{}, nil).Once()
+
+		calendar, err := calendarUsecase.RenderFeed(token, domain.CalendarFilter{Tags: []string{"Work"}, Statuses: []string{"todo"}, As: domain.CalendarTodo})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(calendar, "BEGIN:VTODO"))
+		assert.Contains(t, calendar, "SUMMARY:Shared")
+		assert.Contains(t, calendar, "DUE;VALUE=DATE:20240310")
+	})
+
+	t.Run("feed leaves out workspaces the user left", func(t *testing.T) {
+		token := "member"
+		sum := sha256.Sum256([]byte(token))
+		mockRepo.On("GetFeedByToken", hex.EncodeToString(sum[:])).Return(&domain.CalendarFeed{UserID: userID}, nil).Once()
+		mockUsers.On("GetUserByID", userID.Hex()).Return(&domain.User{}, nil).Once()
+		current := domain.Workspace{ID: primitive.NewObjectID()}
+		personal := domain.Task{ID: primitive.NewObjectID(), Title: "Personal", Status: "todo", DueDate: time.Now()}
+		team := domain.Task{ID: primitive.NewObjectID(), Title: "Team", Status: "todo", WorkspaceID: current.ID, DueDate: time.Now()}
+		left := domain.Task{ID: primitive.NewObjectID(), Title: "Former", Status: "todo", WorkspaceID: primitive.NewObjectID(), DueDate: time.Now()}
+		mockTasks.On("GetTasks", userID.Hex()).Return(&[]domain.Task{personal, team, left}, nil).Once()
+		mockTasks.On("GetAssignedTasks", userID.Hex()).Return(&[]domain.Task{}, nil).Once()
+		mockWorkspaces.On("GetWorkspaces", userID.Hex()).Return(&[]domain.Workspace{current}, nil).Once()
+
+		calendar, err := calendarUsecase.RenderFeed(token, domain.CalendarFilter{})
+
+		assert.NoError(t, err)
+		assert.Contains(t, calendar, "SUMMARY:Personal")
+		assert.Contains(t, calendar, "SUMMARY:Team")
+		assert.NotContains(t, calendar, "SUMMARY:Former")
+	})
+
+	t.Run("feed zone overrides the user's", func(t *testing.T) {
+		token := "zoned"
+		sum := sha256.Sum256([]byte(token))
+		mockRepo.On("GetFeedByToken", hex.EncodeToString(sum[:])).Return(&domain.CalendarFeed{UserID: userID, Timezone: "Asia/Tokyo"}, nil).Once()
+		mockUsers.On("GetUserByID", userID.Hex()).Return(&domain.User{Timezone: "America/New_York"}, nil).Once()
+		due := domain.Task{ID: primitive.NewObjectID(), Title: "Late", Status: "todo", DueDate: time.Date(2024, 3, 10, 20, 0, 0, 0, time.UTC)}
+		mockTasks.On("GetTasks", userID.Hex()).Return(&[]domain.Task{due}, nil).Once()
+		mockTasks.On("GetAssignedTasks", userID.Hex()).Return(&[]domain.Task{}, nil).Once()
+		mockWorkspaces.On("GetWorkspaces", userID.Hex()).Return(&[]domain.Workspace{}, nil).Once()
+
+		calendar, err := calendarUsecase.RenderFeed(token, domain.CalendarFilter{})
+
+		assert.NoError(t, err)
+		assert.Contains(t, calendar, "Asia/Tokyo")
+		assert.NotContains(t, calendar, "America/New_York")
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mockRepo.On("GetFeedByToken", mock.Anything).Return(nil, nil).Once()
+
+		_, err := calendarUsecase.RenderFeed("guess", domain.CalendarFilter{})
+
+		assert.EqualError(t, err, "calendar feed not found")
+	})
+}
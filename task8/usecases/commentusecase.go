@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// mentionPattern matches "@" followed by an email address, e.g. "@ann@example.com".
+var mentionPattern = regexp.MustCompile(`@([A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+
+type CommentUsecase struct {
+	repository domain.CommentRepositoryInterface
+	tasks      domain.TaskUsecaseInterface
+	users      domain.UserRepositoryInterface
+}
+
+func NewCommentUsecase(repository domain.CommentRepositoryInterface, tasks domain.TaskUsecaseInterface, users domain.UserRepositoryInterface) *CommentUsecase {
+	return &CommentUsecase{repository: repository, tasks: tasks, users: users}
+}
+
+// InWorkspace is the usecase limited to the tasks of one workspace.
+func (cu *CommentUsecase) InWorkspace(workspaceID string) (domain.CommentUsecaseInterface, error) {
+
+	tasks, err := cu.tasks.InWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	scoped := *cu
+	scoped.tasks = tasks
+	return &scoped, nil
+}
+
+func (cu *CommentUsecase) CreateComment(taskid string, newcomment *domain.Comment, userid string) error {
+
+	task, err := cu.tasks.GetTask(taskid, userid)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(newcomment.Body) == "" {
+		return errors.New("incomplete information")
+	}
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	if !newcomment.ParentID.IsZero() {
+		parent, err := cu.repository.GetComment(newcomment.ParentID.Hex())
+		if err != nil || parent.TaskID != task.ID {
+			return errors.New("parent comment not found")
+		}
+	}
+
+	newcomment.TaskID = task.ID
+	newcomment.UserID = uid
+	newcomment.Mentions = cu.mentions(newcomment.Body)
+	newcomment.CreatedAt = time.Now()
+	newcomment.UpdatedAt = time.Time{}
+	return cu.repository.CreateComment(newcomment)
+}
+
+// GetComments returns the task's discussion as threads: top level comments
+// in creation order, each carrying its replies.
+func (cu *CommentUsecase) GetComments(taskid string, userid string) (*[]domain.Comment, error) {
+
+	if _, err := cu.tasks.GetTask(taskid, userid); err != nil {
+		return nil, err
+	}
+	comments, err := cu.repository.GetComments(taskid)
+	if err != nil {
+		return nil, err
+	}
+	threads := buildThreads(*comments, primitive.NilObjectID)
+	return &threads, nil
+}
+
+func (cu *CommentUsecase) UpdateComment(taskid string, id string, body string, userid string) (*domain.Comment, error) {
+
+	if _, err := cu.tasks.GetTask(taskid, userid); err != nil {
+		return nil, err
+	}
+	comment, err := cu.comment(taskid, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID.Hex() != userid {
+		return nil, errors.New("only the author can edit a comment")
+	}
+	if strings.TrimSpace(body) == "" {
+		return nil, errors.New("incomplete information")
+	}
+
+	comment.Body = body
+	comment.Mentions = cu.mentions(body)
+	comment.UpdatedAt = time.Now()
+	if err := cu.repository.UpdateComment(id, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (cu *CommentUsecase) RemoveComment(taskid string, id string, userid string) error {
+
+	if _, err := cu.tasks.GetTask(taskid, userid); err != nil {
+		return err
+	}
+	comment, err := cu.comment(taskid, id)
+	if err != nil {
+		return err
+	}
+	if comment.UserID.Hex() != userid {
+		return errors.New("only the author can delete a comment")
+	}
+	return cu.remove(comment)
+}
+
+func (cu *CommentUsecase) AdminRemoveComment(taskid string, id string) error {
+
+	comment, err := cu.comment(taskid, id)
+	if err != nil {
+		return err
+	}
+	return cu.remove(comment)
+}
+
+// comment loads a comment on the given task; comments on other tasks are
+// not found.
+func (cu *CommentUsecase) comment(taskid string, id string) (*domain.Comment, error) {
+
+	comment, err := cu.repository.GetComment(id)
+	if err != nil || comment.TaskID.Hex() != taskid {
+		return nil, errors.New("comment not found")
+	}
+	return comment, nil
+}
+
+// remove deletes a comment together with every reply below it, so no
+// thread is left hanging off a missing parent.
+func (cu *CommentUsecase) remove(comment *domain.Comment) error {
+
+	comments, err := cu.repository.GetComments(comment.TaskID.Hex())
+	if err != nil {
+		return err
+	}
+
+	ids := []string{comment.ID.Hex()}
+	for i := 0; i < len(ids); i++ {
+		for _, c := range *comments {
+			if c.ParentID.Hex() == ids[i] {
+				ids = append(ids, c.ID.Hex())
+			}
+		}
+	}
+	return cu.repository.RemoveComments(comment.TaskID.Hex(), ids)
+}
+
+// mentions resolves the @email mentions in a comment body to user ids,
+// ignoring addresses that do not belong to a user.
+func (cu *CommentUsecase) mentions(body string) []primitive.ObjectID {
+
+	mentioned := []primitive.ObjectID{}
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(match[1])
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		if user, err := cu.users.GetUser(email); err == nil {
+			mentioned = append(mentioned, user.ID)
+		}
+	}
+	return mentioned
+}
+
+func buildThreads(comments []domain.Comment, parent primitive.ObjectID) []domain.Comment {
+	threads := []domain.Comment{}
+	for _, comment := range comments {
+		if comment.ParentID == parent {
+			comment.Replies = buildThreads(comments, comment.ID)
+			threads = append(threads, comment)
+		}
+	}
+	return threads
+}
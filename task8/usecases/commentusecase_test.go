@@ -0,0 +1,176 @@
+package usecases_test
+
+import (
+	"errors"
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateComment(t *testing.T) {
+	mockRepo := new(mocks.CommentRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	commentUsecase := usecases.NewCommentUsecase(mockRepo, mockTasks, mockUsers)
+
+	userID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	annID := primitive.NewObjectID()
+
+	mockTasks.On("GetTask", taskID.Hex(), userID.Hex()).Return(&domain.Task{ID: taskID}, nil)
+
+	t.Run("resolves mentions", func(t *testing.T) {
+		mockUsers.On("GetUser", "ann@example.com").Return(&domain.User{ID: annID}, nil).Once()
+		mockUsers.On("GetUser", "nobody@example.com").Return(nil, errors.New("mongo: no documents in result")).Once()
+		mockRepo.On("CreateComment", mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+
+		comment := &domain.Comment{Body: "**Ping** @ann@example.com and @nobody@example.com, see @Ann@example.com"}
+		err := commentUsecase.CreateComment(taskID.Hex(), comment, userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{annID}, comment.Mentions)
+		assert.Equal(t, taskID, comment.TaskID)
+		assert.Equal(t, userID, comment.UserID)
+	})
+
+	t.Run("rejects a parent from another task", func(t *testing.T) {
+		parentID := primitive.NewObjectID()
+		mockRepo.On("GetComment", parentID.Hex()).Return(&domain.Comment{ID: parentID, TaskID: primitive.NewObjectID()}, nil).Once()
+
+		err := commentUsecase.CreateComment(taskID.Hex(), &domain.Comment{Body: "reply", ParentID: parentID}, userID.Hex())
+
+		assert.EqualError(t, err, "parent comment not found")
+	})
+
+	t.Run("rejects an empty body", func(t *testing.T) {
+		err := commentUsecase.CreateComment(taskID.Hex(), &domain.Comment{Body: "  "}, userID.Hex())
+
+		assert.EqualError(t, err, "incomplete information")
+	})
+}
+
+func TestGetCommentsThreads(t *testing.T) {
+	mockRepo := new(mocks.CommentRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	commentUsecase := usecases.NewCommentUsecase(mockRepo, mockTasks, new(mocks.UserRepositoryInterface))
+
+	taskID := primitive.NewObjectID()
+	rootID := primitive.NewObjectID()
+	replyID := primitive.NewObjectID()
+
+	mockTasks.On("GetTask", taskID.Hex(), "userID").Return(&domain.Task{ID: taskID}, nil)
+	mockRepo.On("GetComments", taskID.Hex()).Return(&[]domain.Comment{
+		{ID: rootID, Body: "root"},
+		{ID: replyID, ParentID: rootID, Body: "reply"},
+		{ID: primitive.NewObjectID(), ParentID: replyID, Body: "nested"},
+	}, nil)
+
+	comments, err := commentUsecase.GetComments(taskID.Hex(), "userID")
+
+	assert.NoError(t, err)
+	assert.Len(t, *comments, 1)
+	assert.Equal(t, "reply", (*comments)[0].Replies[0].Body)
+	assert.Equal(t, "nested", (*comments)[0].Replies[0].Replies[0].Body)
+}
+
+func TestRemoveComment(t *testing.T) {
+	mockRepo := new(mocks.CommentRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	commentUsecase := usecases.NewCommentUsecase(mockRepo, mockTasks, new(mocks.UserRepositoryInterface))
+
+	authorID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	rootID := primitive.NewObjectID()
+	replyID := primitive.NewObjectID()
+	root := &domain.Comment{ID: rootID, TaskID: taskID, UserID: authorID}
+
+	mockRepo.On("GetComment", rootID.Hex()).Return(root, nil)
+	mockRepo.On("GetComments", taskID.Hex()).Return(&[]domain.Comment{*root, {ID: replyID, ParentID: rootID}}, nil)
+	mockTasks.On("GetTask", taskID.Hex(), mock.Anything).Return(&domain.Task{ID: taskID}, nil)
+
+	t.Run("only the author can delete", func(t *testing.T) {
+		err := commentUsecase.RemoveComment(taskID.Hex(), rootID.Hex(), primitive.NewObjectID().Hex())
+
+		assert.EqualError(t, err, "only the author can delete a comment")
+	})
+
+	t.Run("removes replies with the comment", func(t *testing.T) {
+		mockRepo.On("RemoveComments", taskID.Hex(), []string{rootID.Hex(), replyID.Hex()}).Return(nil).Once()
+
+		err := commentUsecase.RemoveComment(taskID.Hex(), rootID.Hex(), authorID.Hex())
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("the comment must be on the task", func(t *testing.T) {
+		otherID := primitive.NewObjectID()
+		mockTasks.On("GetTask", otherID.Hex(), authorID.Hex()).Return(&domain.Task{ID: otherID}, nil).Once()
+
+		err := commentUsecase.RemoveComment(otherID.Hex(), rootID.Hex(), authorID.Hex())
+		assert.EqualError(t, err, "comment not found")
+
+		err = commentUsecase.AdminRemoveComment(otherID.Hex(), rootID.Hex())
+		assert.EqualError(t, err, "comment not found")
+	})
+}
+
+func TestUpdateComment(t *testing.T) {
+	mockRepo := new(mocks.CommentRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	commentUsecase := usecases.NewCommentUsecase(mockRepo, mockTasks, mockUsers)
+
+	authorID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID()
+	mockRepo.On("GetComment", commentID.Hex()).Return(&domain.Comment{ID: commentID, TaskID: taskID, UserID: authorID}, nil)
+
+	t.Run("edits the body", func(t *testing.T) {
+		mockTasks.On("GetTask", taskID.Hex(), authorID.Hex()).Return(&domain.Task{ID: taskID}, nil).Once()
+		mockRepo.On("UpdateComment", commentID.Hex(), mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+
+		comment, err := commentUsecase.UpdateComment(taskID.Hex(), commentID.Hex(), "edited", authorID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "edited", comment.Body)
+	})
+
+	t.Run("the author must still see the task", func(t *testing.T) {
+		mockTasks.On("GetTask", taskID.Hex(), authorID.Hex()).Return(nil, errors.New("insufficient project permissions")).Once()
+
+		_, err := commentUsecase.UpdateComment(taskID.Hex(), commentID.Hex(), "edited", authorID.Hex())
+
+		assert.EqualError(t, err, "insufficient project permissions")
+	})
+	mockRepo.AssertExpectations(t)
+	mockTasks.AssertExpectations(t)
+}
+
+// A scoped usecase only reaches the tasks of its workspace.
+func TestCommentUsecaseInWorkspace(t *testing.T) {
+	mockRepo := new(mocks.CommentRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	scopedTasks := new(mocks.TaskUsecaseInterface)
+	commentUsecase := usecases.NewCommentUsecase(mockRepo, mockTasks, new(mocks.UserRepositoryInterface))
+
+	workspaceID := primitive.NewObjectID().Hex()
+	taskID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+	mockTasks.On("InWorkspace", workspaceID).Return(scopedTasks, nil).Once()
+	scopedTasks.On("GetTask", taskID, userID).Return(nil, errors.New("task not found")).Once()
+
+	scoped, err := commentUsecase.InWorkspace(workspaceID)
+	assert.NoError(t, err)
+	_, err = scoped.GetComments(taskID, userID)
+
+	assert.EqualError(t, err, "task not found")
+	mockTasks.AssertExpectations(t)
+	scopedTasks.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "GetComments", mock.Anything)
+}
@@ -0,0 +1,173 @@
+package usecases
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var fieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+
+type CustomFieldUsecase struct {
+	repository  domain.CustomFieldRepositoryInterface
+	workspaces  domain.WorkspaceRepositoryInterface
+	workspaceID string
+}
+
+func NewCustomFieldUsecase(repository domain.CustomFieldRepositoryInterface, workspaces domain.WorkspaceRepositoryInterface) *CustomFieldUsecase {
+	return &CustomFieldUsecase{repository: repository, workspaces: workspaces}
+}
+
+// InWorkspace is the usecase limited to the fields of one workspace.
+func (cu *CustomFieldUsecase) InWorkspace(workspaceID string) (domain.CustomFieldUsecaseInterface, error) {
+
+	wid, err := workspaceObjectID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	scoped := *cu
+	scoped.repository = cu.repository.InWorkspace(wid)
+	scoped.workspaceID = workspaceID
+	return &scoped, nil
+}
+
+func (cu *CustomFieldUsecase) CreateField(newfield *domain.CustomField, userid string) error {
+
+	if err := cu.authorize(userid); err != nil {
+		return err
+	}
+	newfield.Key = strings.TrimSpace(newfield.Key)
+	if newfield.Key == "" || newfield.Type == "" {
+		return errors.New("incomplete information")
+	}
+	if err := validateField(newfield); err != nil {
+		return err
+	}
+	if !fieldKeyPattern.MatchString(newfield.Key) {
+		return errors.New("key must be lowercase letters, digits and underscores, starting with a letter")
+	}
+	if !slices.Contains(domain.CustomFieldTypes, newfield.Type) {
+		return errors.New("type must be text, number, date, enum or user")
+	}
+	fields, err := cu.repository.GetFields(userid)
+	if err != nil {
+		return err
+	}
+	for _, field := range *fields {
+		if field.Key == newfield.Key {
+			return errors.New("a field with this key already exists")
+		}
+	}
+	if err := checkFieldOptions(newfield); err != nil {
+		return err
+	}
+	newfield.ID = primitive.NilObjectID
+	newfield.CreatedAt = time.Now()
+	return cu.repository.CreateField(newfield, userid)
+}
+
+func (cu *CustomFieldUsecase) GetFields(userid string) (*[]domain.CustomField, error) {
+	return cu.repository.GetFields(userid)
+}
+
+// UpdateField renames a field or changes its options. Its key and type
+// cannot change; tasks holding an option that is dropped lose the value.
+func (cu *CustomFieldUsecase) UpdateField(id string, updatedfield *domain.CustomField, userid string) error {
+
+	if err := cu.authorize(userid); err != nil {
+		return err
+	}
+	field, err := cu.visibleField(id, userid)
+	if err != nil {
+		return err
+	}
+	if (updatedfield.Key != "" && updatedfield.Key != field.Key) || (updatedfield.Type != "" && updatedfield.Type != field.Type) {
+		return errors.New("the key and type of a field cannot be changed")
+	}
+	updatedfield.Type = field.Type
+	if err := validateField(updatedfield); err != nil {
+		return err
+	}
+	if err := checkFieldOptions(updatedfield); err != nil {
+		return err
+	}
+	return cu.repository.UpdateField(id, updatedfield)
+}
+
+// RemoveField deletes a field along with its values on tasks.
+func (cu *CustomFieldUsecase) RemoveField(id string, userid string) error {
+
+	if err := cu.authorize(userid); err != nil {
+		return err
+	}
+	if _, err := cu.visibleField(id, userid); err != nil {
+		return err
+	}
+	return cu.repository.RemoveField(id)
+}
+
+// authorize lets the owners and admins of the workspace change its fields.
+// Anyone defines the fields of their personal workspace.
+func (cu *CustomFieldUsecase) authorize(userid string) error {
+
+	if cu.workspaceID == "" {
+		return nil
+	}
+	workspace, err := cu.workspaces.GetWorkspace(cu.workspaceID)
+	if err != nil || workspaceRole(workspace, userid) == "" {
+		return errors.New("workspace not found")
+	}
+	if workspaceRoleRank[workspaceRole(workspace, userid)] < workspaceRoleRank[domain.WorkspaceAdmin] {
+		return errors.New("only workspace admins can change custom fields")
+	}
+	return nil
+}
+
+// visibleField finds a field of the workspace, or a personal one of the
+// user.
+func (cu *CustomFieldUsecase) visibleField(id string, userid string) (*domain.CustomField, error) {
+
+	field, err := cu.repository.GetField(id)
+	if err != nil {
+		return nil, err
+	}
+	if field.WorkspaceID.IsZero() && field.UserID.Hex() != userid {
+		return nil, errors.New("field not found")
+	}
+	return field, nil
+}
+
+func validateField(field *domain.CustomField) error {
+
+	field.Name = strings.TrimSpace(field.Name)
+	if field.Name == "" {
+		return errors.New("incomplete information")
+	}
+	return nil
+}
+
+// checkFieldOptions trims the options of an enum, which needs at least one.
+// Other types have none.
+func checkFieldOptions(field *domain.CustomField) error {
+
+	if field.Type != domain.FieldEnum {
+		field.Options = nil
+		return nil
+	}
+	options := []string{}
+	for _, option := range field.Options {
+		if option = strings.TrimSpace(option); option != "" && !slices.Contains(options, option) {
+			options = append(options, option)
+		}
+	}
+	if len(options) == 0 {
+		return errors.New("an enum field needs options")
+	}
+	field.Options = options
+	return nil
+}
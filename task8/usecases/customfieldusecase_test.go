@@ -0,0 +1,124 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateField(t *testing.T) {
+	mockRepo := new(mocks.CustomFieldRepositoryInterface)
+	fieldUsecase := usecases.NewCustomFieldUsecase(mockRepo, new(mocks.WorkspaceRepositoryInterface))
+	userID := primitive.NewObjectID().Hex()
+
+	t.Run("an enum with its options", func(t *testing.T) {
+		mockRepo.On("GetFields", userID).Return(&[]domain.CustomField{{Key: "estimate"}}, nil).Once()
+		mockRepo.On("CreateField", mock.AnythingOfType("*domain.CustomField"), userID).Return(nil).Once()
+
+		field := &domain.CustomField{Key: "sprint", Name: " Sprint ", Type: domain.FieldEnum, Options: []string{" 24", "25", "25", ""}}
+		err := fieldUsecase.CreateField(field, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Sprint", field.Name)
+		assert.Equal(t, []string{"24", "25"}, field.Options)
+		assert.False(t, field.CreatedAt.IsZero())
+	})
+
+	t.Run("keys are unique", func(t *testing.T) {
+		mockRepo.On("GetFields", userID).Return(&[]domain.CustomField{{Key: "estimate"}}, nil).Once()
+
+		err := fieldUsecase.CreateField(&domain.CustomField{Key: "estimate", Name: "Estimate", Type: domain.FieldNumber}, userID)
+
+		assert.EqualError(t, err, "a field with this key already exists")
+	})
+
+	tests := []struct {
+		name  string
+		field domain.CustomField
+		err   string
+	}{
+		{"no name", domain.CustomField{Key: "customer", Type: domain.FieldText}, "incomplete information"},
+		{"bad key", domain.CustomField{Key: "Customer Name", Name: "Customer", Type: domain.FieldText}, "key must be lowercase letters, digits and underscores, starting with a letter"},
+		{"unknown type", domain.CustomField{Key: "customer", Name: "Customer", Type: "json"}, "type must be text, number, date, enum or user"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := fieldUsecase.CreateField(&test.field, userID)
+
+			assert.EqualError(t, err, test.err)
+		})
+	}
+
+	t.Run("an enum needs options", func(t *testing.T) {
+		mockRepo.On("GetFields", userID).Return(&[]domain.CustomField{}, nil).Once()
+
+		err := fieldUsecase.CreateField(&domain.CustomField{Key: "sprint", Name: "Sprint", Type: domain.FieldEnum}, userID)
+
+		assert.EqualError(t, err, "an enum field needs options")
+	})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCustomFieldAdmins(t *testing.T) {
+	mockRepo := new(mocks.CustomFieldRepositoryInterface)
+	mockWorkspaces := new(mocks.WorkspaceRepositoryInterface)
+	fieldUsecase := usecases.NewCustomFieldUsecase(mockRepo, mockWorkspaces)
+
+	adminID := primitive.NewObjectID()
+	memberID := primitive.NewObjectID()
+	workspace := &domain.Workspace{ID: primitive.NewObjectID(), Members: []domain.WorkspaceMembership{
+		{UserID: adminID, Role: domain.WorkspaceAdmin},
+		{UserID: memberID, Role: domain.WorkspaceMember},
+	}}
+	mockRepo.On("InWorkspace", workspace.ID).Return(mockRepo)
+	mockWorkspaces.On("GetWorkspace", workspace.ID.Hex()).Return(workspace, nil)
+	scoped, err := fieldUsecase.InWorkspace(workspace.ID.Hex())
+	assert.NoError(t, err)
+	fieldID := primitive.NewObjectID().Hex()
+
+	t.Run("members cannot change fields", func(t *testing.T) {
+		err := scoped.RemoveField(fieldID, memberID.Hex())
+
+		assert.EqualError(t, err, "only workspace admins can change custom fields")
+	})
+
+	t.Run("admins can", func(t *testing.T) {
+		mockRepo.On("GetField", fieldID).Return(&domain.CustomField{Key: "customer", Type: domain.FieldText, WorkspaceID: workspace.ID}, nil).Once()
+		mockRepo.On("RemoveField", fieldID).Return(nil).Once()
+
+		err := scoped.RemoveField(fieldID, adminID.Hex())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("but not change the type", func(t *testing.T) {
+		mockRepo.On("GetField", fieldID).Return(&domain.CustomField{Key: "customer", Type: domain.FieldText, WorkspaceID: workspace.ID}, nil).Once()
+
+		err := scoped.UpdateField(fieldID, &domain.CustomField{Name: "Client", Type: domain.FieldEnum}, adminID.Hex())
+
+		assert.EqualError(t, err, "the key and type of a field cannot be changed")
+	})
+
+	t.Run("a rename", func(t *testing.T) {
+		mockRepo.On("GetField", fieldID).Return(&domain.CustomField{Key: "customer", Type: domain.FieldText, WorkspaceID: workspace.ID}, nil).Once()
+		mockRepo.On("UpdateField", fieldID, mock.MatchedBy(func(field *domain.CustomField) bool {
+			return field.Name == "Client" && field.Options == nil
+		})).Return(nil).Once()
+
+		err := scoped.UpdateField(fieldID, &domain.CustomField{Name: "Client", Options: []string{"a"}}, adminID.Hex())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("outsiders do not see the workspace", func(t *testing.T) {
+		err := scoped.CreateField(&domain.CustomField{Key: "customer", Name: "Customer", Type: domain.FieldText}, primitive.NewObjectID().Hex())
+
+		assert.EqualError(t, err, "workspace not found")
+	})
+	mockRepo.AssertExpectations(t)
+}
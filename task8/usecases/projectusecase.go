@@ -0,0 +1,187 @@
+package usecases
+
+import (
+	"errors"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var projectRoleRank = map[string]int{
+	domain.ProjectViewer: 1,
+	domain.ProjectEditor: 2,
+	domain.ProjectOwner:  3,
+}
+
+type ProjectUsecase struct {
+	repository domain.ProjectRepositoryInterface
+	users      domain.UserRepositoryInterface
+}
+
+func NewProjectUsecase(repository domain.ProjectRepositoryInterface, users domain.UserRepositoryInterface) *ProjectUsecase {
+	return &ProjectUsecase{repository: repository, users: users}
+}
+
+// InWorkspace is the usecase limited to the projects of one workspace, the
+// personal one for an empty ID. Membership of the workspace is for the
+// caller to check.
+func (pu *ProjectUsecase) InWorkspace(workspaceID string) (domain.ProjectUsecaseInterface, error) {
+
+	wid, err := workspaceObjectID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return &ProjectUsecase{repository: pu.repository.InWorkspace(wid), users: pu.users}, nil
+}
+
+func (pu *ProjectUsecase) CreateProject(newproject *domain.Project, userid string) error {
+
+	if newproject.Name == "" {
+		return errors.New("incomplete information")
+	}
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	newproject.Members = []domain.ProjectMember{{UserID: uid, Role: domain.ProjectOwner}}
+	return pu.repository.CreateProject(newproject)
+}
+
+func (pu *ProjectUsecase) GetProject(id string, userid string) (*domain.Project, error) {
+	return pu.authorize(id, userid, domain.ProjectViewer)
+}
+
+func (pu *ProjectUsecase) GetProjects(userid string) (*[]domain.Project, error) {
+	return pu.repository.GetProjects(userid)
+}
+
+func (pu *ProjectUsecase) UpdateProject(id string, updatedproject *domain.Project, userid string) error {
+
+	project, err := pu.authorize(id, userid, domain.ProjectEditor)
+	if err != nil {
+		return err
+	}
+	if updatedproject.Name == "" {
+		return errors.New("incomplete information")
+	}
+	updatedproject.ID = project.ID
+	updatedproject.Members = project.Members
+	return pu.repository.UpdateProject(id, updatedproject)
+}
+
+func (pu *ProjectUsecase) RemoveProject(id string, userid string) error {
+
+	if _, err := pu.authorize(id, userid, domain.ProjectOwner); err != nil {
+		return err
+	}
+	return pu.repository.RemoveProject(id)
+}
+
+// AddMember adds the user with the given email to the project, or changes
+// their role when they already are a member.
+func (pu *ProjectUsecase) AddMember(id string, email string, role string, userid string) (*domain.Project, error) {
+
+	project, err := pu.authorize(id, userid, domain.ProjectOwner)
+	if err != nil {
+		return nil, err
+	}
+	if projectRoleRank[role] == 0 {
+		return nil, errors.New("role must be owner, editor or viewer")
+	}
+	user, err := pu.users.GetUser(email)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	found := false
+	for i := range project.Members {
+		if project.Members[i].UserID == user.ID {
+			project.Members[i].Role = role
+			found = true
+		}
+	}
+	if !found {
+		project.Members = append(project.Members, domain.ProjectMember{UserID: user.ID, Role: role})
+	}
+	if !hasOwner(project) {
+		return nil, errors.New("a project needs at least one owner")
+	}
+
+	if err := pu.repository.UpdateProject(id, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// RemoveMember takes a user off the project. Owners may remove anyone and
+// every member may remove themselves, as long as an owner remains.
+func (pu *ProjectUsecase) RemoveMember(id string, memberid string, userid string) (*domain.Project, error) {
+
+	role := domain.ProjectOwner
+	if memberid == userid {
+		role = domain.ProjectViewer
+	}
+	project, err := pu.authorize(id, userid, role)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []domain.ProjectMember{}
+	for _, member := range project.Members {
+		if member.UserID.Hex() != memberid {
+			members = append(members, member)
+		}
+	}
+	if len(members) == len(project.Members) {
+		return nil, errors.New("user is not a member of the project")
+	}
+	project.Members = members
+	if !hasOwner(project) {
+		return nil, errors.New("a project needs at least one owner")
+	}
+
+	if err := pu.repository.UpdateProject(id, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (pu *ProjectUsecase) authorize(id string, userid string, role string) (*domain.Project, error) {
+
+	project, err := pu.repository.GetProject(id)
+	if err != nil {
+		return nil, err
+	}
+	if projectRole(project, userid) == "" {
+		return nil, errors.New("project not found")
+	}
+	if !hasProjectRole(project, userid, role) {
+		return nil, domain.ErrNoPermission
+	}
+	return project, nil
+}
+
+// projectRole returns the role the user holds in the project, or "" when
+// they are not a member.
+func projectRole(project *domain.Project, userid string) string {
+	for _, member := range project.Members {
+		if member.UserID.Hex() == userid {
+			return member.Role
+		}
+	}
+	return ""
+}
+
+func hasProjectRole(project *domain.Project, userid string, role string) bool {
+	rank := projectRoleRank[projectRole(project, userid)]
+	return rank > 0 && rank >= projectRoleRank[role]
+}
+
+func hasOwner(project *domain.Project) bool {
+	for _, member := range project.Members {
+		if member.Role == domain.ProjectOwner {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package usecases_test
+
+import (
+	"errors"
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateProject(t *testing.T) {
+	mockRepo := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	projectUsecase := usecases.NewProjectUsecase(mockRepo, mockUsers)
+
+	userID := primitive.NewObjectID()
+	mockRepo.On("CreateProject", mock.AnythingOfType("*domain.Project")).Return(nil)
+
+	project := &domain.Project{Name: "Website"}
+	err := projectUsecase.CreateProject(project, userID.Hex())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ProjectMember{{UserID: userID, Role: domain.ProjectOwner}}, project.Members)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddMember(t *testing.T) {
+	mockRepo := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	projectUsecase := usecases.NewProjectUsecase(mockRepo, mockUsers)
+
+	ownerID := primitive.NewObjectID()
+	editorID := primitive.NewObjectID()
+	newUserID := primitive.NewObjectID()
+	projectID := primitive.NewObjectID()
+
+	project := func() *domain.Project {
+		return &domain.Project{ID: projectID, Members: []domain.ProjectMember{
+			{UserID: ownerID, Role: domain.ProjectOwner},
+			{UserID: editorID, Role: domain.ProjectEditor},
+		}}
+	}
+
+	t.Run("owner adds a viewer", func(t *testing.T) {
+		mockRepo.On("GetProject", projectID.Hex()).Return(project(), nil).Once()
+		mockUsers.On("GetUser", "new@example.com").Return(&domain.User{ID: newUserID}, nil).Once()
+		mockRepo.On("UpdateProject", projectID.Hex(), mock.AnythingOfType("*domain.Project")).Return(nil).Once()
+
+		result, err := projectUsecase.AddMember(projectID.Hex(), "new@example.com", domain.ProjectViewer, ownerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Members, 3)
+	})
+
+	t.Run("editor cannot add members", func(t *testing.T) {
+		mockRepo.On("GetProject", projectID.Hex()).Return(project(), nil).Once()
+
+		_, err := projectUsecase.AddMember(projectID.Hex(), "new@example.com", domain.ProjectViewer, editorID.Hex())
+
+		assert.EqualError(t, err, "insufficient project permissions")
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mockRepo.On("GetProject", projectID.Hex()).Return(project(), nil).Once()
+		mockUsers.On("GetUser", "ghost@example.com").Return(nil, errors.New("mongo: no documents in result")).Once()
+
+		_, err := projectUsecase.AddMember(projectID.Hex(), "ghost@example.com", domain.ProjectViewer, ownerID.Hex())
+
+		assert.EqualError(t, err, "user not found")
+	})
+
+	t.Run("last owner cannot be demoted", func(t *testing.T) {
+		mockRepo.On("GetProject", projectID.Hex()).Return(project(), nil).Once()
+		mockUsers.On("GetUser", "owner@example.com").Return(&domain.User{ID: ownerID}, nil).Once()
+
+		_, err := projectUsecase.AddMember(projectID.Hex(), "owner@example.com", domain.ProjectEditor, ownerID.Hex())
+
+		assert.EqualError(t, err, "a project needs at least one owner")
+	})
+}
+
+func TestRemoveMember(t *testing.T) {
+	mockRepo := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	projectUsecase := usecases.NewProjectUsecase(mockRepo, mockUsers)
+
+	ownerID := primitive.NewObjectID()
+	viewerID := primitive.NewObjectID()
+	projectID := primitive.NewObjectID()
+
+	mockRepo.On("GetProject", projectID.Hex()).Return(&domain.Project{ID: projectID, Members: []domain.ProjectMember{
+		{UserID: ownerID, Role: domain.ProjectOwner},
+		{UserID: viewerID, Role: domain.ProjectViewer},
+	}}, nil)
+	mockRepo.On("UpdateProject", projectID.Hex(), mock.AnythingOfType("*domain.Project")).Return(nil)
+
+	result, err := projectUsecase.RemoveMember(projectID.Hex(), viewerID.Hex(), viewerID.Hex())
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Members, 1)
+}
@@ -0,0 +1,230 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// reminderLease is how long a claimed reminder stays with one delivery
+	// attempt. A failed or interrupted attempt is retried once it runs out.
+	reminderLease        = 2 * time.Minute
+	maxReminderAttempts  = 5
+	maxRemindersPerCycle = 100
+)
+
+type ReminderUsecase struct {
+	repository    domain.ReminderRepositoryInterface
+	notifications domain.NotificationRepositoryInterface
+	tasks         domain.TaskUsecaseInterface
+	users         domain.UserRepositoryInterface
+	notifiers     map[string]domain.Notifier
+}
+
+func NewReminderUsecase(repository domain.ReminderRepositoryInterface, notifications domain.NotificationRepositoryInterface, tasks domain.TaskUsecaseInterface, users domain.UserRepositoryInterface, notifiers map[string]domain.Notifier) *ReminderUsecase {
+	return &ReminderUsecase{repository: repository, notifications: notifications, tasks: tasks, users: users, notifiers: notifiers}
+}
+
+// InWorkspace is the usecase limited to the tasks of one workspace.
+func (ru *ReminderUsecase) InWorkspace(workspaceID string) (domain.ReminderUsecaseInterface, error) {
+
+	tasks, err := ru.tasks.InWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	scoped := *ru
+	scoped.tasks = tasks
+	return &scoped, nil
+}
+
+// CreateReminder sets a reminder on a task the user can see, to go out the
+// given time before the task is due.
+func (ru *ReminderUsecase) CreateReminder(taskid string, newreminder *domain.Reminder, userid string) error {
+
+	task, err := ru.tasks.GetTask(taskid, userid)
+	if err != nil {
+		return err
+	}
+	if task.DueDate.IsZero() {
+		return errors.New("task has no due date")
+	}
+	before, err := parseBefore(newreminder.Before)
+	if err != nil {
+		return err
+	}
+	if _, ok := ru.notifiers[newreminder.Channel]; !ok {
+		return errors.New("unsupported reminder channel")
+	}
+	user, err := ru.users.GetUserByID(userid)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	switch newreminder.Channel {
+	case domain.ReminderEmail:
+		newreminder.Target = user.Email
+	case domain.ReminderWebhook:
+		target, err := url.Parse(newreminder.Target)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return errors.New("webhook reminders need an http(s) target URL")
+		}
+	default:
+		newreminder.Target = ""
+	}
+
+	remindAt := task.DueDate.Add(-before)
+	if !remindAt.After(time.Now()) {
+		return errors.New("reminder time has already passed")
+	}
+
+	newreminder.ID = primitive.NilObjectID
+	newreminder.TaskID = task.ID
+	newreminder.UserID = user.ID
+	newreminder.Offset = int64(before / time.Second)
+	newreminder.RemindAt = remindAt
+	newreminder.Status = domain.ReminderPending
+	newreminder.Attempts = 0
+	newreminder.LockedUntil = time.Time{}
+	newreminder.SentAt = time.Time{}
+	newreminder.Error = ""
+	return ru.repository.CreateReminder(newreminder)
+}
+
+func (ru *ReminderUsecase) GetReminders(taskid string, userid string) (*[]domain.Reminder, error) {
+
+	if _, err := ru.tasks.GetTask(taskid, userid); err != nil {
+		return nil, err
+	}
+	return ru.repository.GetReminders(taskid, userid)
+}
+
+func (ru *ReminderUsecase) RemoveReminder(taskid string, id string, userid string) error {
+
+	reminder, err := ru.repository.GetReminder(id)
+	if err != nil || reminder.TaskID.Hex() != taskid || reminder.UserID.Hex() != userid {
+		return errors.New("reminder not found")
+	}
+	return ru.repository.RemoveReminder(id)
+}
+
+func (ru *ReminderUsecase) GetNotifications(userid string) (*[]domain.Notification, error) {
+	return ru.notifications.GetNotifications(userid)
+}
+
+func (ru *ReminderUsecase) ReadNotification(id string, userid string) error {
+	return ru.notifications.ReadNotification(id, userid)
+}
+
+// DispatchReminders delivers the reminders due at now and reports how many
+// went out. It is run by the background scheduler.
+//
+// Each reminder is claimed atomically before it is sent and only marked sent
+// afterwards, so several server instances never send the same one and a
+// reminder claimed by a process that died is picked up again once its lease
+// runs out. In that last case the notification keeps its ID, which the
+// notifiers use to let receivers drop the duplicate.
+func (ru *ReminderUsecase) DispatchReminders(now time.Time) (int, error) {
+
+	sent := 0
+	for i := 0; i < maxRemindersPerCycle; i++ {
+		reminder, err := ru.repository.ClaimReminder(now, reminderLease)
+		if err != nil {
+			return sent, err
+		}
+		if reminder == nil {
+			return sent, nil
+		}
+		ok, err := ru.deliver(reminder)
+		if err != nil {
+			return sent, err
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// deliver sends one claimed reminder and records the outcome. A failed send
+// keeps the claim so the lease doubles as the retry delay.
+func (ru *ReminderUsecase) deliver(reminder *domain.Reminder) (bool, error) {
+
+	id := reminder.ID.Hex()
+
+	task, err := ru.tasks.GetTask(reminder.TaskID.Hex(), reminder.UserID.Hex())
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, domain.ErrTaskNotFound):
+		return false, ru.repository.FinishReminder(id, domain.ReminderCancelled, "task is gone")
+	case errors.Is(err, domain.ErrNoPermission):
+		return false, ru.repository.FinishReminder(id, domain.ReminderCancelled, "task is no longer shared with the user")
+	case err != nil:
+		return false, ru.retry(reminder, err)
+	}
+	if domain.IsDone(task.Status) || task.DueDate.IsZero() {
+		return false, ru.repository.FinishReminder(id, domain.ReminderCancelled, "")
+	}
+
+	notifier, ok := ru.notifiers[reminder.Channel]
+	if !ok {
+		return false, ru.repository.FinishReminder(id, domain.ReminderFailed, "unsupported reminder channel")
+	}
+	notification := &domain.Notification{
+		ID:        reminder.ID,
+		UserID:    reminder.UserID,
+		TaskID:    task.ID,
+		Title:     "Reminder: " + task.Title,
+		Message:   fmt.Sprintf("%q is due %s.", task.Title, task.DueDate.Format(time.RFC1123)),
+		CreatedAt: time.Now(),
+	}
+
+	if err := notifier.Notify(reminder.Target, notification); err != nil {
+		return false, ru.retry(reminder, err)
+	}
+	return true, ru.repository.FinishReminder(id, domain.ReminderSent, "")
+}
+
+// retry leaves a failed attempt to be retried once the claim runs out, and
+// fails the reminder after its last attempt.
+func (ru *ReminderUsecase) retry(reminder *domain.Reminder, err error) error {
+
+	id := reminder.ID.Hex()
+	log.Printf("reminder %s: attempt %d failed: %v", id, reminder.Attempts, err)
+	if reminder.Attempts >= maxReminderAttempts {
+		return ru.repository.FinishReminder(id, domain.ReminderFailed, err.Error())
+	}
+	return nil
+}
+
+// parseBefore reads a reminder offset such as "30m", "1h" or "1d". Days and
+// weeks ("2w") are accepted on top of Go durations.
+func parseBefore(value string) (time.Duration, error) {
+
+	value = strings.TrimSpace(value)
+	var before time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(value, "d"), strings.HasSuffix(value, "w"):
+		unit := 24 * time.Hour
+		if strings.HasSuffix(value, "w") {
+			unit *= 7
+		}
+		var n int
+		n, err = strconv.Atoi(value[:len(value)-1])
+		before = time.Duration(n) * unit
+	default:
+		before, err = time.ParseDuration(value)
+	}
+	if err != nil || before < time.Minute || before > 365*24*time.Hour {
+		return 0, errors.New("before must be a duration between 1m and 365d, e.g. 1h or 1d")
+	}
+	return before, nil
+}
@@ -0,0 +1,185 @@
+package usecases_test
+
+import (
+	"errors"
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateReminder(t *testing.T) {
+	mockRepo := new(mocks.ReminderRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	notifiers := map[string]domain.Notifier{
+		domain.ReminderEmail:   new(mocks.Notifier),
+		domain.ReminderWebhook: new(mocks.Notifier),
+	}
+	reminderUsecase := usecases.NewReminderUsecase(mockRepo, new(mocks.NotificationRepositoryInterface), mockTasks, mockUsers, notifiers)
+
+	userID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	due := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+
+	mockTasks.On("GetTask", taskID.Hex(), userID.Hex()).Return(&domain.Task{ID: taskID, DueDate: due}, nil)
+	mockUsers.On("GetUserByID", userID.Hex()).Return(&domain.User{ID: userID, Email: "ann@example.com"}, nil)
+
+	t.Run("schedules an email a day ahead", func(t *testing.T) {
+		mockRepo.On("CreateReminder", mock.AnythingOfType("*domain.Reminder")).Return(nil).Once()
+
+		reminder := &domain.Reminder{Before: "1d", Channel: domain.ReminderEmail, Target: "someone@else.com"}
+		err := reminderUsecase.CreateReminder(taskID.Hex(), reminder, userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, due.Add(-24*time.Hour), reminder.RemindAt)
+		assert.Equal(t, int64(86400), reminder.Offset)
+		assert.Equal(t, "ann@example.com", reminder.Target)
+		assert.Equal(t, domain.ReminderPending, reminder.Status)
+	})
+
+	t.Run("rejects a reminder in the past", func(t *testing.T) {
+		err := reminderUsecase.CreateReminder(taskID.Hex(), &domain.Reminder{Before: "1w", Channel: domain.ReminderEmail}, userID.Hex())
+
+		assert.EqualError(t, err, "reminder time has already passed")
+	})
+
+	t.Run("rejects a bad offset", func(t *testing.T) {
+		err := reminderUsecase.CreateReminder(taskID.Hex(), &domain.Reminder{Before: "soon", Channel: domain.ReminderEmail}, userID.Hex())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects an unknown channel", func(t *testing.T) {
+		err := reminderUsecase.CreateReminder(taskID.Hex(), &domain.Reminder{Before: "1h", Channel: "pigeon"}, userID.Hex())
+
+		assert.EqualError(t, err, "unsupported reminder channel")
+	})
+
+	t.Run("webhooks need a URL", func(t *testing.T) {
+		err := reminderUsecase.CreateReminder(taskID.Hex(), &domain.Reminder{Before: "1h", Channel: domain.ReminderWebhook, Target: "ftp://example.com"}, userID.Hex())
+
+		assert.EqualError(t, err, "webhook reminders need an http(s) target URL")
+	})
+}
+
+func TestDispatchReminders(t *testing.T) {
+	userID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	now := time.Now()
+	task := &domain.Task{ID: taskID, Title: "Report", Status: "pending", DueDate: now.Add(time.Hour)}
+
+	setup := func() (*mocks.ReminderRepositoryInterface, *mocks.TaskUsecaseInterface, *mocks.Notifier, *usecases.ReminderUsecase) {
+		mockRepo := new(mocks.ReminderRepositoryInterface)
+		mockTasks := new(mocks.TaskUsecaseInterface)
+		mockNotifier := new(mocks.Notifier)
+		notifiers := map[string]domain.Notifier{domain.ReminderInApp: mockNotifier}
+		reminderUsecase := usecases.NewReminderUsecase(mockRepo, new(mocks.NotificationRepositoryInterface), mockTasks, new(mocks.UserRepositoryInterface), notifiers)
+		return mockRepo, mockTasks, mockNotifier, reminderUsecase
+	}
+
+	t.Run("sends each claimed reminder once", func(t *testing.T) {
+		mockRepo, mockTasks, mockNotifier, reminderUsecase := setup()
+		reminder := &domain.Reminder{ID: primitive.NewObjectID(), TaskID: taskID, UserID: userID, Channel: domain.ReminderInApp, Attempts: 1}
+
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(reminder, nil).Once()
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(nil, nil).Once()
+		mockTasks.On("GetTask", taskID.Hex(), userID.Hex()).Return(task, nil).Once()
+		mockNotifier.On("Notify", "", mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.ID == reminder.ID && n.UserID == userID && n.Title == "Reminder: Report"
+		})).Return(nil).Once()
+		mockRepo.On("FinishReminder", reminder.ID.Hex(), domain.ReminderSent, "").Return(nil).Once()
+
+		sent, err := reminderUsecase.DispatchReminders(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertExpectations(t)
+	})
+
+	t.Run("keeps the claim when delivery fails", func(t *testing.T) {
+		mockRepo, mockTasks, mockNotifier, reminderUsecase := setup()
+		reminder := &domain.Reminder{ID: primitive.NewObjectID(), TaskID: taskID, UserID: userID, Channel: domain.ReminderInApp, Attempts: 1}
+
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(reminder, nil).Once()
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(nil, nil).Once()
+		mockTasks.On("GetTask", taskID.Hex(), userID.Hex()).Return(task, nil).Once()
+		mockNotifier.On("Notify", "", mock.Anything).Return(errors.New("unavailable")).Once()
+
+		sent, err := reminderUsecase.DispatchReminders(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sent)
+		mockRepo.AssertNotCalled(t, "FinishReminder", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		mockRepo, mockTasks, mockNotifier, reminderUsecase := setup()
+		reminder := &domain.Reminder{ID: primitive.NewObjectID(), TaskID: taskID, UserID: userID, Channel: domain.ReminderInApp, Attempts: 5}
+
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(reminder, nil).Once()
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(nil, nil).Once()
+		mockTasks.On("GetTask", taskID.Hex(), userID.Hex()).Return(task, nil).Once()
+		mockNotifier.On("Notify", "", mock.Anything).Return(errors.New("unavailable")).Once()
+		mockRepo.On("FinishReminder", reminder.ID.Hex(), domain.ReminderFailed, "unavailable").Return(nil).Once()
+
+		_, err := reminderUsecase.DispatchReminders(now)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("cancels reminders of completed tasks", func(t *testing.T) {
+		mockRepo, mockTasks, mockNotifier, reminderUsecase := setup()
+		reminder := &domain.Reminder{ID: primitive.NewObjectID(), TaskID: taskID, UserID: userID, Channel: domain.ReminderInApp, Attempts: 1}
+
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(reminder, nil).Once()
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(nil, nil).Once()
+		mockTasks.On("GetTask", taskID.Hex(), userID.Hex()).Return(&domain.Task{ID: taskID, Status: "done", DueDate: task.DueDate}, nil).Once()
+		mockRepo.On("FinishReminder", reminder.ID.Hex(), domain.ReminderCancelled, "").Return(nil).Once()
+
+		_, err := reminderUsecase.DispatchReminders(now)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("cancels reminders of tasks the user lost access to", func(t *testing.T) {
+		mockRepo, mockTasks, _, reminderUsecase := setup()
+		reminder := &domain.Reminder{ID: primitive.NewObjectID(), TaskID: taskID, UserID: userID, Channel: domain.ReminderInApp, Attempts: 1}
+
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(reminder, nil).Once()
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(nil, nil).Once()
+		mockTasks.On("GetTask", taskID.Hex(), userID.Hex()).Return(nil, domain.ErrNoPermission).Once()
+		mockRepo.On("FinishReminder", reminder.ID.Hex(), domain.ReminderCancelled, "task is no longer shared with the user").Return(nil).Once()
+
+		_, err := reminderUsecase.DispatchReminders(now)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("other lookup errors count as attempts", func(t *testing.T) {
+		mockRepo, mockTasks, _, reminderUsecase := setup()
+		failing := &domain.Reminder{ID: primitive.NewObjectID(), TaskID: taskID, UserID: userID, Channel: domain.ReminderInApp, Attempts: 5}
+		next := &domain.Reminder{ID: primitive.NewObjectID(), TaskID: taskID, UserID: userID, Channel: domain.ReminderInApp, Attempts: 1}
+
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(failing, nil).Once()
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(next, nil).Once()
+		mockRepo.On("ClaimReminder", now, mock.Anything).Return(nil, nil).Once()
+		mockTasks.On("GetTask", taskID.Hex(), userID.Hex()).Return(nil, errors.New("server selection timeout")).Twice()
+		mockRepo.On("FinishReminder", failing.ID.Hex(), domain.ReminderFailed, "server selection timeout").Return(nil).Once()
+
+		_, err := reminderUsecase.DispatchReminders(now)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"errors"
+	"log"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StreamUsecase struct {
+	projects   domain.ProjectRepositoryInterface
+	workspaces domain.WorkspaceRepositoryInterface
+	broker     domain.EventBroker
+}
+
+func NewStreamUsecase(projects domain.ProjectRepositoryInterface, workspaces domain.WorkspaceRepositoryInterface, broker domain.EventBroker) *StreamUsecase {
+	return &StreamUsecase{projects: projects, workspaces: workspaces, broker: broker}
+}
+
+// Publish hands a task event to the broker, addressed to the users involved
+// in the task.
+func (su *StreamUsecase) Publish(event string, task *domain.Task) {
+
+	audience, err := taskAudience(su.projects, su.workspaces, task)
+	if err != nil {
+		log.Printf("stream: %s for task %s: %v", event, task.ID.Hex(), err)
+		return
+	}
+	su.broker.Publish(domain.StreamEvent{Event: event, Task: task, At: time.Now(), Audience: audience})
+}
+
+// Subscribe follows the changes to the user's tasks, resuming after
+// lastEventID when it is given.
+func (su *StreamUsecase) Subscribe(userid string, lastEventID string) (<-chan domain.StreamEvent, func(), error) {
+
+	if _, err := primitive.ObjectIDFromHex(userid); err != nil {
+		return nil, nil, errors.New("user ID is not a valid ObjectID")
+	}
+	events, cancel := su.broker.Subscribe(userid, lastEventID)
+	return events, cancel, nil
+}
@@ -0,0 +1,59 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStreamPublish(t *testing.T) {
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockBroker := new(mocks.EventBroker)
+	streamUsecase := usecases.NewStreamUsecase(mockProjects, new(mocks.WorkspaceRepositoryInterface), mockBroker)
+
+	ownerID := primitive.NewObjectID()
+	watcherID := primitive.NewObjectID()
+	task := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Watchers: []primitive.ObjectID{watcherID}}
+
+	mockBroker.On("Publish", mock.MatchedBy(func(event domain.StreamEvent) bool {
+		return event.Event == domain.EventTaskUpdated && event.Task == task &&
+			assert.ObjectsAreEqual([]primitive.ObjectID{ownerID, watcherID}, event.Audience)
+	})).Once()
+
+	streamUsecase.Publish(domain.EventTaskUpdated, task)
+
+	mockBroker.AssertExpectations(t)
+}
+
+func TestStreamPublishWorkspace(t *testing.T) {
+	mockWorkspaces := new(mocks.WorkspaceRepositoryInterface)
+	mockBroker := new(mocks.EventBroker)
+	streamUsecase := usecases.NewStreamUsecase(new(mocks.ProjectRepositoryInterface), mockWorkspaces, mockBroker)
+
+	ownerID := primitive.NewObjectID()
+	formerID := primitive.NewObjectID()
+	workspace := &domain.Workspace{ID: primitive.NewObjectID(), Members: []domain.WorkspaceMembership{{UserID: ownerID, Role: domain.WorkspaceOwner}}}
+	task := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, AssigneeID: formerID, WorkspaceID: workspace.ID}
+
+	mockWorkspaces.On("GetWorkspace", workspace.ID.Hex()).Return(workspace, nil).Once()
+	mockBroker.On("Publish", mock.MatchedBy(func(event domain.StreamEvent) bool {
+		return assert.ObjectsAreEqual([]primitive.ObjectID{ownerID}, event.Audience)
+	})).Once()
+
+	streamUsecase.Publish(domain.EventTaskUpdated, task)
+
+	mockBroker.AssertExpectations(t)
+}
+
+func TestStreamSubscribe(t *testing.T) {
+	streamUsecase := usecases.NewStreamUsecase(new(mocks.ProjectRepositoryInterface), new(mocks.WorkspaceRepositoryInterface), new(mocks.EventBroker))
+
+	_, _, err := streamUsecase.Subscribe("invalidID", "")
+
+	assert.EqualError(t, err, "user ID is not a valid ObjectID")
+}
@@ -0,0 +1,169 @@
+package usecases
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const defaultTagColor = "#9e9e9e"
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type TagUsecase struct {
+	repository domain.TagRepositoryInterface
+	workspaces domain.WorkspaceRepositoryInterface
+}
+
+func NewTagUsecase(repository domain.TagRepositoryInterface, workspaces domain.WorkspaceRepositoryInterface) *TagUsecase {
+	return &TagUsecase{repository: repository, workspaces: workspaces}
+}
+
+func (tu *TagUsecase) CreateTag(newtag *domain.Tag, userid string) error {
+
+	newtag.Name = normalizeTag(newtag.Name)
+	if err := validateTag(newtag); err != nil {
+		return err
+	}
+	if err := tu.checkNameFree(newtag.Name, "", userid); err != nil {
+		return err
+	}
+	return tu.repository.CreateTag(newtag, userid)
+}
+
+func (tu *TagUsecase) GetTags(userid string) (*[]domain.Tag, error) {
+	return tu.repository.GetTags(userid)
+}
+
+func (tu *TagUsecase) UpdateTag(id string, updatedtag *domain.Tag, userid string) error {
+
+	if _, err := tu.ownedTag(id, userid); err != nil {
+		return err
+	}
+	updatedtag.Name = normalizeTag(updatedtag.Name)
+	if err := validateTag(updatedtag); err != nil {
+		return err
+	}
+	if err := tu.checkNameFree(updatedtag.Name, id, userid); err != nil {
+		return err
+	}
+	repository, err := tu.reach(userid)
+	if err != nil {
+		return err
+	}
+	return repository.UpdateTag(id, updatedtag)
+}
+
+func (tu *TagUsecase) RemoveTag(id string, userid string) error {
+
+	if _, err := tu.ownedTag(id, userid); err != nil {
+		return err
+	}
+	repository, err := tu.reach(userid)
+	if err != nil {
+		return err
+	}
+	return repository.RemoveTag(id)
+}
+
+func (tu *TagUsecase) MergeTags(sourceid string, targetid string, userid string) error {
+
+	if sourceid == targetid {
+		return errors.New("cannot merge a tag into itself")
+	}
+	if _, err := tu.ownedTag(sourceid, userid); err != nil {
+		return err
+	}
+	if _, err := tu.ownedTag(targetid, userid); err != nil {
+		return err
+	}
+	repository, err := tu.reach(userid)
+	if err != nil {
+		return err
+	}
+	return repository.MergeTags(sourceid, targetid)
+}
+
+// reach is the repository whose changes relabel the user's tasks in every
+// workspace the catalogue serves: the personal one and those the user is
+// still a member of.
+func (tu *TagUsecase) reach(userid string) (domain.TagRepositoryInterface, error) {
+
+	workspaces, err := tu.workspaces.GetWorkspaces(userid)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(*workspaces))
+	for _, workspace := range *workspaces {
+		ids = append(ids, workspace.ID)
+	}
+	return tu.repository.InWorkspaces(ids), nil
+}
+
+func (tu *TagUsecase) ownedTag(id string, userid string) (*domain.Tag, error) {
+
+	tag, err := tu.repository.GetTag(id)
+	if err != nil {
+		return nil, err
+	}
+	if tag.UserID.Hex() != userid {
+		return nil, errors.New("tag not found")
+	}
+	return tag, nil
+}
+
+// checkNameFree rejects a name already used by another tag of the user;
+// renaming onto an existing tag is what MergeTags is for.
+func (tu *TagUsecase) checkNameFree(name string, id string, userid string) error {
+
+	tags, err := tu.repository.GetTags(userid)
+	if err != nil {
+		return err
+	}
+	for _, tag := range *tags {
+		if tag.Name == name && tag.ID.Hex() != id {
+			return errors.New("tag already exists")
+		}
+	}
+	return nil
+}
+
+func validateTag(tag *domain.Tag) error {
+
+	if tag.Name == "" {
+		return errors.New("incomplete information")
+	}
+	if tag.Color == "" {
+		tag.Color = defaultTagColor
+	}
+	if !tagColorPattern.MatchString(tag.Color) {
+		return errors.New("color must be a hex value like #ff0000")
+	}
+	return nil
+}
+
+func normalizeTag(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// normalizeTags lowercases and trims tag names, dropping blanks and duplicates.
+func normalizeTags(tags []string) []string {
+
+	if tags == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
@@ -0,0 +1,84 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateTag(t *testing.T) {
+	mockRepo := new(mocks.TagRepositoryInterface)
+	tagUsecase := usecases.NewTagUsecase(mockRepo, new(mocks.WorkspaceRepositoryInterface))
+
+	userID := primitive.NewObjectID()
+	mockRepo.On("GetTags", userID.Hex()).Return(&[]domain.Tag{{ID: primitive.NewObjectID(), Name: "urgent"}}, nil)
+
+	t.Run("normalizes the name and defaults the colour", func(t *testing.T) {
+		mockRepo.On("CreateTag", mock.AnythingOfType("*domain.Tag"), userID.Hex()).Return(nil).Once()
+
+		tag := &domain.Tag{Name: "  Client-X "}
+		err := tagUsecase.CreateTag(tag, userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "client-x", tag.Name)
+		assert.Equal(t, "#9e9e9e", tag.Color)
+	})
+
+	t.Run("rejects a duplicate name", func(t *testing.T) {
+		err := tagUsecase.CreateTag(&domain.Tag{Name: "Urgent"}, userID.Hex())
+
+		assert.EqualError(t, err, "tag already exists")
+	})
+
+	t.Run("rejects an invalid colour", func(t *testing.T) {
+		err := tagUsecase.CreateTag(&domain.Tag{Name: "later", Color: "red"}, userID.Hex())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateTagOwnership(t *testing.T) {
+	mockRepo := new(mocks.TagRepositoryInterface)
+	tagUsecase := usecases.NewTagUsecase(mockRepo, new(mocks.WorkspaceRepositoryInterface))
+
+	tagID := primitive.NewObjectID()
+	mockRepo.On("GetTag", tagID.Hex()).Return(&domain.Tag{ID: tagID, UserID: primitive.NewObjectID(), Name: "urgent"}, nil)
+
+	err := tagUsecase.UpdateTag(tagID.Hex(), &domain.Tag{Name: "asap"}, primitive.NewObjectID().Hex())
+
+	assert.EqualError(t, err, "tag not found")
+	mockRepo.AssertNotCalled(t, "UpdateTag", mock.Anything, mock.Anything)
+}
+
+// A merge relabels the user's tasks in their personal workspace and every
+// workspace they are still a member of, as the catalogue serves them all.
+func TestMergeTags(t *testing.T) {
+	mockRepo := new(mocks.TagRepositoryInterface)
+	mockWorkspaces := new(mocks.WorkspaceRepositoryInterface)
+	tagUsecase := usecases.NewTagUsecase(mockRepo, mockWorkspaces)
+
+	userID := primitive.NewObjectID()
+	sourceID := primitive.NewObjectID()
+	targetID := primitive.NewObjectID()
+	workspaceID := primitive.NewObjectID()
+
+	mockWorkspaces.On("GetWorkspaces", userID.Hex()).Return(&[]domain.Workspace{{ID: workspaceID}}, nil).Once()
+	mockRepo.On("InWorkspaces", []primitive.ObjectID{workspaceID}).Return(mockRepo).Once()
+
+	mockRepo.On("GetTag", sourceID.Hex()).Return(&domain.Tag{ID: sourceID, UserID: userID, Name: "client-x"}, nil)
+	mockRepo.On("GetTag", targetID.Hex()).Return(&domain.Tag{ID: targetID, UserID: userID, Name: "clientx"}, nil)
+	mockRepo.On("MergeTags", sourceID.Hex(), targetID.Hex()).Return(nil)
+
+	err := tagUsecase.MergeTags(sourceID.Hex(), targetID.Hex(), userID.Hex())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+
+	err = tagUsecase.MergeTags(sourceID.Hex(), sourceID.Hex(), userID.Hex())
+	assert.EqualError(t, err, "cannot merge a tag into itself")
+}
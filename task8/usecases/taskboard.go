@@ -0,0 +1,210 @@
+package usecases
+
+import (
+	"errors"
+	"strings"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// rankStep is the gap left between cards at the ends of a column and
+	// after a rebalance.
+	rankStep = 1024
+	// minRankGap is the gap under which a column is rebalanced; halving it
+	// is still exact for any rank a board will reach.
+	minRankGap      = 1e-3
+	maxMoveAttempts = 3
+)
+
+// lastRank ranks a new task below the cards already in its column. An
+// invalid user ID is left for CreateTask to turn away.
+func (tc *TaskUsecase) lastRank(task *domain.Task, userID string) (float64, error) {
+
+	last, err := tc.repository.LastRank(rankColumn(task, userID))
+	if err != nil {
+		return 0, err
+	}
+	return last + rankStep, nil
+}
+
+// rankColumn is the column a task the user creates goes into.
+func rankColumn(task *domain.Task, userID string) domain.BoardColumn {
+
+	column := boardColumn(task, task.Status)
+	if task.ProjectID.IsZero() {
+		column.UserID, _ = primitive.ObjectIDFromHex(userID)
+	}
+	return column
+}
+
+func boardColumn(task *domain.Task, status string) domain.BoardColumn {
+	if !task.ProjectID.IsZero() {
+		return domain.BoardColumn{ProjectID: task.ProjectID, Status: status}
+	}
+	return domain.BoardColumn{UserID: task.UserID, WorkspaceID: task.WorkspaceID, Status: status}
+}
+
+// MoveTask moves a card on its board, into another status column or within
+// its own. The new rank is taken halfway between the neighbours as they are
+// stored now, then checked once written: if a rebalance renumbered the
+// column in between, the move is placed again.
+func (tc *TaskUsecase) MoveTask(id string, move domain.TaskMove, userID string) (*domain.Task, error) {
+
+	task, err := tc.authorize(id, userID, domain.ProjectEditor)
+	if err != nil {
+		return nil, err
+	}
+	if move.After == id || move.Before == id {
+		return nil, errors.New("a task cannot be placed next to itself")
+	}
+	status := strings.TrimSpace(move.Status)
+	if status == "" {
+		status = task.Status
+	}
+	if !domain.IsDone(task.Status) && domain.IsDone(status) {
+		if err := tc.checkBlockers(task); err != nil {
+			return nil, err
+		}
+	}
+	column := boardColumn(task, status)
+
+	for attempt := 0; attempt < maxMoveAttempts; attempt++ {
+		cards, err := tc.repository.GetColumn(column)
+		if err != nil {
+			return nil, err
+		}
+		rank, ok, err := placeBetween(*cards, id, move)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			if err := tc.repository.RebalanceColumn(column, rankStep); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		completed := completedAt(task, status)
+		if err := tc.repository.MoveTask(id, status, rank, completed); err != nil {
+			return nil, err
+		}
+		if cards, err = tc.repository.GetColumn(column); err != nil {
+			return nil, err
+		}
+		if !inPlace(*cards, id, rank, move) {
+			continue
+		}
+
+		moved := *task
+		moved.Status = status
+		moved.Rank = rank
+		moved.CompletedAt = completed
+		tc.events.Publish(domain.EventTaskUpdated, &moved)
+		if !domain.IsDone(task.Status) && domain.IsDone(status) {
+			tc.events.Publish(domain.EventTaskCompleted, &moved)
+			if task.RRule != "" {
+				if err := tc.scheduleNext(task); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return &moved, nil
+	}
+	return nil, errors.New("the board is busy, try again")
+}
+
+// RebalanceBoards respaces the columns whose cards got too close together.
+// It returns how many columns it rebalanced.
+func (tc *TaskUsecase) RebalanceBoards() (int, error) {
+
+	columns, err := tc.repository.DenseColumns(minRankGap)
+	if err != nil {
+		return 0, err
+	}
+	for i, column := range columns {
+		if err := tc.repository.RebalanceColumn(column, rankStep); err != nil {
+			return i, err
+		}
+	}
+	return len(columns), nil
+}
+
+// placeBetween works out the rank that puts the task where move asks in a
+// column, given in board order. It reports false when the neighbours are too
+// close to fit a card between them and the column needs a rebalance first.
+func placeBetween(cards []domain.Task, id string, move domain.TaskMove) (float64, bool, error) {
+
+	others := make([]domain.Task, 0, len(cards))
+	after, before := -1, -1
+	for _, card := range cards {
+		if card.ID.Hex() == id {
+			continue
+		}
+		switch card.ID.Hex() {
+		case move.After:
+			after = len(others)
+		case move.Before:
+			before = len(others)
+		}
+		others = append(others, card)
+	}
+	if (move.After != "" && after < 0) || (move.Before != "" && before < 0) {
+		return 0, false, errors.New("neighbour is not in the target column")
+	}
+
+	var low, high float64
+	switch {
+	case move.After != "" && move.Before != "":
+		if after >= before {
+			return 0, false, errors.New("the board changed, reload it and try again")
+		}
+		low, high = others[after].Rank, others[before].Rank
+	case move.After != "":
+		if after == len(others)-1 {
+			return others[after].Rank + rankStep, true, nil
+		}
+		low, high = others[after].Rank, others[after+1].Rank
+	case move.Before != "":
+		if before == 0 {
+			return others[before].Rank - rankStep, true, nil
+		}
+		low, high = others[before-1].Rank, others[before].Rank
+	default:
+		if len(others) == 0 {
+			return rankStep, true, nil
+		}
+		return others[len(others)-1].Rank + rankStep, true, nil
+	}
+
+	rank := low + (high-low)/2
+	if !(low < rank && rank < high) {
+		return 0, false, nil
+	}
+	return rank, true, nil
+}
+
+// inPlace checks a written move against the column as it is now. A card
+// moved again since keeps its latest place; a neighbour that left the
+// column no longer constrains it.
+func inPlace(cards []domain.Task, id string, rank float64, move domain.TaskMove) bool {
+
+	for _, card := range cards {
+		switch card.ID.Hex() {
+		case id:
+			if card.Rank != rank {
+				return true
+			}
+		case move.After:
+			if card.Rank >= rank {
+				return false
+			}
+		case move.Before:
+			if card.Rank <= rank {
+				return false
+			}
+		}
+	}
+	return true
+}
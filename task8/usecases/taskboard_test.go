@@ -0,0 +1,130 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMoveTask(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: new(mocks.UserRepositoryInterface), Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	ownerID := primitive.NewObjectID()
+	card := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Status: "todo", Rank: 100}
+	a := domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Status: "doing", Rank: 1024}
+	b := domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Status: "doing", Rank: 2048}
+	c := domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Status: "doing", Rank: 3072}
+	doing := domain.BoardColumn{UserID: ownerID, Status: "doing"}
+	id := card.ID.Hex()
+	mockRepo.On("GetTask", id).Return(card, nil)
+
+	column := func(cards ...domain.Task) *[]domain.Task { return &cards }
+	placed := func(rank float64) domain.Task {
+		moved := *card
+		moved.Status = "doing"
+		moved.Rank = rank
+		return moved
+	}
+
+	t.Run("between two cards of another column", func(t *testing.T) {
+		mockRepo.On("GetColumn", doing).Return(column(a, b, c), nil).Once()
+		mockRepo.On("MoveTask", id, "doing", 1536.0, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockRepo.On("GetColumn", doing).Return(column(a, placed(1536), b, c), nil).Once()
+
+		task, err := taskUsecase.MoveTask(id, domain.TaskMove{Status: "doing", After: a.ID.Hex(), Before: b.ID.Hex()}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "doing", task.Status)
+		assert.Equal(t, 1536.0, task.Rank)
+	})
+
+	t.Run("to the top and to the bottom", func(t *testing.T) {
+		mockRepo.On("GetColumn", doing).Return(column(a, b), nil).Once()
+		mockRepo.On("MoveTask", id, "doing", 0.0, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockRepo.On("GetColumn", doing).Return(column(placed(0), a, b), nil).Once()
+
+		_, err := taskUsecase.MoveTask(id, domain.TaskMove{Status: "doing", Before: a.ID.Hex()}, ownerID.Hex())
+		assert.NoError(t, err)
+
+		mockRepo.On("GetColumn", doing).Return(column(a, b), nil).Once()
+		mockRepo.On("MoveTask", id, "doing", 3072.0, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockRepo.On("GetColumn", doing).Return(column(a, b, placed(3072)), nil).Once()
+
+		_, err = taskUsecase.MoveTask(id, domain.TaskMove{Status: "doing"}, ownerID.Hex())
+		assert.NoError(t, err)
+	})
+
+	t.Run("rebalances a column out of room", func(t *testing.T) {
+		tight := b
+		tight.Rank = a.Rank
+		mockRepo.On("GetColumn", doing).Return(column(a, tight), nil).Once()
+		mockRepo.On("RebalanceColumn", doing, 1024.0).Return(nil).Once()
+		mockRepo.On("GetColumn", doing).Return(column(a, b), nil).Once()
+		mockRepo.On("MoveTask", id, "doing", 1536.0, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockRepo.On("GetColumn", doing).Return(column(a, placed(1536), b), nil).Once()
+
+		_, err := taskUsecase.MoveTask(id, domain.TaskMove{Status: "doing", After: a.ID.Hex(), Before: tight.ID.Hex()}, ownerID.Hex())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("places again after a concurrent rebalance", func(t *testing.T) {
+		// Read just before the rebalancer renumbered b and c to 1024 and 2048.
+		mockRepo.On("GetColumn", doing).Return(column(b, c), nil).Once()
+		mockRepo.On("MoveTask", id, "doing", 2560.0, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		rb, rc := b, c
+		rb.Rank, rc.Rank = 1024, 2048
+		mockRepo.On("GetColumn", doing).Return(column(rb, rc, placed(2560)), nil).Twice()
+		mockRepo.On("MoveTask", id, "doing", 1536.0, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockRepo.On("GetColumn", doing).Return(column(rb, placed(1536), rc), nil).Once()
+
+		task, err := taskUsecase.MoveTask(id, domain.TaskMove{Status: "doing", Before: c.ID.Hex()}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1536.0, task.Rank)
+	})
+
+	t.Run("stale neighbours", func(t *testing.T) {
+		mockRepo.On("GetColumn", doing).Return(column(a, b), nil).Once()
+
+		_, err := taskUsecase.MoveTask(id, domain.TaskMove{Status: "doing", After: b.ID.Hex(), Before: a.ID.Hex()}, ownerID.Hex())
+		assert.EqualError(t, err, "the board changed, reload it and try again")
+
+		mockRepo.On("GetColumn", doing).Return(column(a, b), nil).Once()
+
+		_, err = taskUsecase.MoveTask(id, domain.TaskMove{Status: "doing", After: primitive.NewObjectID().Hex()}, ownerID.Hex())
+		assert.EqualError(t, err, "neighbour is not in the target column")
+	})
+
+	t.Run("other users cannot move it", func(t *testing.T) {
+		_, err := taskUsecase.MoveTask(id, domain.TaskMove{Status: "doing"}, primitive.NewObjectID().Hex())
+
+		assert.EqualError(t, err, "task not found")
+	})
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRebalanceBoards(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: new(mocks.UserRepositoryInterface), Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: new(mocks.CustomFieldRepositoryInterface), Events: new(mocks.EventPublisher)})
+
+	columns := []domain.BoardColumn{{ProjectID: primitive.NewObjectID(), Status: "todo"}, {UserID: primitive.NewObjectID(), Status: "done"}}
+	mockRepo.On("DenseColumns", 1e-3).Return(columns, nil).Once()
+	mockRepo.On("RebalanceColumn", columns[0], 1024.0).Return(nil).Once()
+	mockRepo.On("RebalanceColumn", columns[1], 1024.0).Return(nil).Once()
+
+	count, err := taskUsecase.RebalanceBoards()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	mockRepo.AssertExpectations(t)
+}
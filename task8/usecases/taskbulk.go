@@ -0,0 +1,295 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxBulkItems = 500
+
+// BulkTasks applies a batch of operations, or an action to every task a
+// filter matches, under the same rules as the single task endpoints. Each
+// item gets its own result. By default the items are independent; an
+// atomic batch is checked as a whole first and written in one transaction.
+func (tc *TaskUsecase) BulkTasks(request domain.BulkRequest, userID string) (*domain.BulkResponse, error) {
+
+	operations := request.Operations
+	if request.Filter != nil || request.Action != nil {
+		if len(operations) > 0 {
+			return nil, errors.New("send either operations or a filter with an action")
+		}
+		if request.Filter == nil || request.Action == nil {
+			return nil, errors.New("a filter needs an action, and an action a filter")
+		}
+		expanded, err := tc.expandFilter(*request.Filter, *request.Action, userID)
+		if err != nil {
+			return nil, err
+		}
+		operations = expanded
+	} else if len(operations) == 0 {
+		return nil, errors.New("no operations given")
+	}
+	if len(operations) > maxBulkItems {
+		return nil, fmt.Errorf("a bulk request can touch at most %d tasks", maxBulkItems)
+	}
+
+	if request.Atomic {
+		return tc.bulkAtomic(operations, userID)
+	}
+	response := &domain.BulkResponse{Results: make([]domain.BulkResult, len(operations))}
+	for i, operation := range operations {
+		result := domain.BulkResult{Index: i, Op: operation.Op, ID: operation.ID, Status: domain.BulkOK}
+		task, err := tc.applyOperation(operation, userID)
+		if err != nil {
+			result.Status = domain.BulkFailed
+			result.Error = err.Error()
+		}
+		if task != nil {
+			result.ID = task.ID.Hex()
+			result.Task = task
+		}
+		response.Results[i] = result
+	}
+	return response, nil
+}
+
+func (tc *TaskUsecase) applyOperation(operation domain.BulkOperation, userID string) (*domain.Task, error) {
+
+	switch operation.Op {
+	case domain.BulkCreate:
+		if operation.Task == nil {
+			return nil, errors.New("task is required")
+		}
+		task := *operation.Task
+		task.ID = primitive.NilObjectID
+		if err := tc.CreateTask(&task, userID); err != nil {
+			return nil, err
+		}
+		return &task, nil
+	case domain.BulkUpdate:
+		if operation.Task == nil {
+			return nil, errors.New("task is required")
+		}
+		task := *operation.Task
+		return tc.updateTask(operation.ID, &task, userID)
+	case domain.BulkDelete:
+		return nil, tc.RemoveTask(operation.ID, userID)
+	}
+	return nil, errors.New("unknown operation")
+}
+
+// bulkAtomic checks every operation before writing any. When one fails the
+// others are skipped; otherwise all are written in one transaction and the
+// follow-ups (events, reminders, attachments) run after it commits.
+func (tc *TaskUsecase) bulkAtomic(operations []domain.BulkOperation, userID string) (*domain.BulkResponse, error) {
+
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+
+	response := &domain.BulkResponse{Atomic: true, Results: make([]domain.BulkResult, len(operations))}
+	writes := make([]domain.TaskWrite, 0, len(operations))
+	followups := make([]func() (*domain.Task, error), len(operations))
+	touched := map[string]bool{}
+	ranks := map[domain.BoardColumn]float64{}
+	failed := false
+
+	for i, operation := range operations {
+		response.Results[i] = domain.BulkResult{Index: i, Op: operation.Op, ID: operation.ID, Status: domain.BulkOK}
+		write, followup, err := tc.planOperation(operation, uid, userID, touched, ranks)
+		if err != nil {
+			response.Results[i].Status = domain.BulkFailed
+			response.Results[i].Error = err.Error()
+			failed = true
+			continue
+		}
+		writes = append(writes, write)
+		followups[i] = followup
+	}
+
+	if !failed {
+		if err := tc.repository.WriteTasks(writes); err != nil {
+			for i := range response.Results {
+				response.Results[i].Status = domain.BulkFailed
+				response.Results[i].Error = err.Error()
+			}
+			return response, nil
+		}
+	}
+
+	for i := range response.Results {
+		result := &response.Results[i]
+		if failed {
+			if result.Status == domain.BulkOK {
+				result.Status = domain.BulkSkipped
+				result.Error = "not applied: another operation failed"
+			}
+			continue
+		}
+		task, err := followups[i]()
+		if task != nil {
+			result.ID = task.ID.Hex()
+			result.Task = task
+		}
+		// The write is committed; only what follows it went wrong.
+		if err != nil {
+			result.Error = err.Error()
+		}
+	}
+	return response, nil
+}
+
+// planOperation checks one operation of an atomic batch and turns it into
+// the write to make and the follow-up to run once the batch is stored.
+// Created tasks are ranked as CreateTask ranks them; ranks holds the last
+// rank handed out per column, as none of the batch is stored yet.
+func (tc *TaskUsecase) planOperation(operation domain.BulkOperation, uid primitive.ObjectID, userID string, touched map[string]bool, ranks map[domain.BoardColumn]float64) (domain.TaskWrite, func() (*domain.Task, error), error) {
+
+	if operation.ID != "" {
+		if touched[operation.ID] {
+			return domain.TaskWrite{}, nil, errors.New("task appears in more than one operation")
+		}
+		touched[operation.ID] = true
+	}
+
+	switch operation.Op {
+	case domain.BulkCreate:
+		if operation.Task == nil {
+			return domain.TaskWrite{}, nil, errors.New("task is required")
+		}
+		task := *operation.Task
+		task.ID = primitive.NilObjectID
+		if err := tc.prepareCreate(&task, userID); err != nil {
+			return domain.TaskWrite{}, nil, err
+		}
+		rank, err := tc.lastRank(&task, userID)
+		if err != nil {
+			return domain.TaskWrite{}, nil, err
+		}
+		column := rankColumn(&task, userID)
+		if last, ok := ranks[column]; ok && last >= rank {
+			rank = last + rankStep
+		}
+		ranks[column] = rank
+		task.Rank = rank
+		task.UserID = uid
+		return domain.TaskWrite{Create: &task}, func() (*domain.Task, error) {
+			tc.events.Publish(domain.EventTaskCreated, &task)
+			return &task, nil
+		}, nil
+
+	case domain.BulkUpdate:
+		if operation.Task == nil {
+			return domain.TaskWrite{}, nil, errors.New("task is required")
+		}
+		task := *operation.Task
+		change, err := tc.prepareUpdate(operation.ID, &task, userID)
+		if err != nil {
+			return domain.TaskWrite{}, nil, err
+		}
+		write := domain.TaskWrite{ID: operation.ID, Update: &task}
+		if change.reassigned {
+			write.Assignee = task.AssigneeID.Hex()
+			write.Assignment = &change.event
+		}
+		return write, func() (*domain.Task, error) {
+			return tc.finishUpdate(change)
+		}, nil
+
+	case domain.BulkDelete:
+		task, err := tc.authorize(operation.ID, userID, manageTask)
+		if err != nil {
+			return domain.TaskWrite{}, nil, err
+		}
+		return domain.TaskWrite{ID: operation.ID, Delete: true}, func() (*domain.Task, error) {
+			tc.events.Publish(domain.EventTaskDeleted, task)
+			return nil, tc.removeTaskData(operation.ID)
+		}, nil
+	}
+	return domain.TaskWrite{}, nil, errors.New("unknown operation")
+}
+
+// expandFilter turns a filter and an action into one operation per task the
+// filter matches.
+func (tc *TaskUsecase) expandFilter(filter domain.BulkFilter, action domain.BulkAction, userID string) ([]domain.BulkOperation, error) {
+
+	switch action.Type {
+	case domain.BulkDelete:
+	case domain.BulkSetStatus, domain.BulkAddTag, domain.BulkRemoveTag:
+		if strings.TrimSpace(action.Value) == "" {
+			return nil, errors.New("the action needs a value")
+		}
+	default:
+		return nil, errors.New("unknown action")
+	}
+
+	tags := normalizeTags(filter.Tags)
+	var tasks *[]domain.Task
+	var err error
+	if filter.ProjectID != "" {
+		tasks, err = tc.GetProjectTasks(filter.ProjectID, userID)
+	} else {
+		tasks, err = tc.FilterTasks(userID, domain.TaskFilter{Tags: tags, MatchAll: filter.MatchAll})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	operations := []domain.BulkOperation{}
+	for _, task := range *tasks {
+		if filter.Status != "" && !strings.EqualFold(task.Status, filter.Status) {
+			continue
+		}
+		if filter.ProjectID != "" && !hasTags(task.Tags, tags, filter.MatchAll) {
+			continue
+		}
+		if action.Type == domain.BulkDelete {
+			operations = append(operations, domain.BulkOperation{Op: domain.BulkDelete, ID: task.ID.Hex()})
+			continue
+		}
+		updated := task
+		switch action.Type {
+		case domain.BulkSetStatus:
+			updated.Status = strings.TrimSpace(action.Value)
+		case domain.BulkAddTag:
+			updated.Tags = append(append([]string{}, task.Tags...), action.Value)
+		case domain.BulkRemoveTag:
+			removed := normalizeTags([]string{action.Value})
+			updated.Tags = []string{}
+			for _, tag := range task.Tags {
+				if len(removed) == 0 || tag != removed[0] {
+					updated.Tags = append(updated.Tags, tag)
+				}
+			}
+		}
+		operations = append(operations, domain.BulkOperation{Op: domain.BulkUpdate, ID: task.ID.Hex(), Task: &updated})
+	}
+	return operations, nil
+}
+
+// hasTags reports whether a task carries any, or with all set every one, of
+// the wanted tags. No wanted tags match every task.
+func hasTags(tags []string, wanted []string, all bool) bool {
+
+	if len(wanted) == 0 {
+		return true
+	}
+	found := 0
+	for _, want := range wanted {
+		for _, tag := range tags {
+			if tag == want {
+				found++
+				break
+			}
+		}
+	}
+	if all {
+		return found == len(wanted)
+	}
+	return found > 0
+}
@@ -0,0 +1,189 @@
+package usecases_test
+
+import (
+	"errors"
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBulkTasks(t *testing.T) {
+	ownerID := primitive.NewObjectID()
+	mine := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Title: "Mine", Description: "d", Status: "todo", Tags: []string{"ops"}}
+	theirs := &domain.Task{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), Title: "Theirs", Description: "d", Status: "todo"}
+
+	setup := func() (*usecases.TaskUsecase, *mocks.TaskRepositoryInterface, *mocks.AttachmentRepositoryInterface, *mocks.ReminderRepositoryInterface) {
+		mockRepo := new(mocks.TaskRepositoryInterface)
+		attachments := new(mocks.AttachmentRepositoryInterface)
+		reminders := new(mocks.ReminderRepositoryInterface)
+		comments := new(mocks.CommentRepositoryInterface)
+		comments.On("RemoveTaskComments", mock.Anything).Return(nil).Maybe()
+		entries := new(mocks.TimeEntryRepositoryInterface)
+		entries.On("RemoveTaskEntries", mock.Anything).Return(nil).Maybe()
+		mockEvents := new(mocks.EventPublisher)
+		mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+		mockRepo.On("GetTask", mine.ID.Hex()).Return(mine, nil).Maybe()
+		mockRepo.On("GetTask", theirs.ID.Hex()).Return(theirs, nil).Maybe()
+		taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: new(mocks.UserRepositoryInterface), Attachments: attachments, Reminders: reminders, Comments: comments, TimeEntries: entries, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+		return taskUsecase, mockRepo, attachments, reminders
+	}
+
+	t.Run("applies each operation on its own", func(t *testing.T) {
+		taskUsecase, mockRepo, _, _ := setup()
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("CreateTask", mock.AnythingOfType("*domain.Task"), ownerID.Hex()).Return(nil).Once()
+		mockRepo.On("UpdateTask", mine.ID.Hex(), mock.AnythingOfType("*domain.Task")).Return(nil).Once()
+
+		response, err := taskUsecase.BulkTasks(domain.BulkRequest{Operations: []domain.BulkOperation{
+			{Op: domain.BulkCreate, Task: &domain.Task{Title: "New", Description: "d", Status: "todo"}},
+			{Op: domain.BulkUpdate, ID: mine.ID.Hex(), Task: &domain.Task{Title: "Renamed", Description: "d", Status: "doing"}},
+			{Op: domain.BulkDelete, ID: theirs.ID.Hex()},
+			{Op: "archive", ID: mine.ID.Hex()},
+		}}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		assert.False(t, response.Atomic)
+		assert.Len(t, response.Results, 4)
+		assert.Equal(t, domain.BulkOK, response.Results[0].Status)
+		assert.Equal(t, "New", response.Results[0].Task.Title)
+		assert.Equal(t, domain.BulkOK, response.Results[1].Status)
+		assert.Equal(t, "Renamed", response.Results[1].Task.Title)
+		assert.Equal(t, domain.BulkFailed, response.Results[2].Status)
+		assert.Equal(t, "task not found", response.Results[2].Error)
+		assert.Equal(t, "unknown operation", response.Results[3].Error)
+		mockRepo.AssertNotCalled(t, "RemoveTask", mock.Anything)
+	})
+
+	t.Run("atomic batch skips everything when one item fails", func(t *testing.T) {
+		taskUsecase, mockRepo, _, _ := setup()
+
+		response, err := taskUsecase.BulkTasks(domain.BulkRequest{Atomic: true, Operations: []domain.BulkOperation{
+			{Op: domain.BulkUpdate, ID: mine.ID.Hex(), Task: &domain.Task{Title: "Renamed", Description: "d", Status: "doing"}},
+			{Op: domain.BulkDelete, ID: theirs.ID.Hex()},
+		}}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		assert.True(t, response.Atomic)
+		assert.Equal(t, domain.BulkSkipped, response.Results[0].Status)
+		assert.Equal(t, domain.BulkFailed, response.Results[1].Status)
+		mockRepo.AssertNotCalled(t, "WriteTasks", mock.Anything)
+		mockRepo.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+	})
+
+	t.Run("atomic batch refuses a task twice", func(t *testing.T) {
+		taskUsecase, _, _, _ := setup()
+
+		response, err := taskUsecase.BulkTasks(domain.BulkRequest{Atomic: true, Operations: []domain.BulkOperation{
+			{Op: domain.BulkUpdate, ID: mine.ID.Hex(), Task: &domain.Task{Title: "Renamed", Description: "d", Status: "doing"}},
+			{Op: domain.BulkDelete, ID: mine.ID.Hex()},
+		}}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.BulkSkipped, response.Results[0].Status)
+		assert.Equal(t, "task appears in more than one operation", response.Results[1].Error)
+	})
+
+	t.Run("atomic batch is written in one go", func(t *testing.T) {
+		taskUsecase, mockRepo, attachments, reminders := setup()
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("WriteTasks", mock.MatchedBy(func(writes []domain.TaskWrite) bool {
+			return len(writes) == 2 && writes[0].Create != nil && writes[0].Create.UserID == ownerID && writes[1].Delete && writes[1].ID == mine.ID.Hex()
+		})).Return(nil).Once()
+		attachments.On("RemoveTaskAttachments", mine.ID.Hex()).Return(nil).Once()
+		reminders.On("RemoveTaskReminders", mine.ID.Hex()).Return(nil).Once()
+
+		response, err := taskUsecase.BulkTasks(domain.BulkRequest{Atomic: true, Operations: []domain.BulkOperation{
+			{Op: domain.BulkCreate, Task: &domain.Task{Title: "New", Description: "d", Status: "todo"}},
+			{Op: domain.BulkDelete, ID: mine.ID.Hex()},
+		}}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.BulkOK, response.Results[0].Status)
+		assert.Equal(t, domain.BulkOK, response.Results[1].Status)
+		mockRepo.AssertExpectations(t)
+		attachments.AssertExpectations(t)
+		reminders.AssertExpectations(t)
+	})
+
+	t.Run("atomic creates are ranked like single ones", func(t *testing.T) {
+		taskUsecase, mockRepo, _, _ := setup()
+		mockRepo.On("LastRank", domain.BoardColumn{UserID: ownerID, Status: "todo"}).Return(4096.0, nil)
+		mockRepo.On("LastRank", domain.BoardColumn{UserID: ownerID, Status: "doing"}).Return(0.0, nil)
+		mockRepo.On("WriteTasks", mock.MatchedBy(func(writes []domain.TaskWrite) bool {
+			return len(writes) == 3 && writes[0].Create.Rank == 5120 && writes[1].Create.Rank == 6144 && writes[2].Create.Rank == 1024
+		})).Return(nil).Once()
+
+		response, err := taskUsecase.BulkTasks(domain.BulkRequest{Atomic: true, Operations: []domain.BulkOperation{
+			{Op: domain.BulkCreate, Task: &domain.Task{Title: "First", Description: "d", Status: "todo"}},
+			{Op: domain.BulkCreate, Task: &domain.Task{Title: "Second", Description: "d", Status: "todo"}},
+			{Op: domain.BulkCreate, Task: &domain.Task{Title: "Started", Description: "d", Status: "doing"}},
+		}}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.BulkOK, response.Results[0].Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("atomic batch fails as a whole", func(t *testing.T) {
+		taskUsecase, mockRepo, _, _ := setup()
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("WriteTasks", mock.Anything).Return(errors.New("transaction aborted")).Once()
+
+		response, err := taskUsecase.BulkTasks(domain.BulkRequest{Atomic: true, Operations: []domain.BulkOperation{
+			{Op: domain.BulkCreate, Task: &domain.Task{Title: "New", Description: "d", Status: "todo"}},
+		}}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.BulkFailed, response.Results[0].Status)
+		assert.Equal(t, "transaction aborted", response.Results[0].Error)
+	})
+
+	t.Run("filter with an action", func(t *testing.T) {
+		taskUsecase, mockRepo, _, _ := setup()
+		done := *mine
+		done.ID = primitive.NewObjectID()
+		done.Status = "done"
+		mockRepo.On("FilterTasks", ownerID.Hex(), domain.TaskFilter{Tags: []string{"ops"}}).Return(&[]domain.Task{*mine, done}, nil).Once()
+		mockRepo.On("UpdateTask", mine.ID.Hex(), mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Status == "blocked" && task.Title == mine.Title
+		})).Return(nil).Once()
+
+		response, err := taskUsecase.BulkTasks(domain.BulkRequest{
+			Filter: &domain.BulkFilter{Tags: []string{"OPS"}, Status: "todo"},
+			Action: &domain.BulkAction{Type: domain.BulkSetStatus, Value: "blocked"},
+		}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, response.Results, 1)
+		assert.Equal(t, domain.BulkOK, response.Results[0].Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		taskUsecase, _, _, _ := setup()
+
+		_, err := taskUsecase.BulkTasks(domain.BulkRequest{}, ownerID.Hex())
+		assert.EqualError(t, err, "no operations given")
+
+		_, err = taskUsecase.BulkTasks(domain.BulkRequest{
+			Operations: []domain.BulkOperation{{Op: domain.BulkDelete, ID: mine.ID.Hex()}},
+			Filter:     &domain.BulkFilter{},
+			Action:     &domain.BulkAction{Type: domain.BulkDelete},
+		}, ownerID.Hex())
+		assert.EqualError(t, err, "send either operations or a filter with an action")
+
+		_, err = taskUsecase.BulkTasks(domain.BulkRequest{Filter: &domain.BulkFilter{}}, ownerID.Hex())
+		assert.EqualError(t, err, "a filter needs an action, and an action a filter")
+
+		_, err = taskUsecase.BulkTasks(domain.BulkRequest{Filter: &domain.BulkFilter{}, Action: &domain.BulkAction{Type: domain.BulkAddTag}}, ownerID.Hex())
+		assert.EqualError(t, err, "the action needs a value")
+
+		_, err = taskUsecase.BulkTasks(domain.BulkRequest{Operations: make([]domain.BulkOperation, 501)}, ownerID.Hex())
+		assert.EqualError(t, err, "a bulk request can touch at most 500 tasks")
+	})
+}
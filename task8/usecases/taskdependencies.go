@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxBlockers = 50
+
+// AddBlocker makes the task wait on blockerID. The user needs to be able to
+// edit the task and read the blocker. A dependency closing a cycle is
+// refused, including one closed by a concurrent request: the check runs
+// again once written and the new dependency is taken back if it failed.
+func (tc *TaskUsecase) AddBlocker(id string, blockerID string, userID string) (*domain.Task, error) {
+
+	task, err := tc.authorize(id, userID, domain.ProjectEditor)
+	if err != nil {
+		return nil, err
+	}
+	if blockerID == id {
+		return nil, errors.New("a task cannot block itself")
+	}
+	blocker, err := tc.authorize(blockerID, userID, domain.ProjectViewer)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range task.BlockedBy {
+		if existing == blocker.ID {
+			return task, nil
+		}
+	}
+	if len(task.BlockedBy) >= maxBlockers {
+		return nil, fmt.Errorf("a task can have at most %d blockers", maxBlockers)
+	}
+	if err := tc.checkCycle(task.ID, blockerID); err != nil {
+		return nil, err
+	}
+
+	if err := tc.repository.AddBlocker(id, blockerID); err != nil {
+		return nil, err
+	}
+	if err := tc.checkCycle(task.ID, blockerID); err != nil {
+		if removeErr := tc.repository.RemoveBlocker(id, blockerID); removeErr != nil {
+			return nil, removeErr
+		}
+		return nil, err
+	}
+
+	task, err = tc.repository.GetTask(id)
+	if err != nil {
+		return nil, err
+	}
+	tc.events.Publish(domain.EventTaskUpdated, task)
+	return task, nil
+}
+
+func (tc *TaskUsecase) RemoveBlocker(id string, blockerID string, userID string) (*domain.Task, error) {
+
+	if _, err := tc.authorize(id, userID, domain.ProjectEditor); err != nil {
+		return nil, err
+	}
+	if err := tc.repository.RemoveBlocker(id, blockerID); err != nil {
+		return nil, err
+	}
+	task, err := tc.repository.GetTask(id)
+	if err != nil {
+		return nil, err
+	}
+	tc.events.Publish(domain.EventTaskUpdated, task)
+	return task, nil
+}
+
+// GetDependencyGraph returns the tasks the given one waits on and the tasks
+// waiting on it, transitively, with an edge per dependency.
+func (tc *TaskUsecase) GetDependencyGraph(id string, userID string) (*domain.TaskGraph, error) {
+
+	root, err := tc.authorize(id, userID, domain.ProjectViewer)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := tc.repository.GetDependencyGraph(id)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &domain.TaskGraph{Root: root.ID, Nodes: []domain.GraphNode{}, Edges: []domain.GraphEdge{}}
+	seen := map[primitive.ObjectID]bool{}
+	for _, task := range *tasks {
+		if seen[task.ID] {
+			continue
+		}
+		seen[task.ID] = true
+		node := domain.GraphNode{ID: task.ID, Hidden: true}
+		if tc.allowed(&task, userID, domain.ProjectViewer) == nil {
+			node = domain.GraphNode{ID: task.ID, Title: task.Title, Status: task.Status}
+		}
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	for _, task := range *tasks {
+		for _, blocker := range task.BlockedBy {
+			if seen[blocker] {
+				graph.Edges = append(graph.Edges, domain.GraphEdge{From: blocker, To: task.ID})
+			}
+		}
+	}
+	return graph, nil
+}
+
+// checkCycle refuses a dependency of taskID on blockerID when the blocker
+// already waits on the task, directly or not.
+func (tc *TaskUsecase) checkCycle(taskID primitive.ObjectID, blockerID string) error {
+
+	blockers, err := tc.repository.GetBlockers(blockerID)
+	if err != nil {
+		return err
+	}
+	for _, blocker := range *blockers {
+		if blocker.ID == taskID {
+			return errors.New("the dependency would create a cycle")
+		}
+	}
+	return nil
+}
+
+// checkBlockers keeps a task from being done while a task it waits on is
+// still open. Deleted blockers no longer count.
+func (tc *TaskUsecase) checkBlockers(task *domain.Task) error {
+
+	if len(task.BlockedBy) == 0 {
+		return nil
+	}
+	blockers, err := tc.repository.GetTasksByIDs(task.BlockedBy)
+	if err != nil {
+		return err
+	}
+	open := 0
+	for _, blocker := range *blockers {
+		if !domain.IsDone(blocker.Status) {
+			open++
+		}
+	}
+	if open > 0 {
+		return fmt.Errorf("task is blocked by %d open task(s)", open)
+	}
+	return nil
+}
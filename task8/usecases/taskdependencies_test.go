@@ -0,0 +1,103 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskDependencies(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: new(mocks.UserRepositoryInterface), Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	ownerID := primitive.NewObjectID()
+	design := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Title: "Design", Status: "todo"}
+	build := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Title: "Build", Status: "todo"}
+	ship := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Title: "Ship", Status: "todo"}
+	for _, task := range []*domain.Task{design, build, ship} {
+		mockRepo.On("GetTask", task.ID.Hex()).Return(task, nil).Maybe()
+	}
+
+	t.Run("build waits on design", func(t *testing.T) {
+		mockRepo.On("GetBlockers", design.ID.Hex()).Return(&[]domain.Task{}, nil).Twice()
+		mockRepo.On("AddBlocker", build.ID.Hex(), design.ID.Hex()).Return(nil).Once()
+
+		_, err := taskUsecase.AddBlocker(build.ID.Hex(), design.ID.Hex(), ownerID.Hex())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("a task cannot block itself", func(t *testing.T) {
+		_, err := taskUsecase.AddBlocker(build.ID.Hex(), build.ID.Hex(), ownerID.Hex())
+
+		assert.EqualError(t, err, "a task cannot block itself")
+	})
+
+	t.Run("rejects a cycle", func(t *testing.T) {
+		// ship waits on build, which waits on design: design cannot wait on ship.
+		mockRepo.On("GetBlockers", ship.ID.Hex()).Return(&[]domain.Task{*build, *design}, nil).Once()
+
+		_, err := taskUsecase.AddBlocker(design.ID.Hex(), ship.ID.Hex(), ownerID.Hex())
+
+		assert.EqualError(t, err, "the dependency would create a cycle")
+	})
+
+	t.Run("takes back a cycle closed concurrently", func(t *testing.T) {
+		mockRepo.On("GetBlockers", build.ID.Hex()).Return(&[]domain.Task{}, nil).Once()
+		mockRepo.On("AddBlocker", ship.ID.Hex(), build.ID.Hex()).Return(nil).Once()
+		mockRepo.On("GetBlockers", build.ID.Hex()).Return(&[]domain.Task{*ship}, nil).Once()
+		mockRepo.On("RemoveBlocker", ship.ID.Hex(), build.ID.Hex()).Return(nil).Once()
+
+		_, err := taskUsecase.AddBlocker(ship.ID.Hex(), build.ID.Hex(), ownerID.Hex())
+
+		assert.EqualError(t, err, "the dependency would create a cycle")
+	})
+
+	t.Run("cannot be done while a blocker is open", func(t *testing.T) {
+		blocked := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Status: "todo", BlockedBy: []primitive.ObjectID{design.ID, ship.ID}}
+		mockRepo.On("GetTask", blocked.ID.Hex()).Return(blocked, nil)
+		doneShip := *ship
+		doneShip.Status = "done"
+		mockRepo.On("GetTasksByIDs", blocked.BlockedBy).Return(&[]domain.Task{*design, doneShip}, nil)
+
+		err := taskUsecase.UpdateTask(blocked.ID.Hex(), &domain.Task{Title: "Blocked", Status: "done"}, ownerID.Hex())
+		assert.EqualError(t, err, "task is blocked by 1 open task(s)")
+
+		_, err = taskUsecase.MoveTask(blocked.ID.Hex(), domain.TaskMove{Status: "done"}, ownerID.Hex())
+		assert.EqualError(t, err, "task is blocked by 1 open task(s)")
+	})
+
+	t.Run("graph hides tasks the user cannot read", func(t *testing.T) {
+		private := domain.Task{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), Title: "Secret", Status: "todo"}
+		root := *build
+		root.BlockedBy = []primitive.ObjectID{design.ID, private.ID}
+		shipping := *ship
+		shipping.BlockedBy = []primitive.ObjectID{build.ID}
+		mockRepo.On("GetDependencyGraph", build.ID.Hex()).Return(&[]domain.Task{root, *design, private, shipping}, nil).Once()
+
+		graph, err := taskUsecase.GetDependencyGraph(build.ID.Hex(), ownerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, build.ID, graph.Root)
+		assert.Equal(t, []domain.GraphNode{
+			{ID: build.ID, Title: "Build", Status: "todo"},
+			{ID: design.ID, Title: "Design", Status: "todo"},
+			{ID: private.ID, Hidden: true},
+			{ID: ship.ID, Title: "Ship", Status: "todo"},
+		}, graph.Nodes)
+		assert.Equal(t, []domain.GraphEdge{
+			{From: design.ID, To: build.ID},
+			{From: private.ID, To: build.ID},
+			{From: build.ID, To: ship.ID},
+		}, graph.Edges)
+	})
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"errors"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultUpcomingDays = 7
+	maxUpcomingDays     = 90
+	// overdueCatchUp is how far back PublishOverdue looks, which covers the
+	// time the server was down, within reason.
+	overdueCatchUp = 24 * time.Hour
+)
+
+// GetDueTasks returns the user's open tasks due today, in the days after
+// today or before now, with days counted in the user's timezone.
+func (tc *TaskUsecase) GetDueTasks(userID string, view string, days int) (*[]domain.Task, error) {
+
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	window, err := dueWindow(view, time.Now().In(userLocation(tc.users, userID)), days)
+	if err != nil {
+		return nil, err
+	}
+	return tc.repository.GetDueTasks(userID, window)
+}
+
+// dueWindow computes a view's window from the wall clock at now. Days are
+// stepped with time.Date, so a day the clocks change on is 23 or 25 hours
+// long as it should be. days only applies to the upcoming view, where 0
+// means a week.
+func dueWindow(view string, now time.Time, days int) (domain.DueWindow, error) {
+
+	year, month, day := now.Date()
+	midnight := func(offset int) time.Time {
+		return time.Date(year, month, day+offset, 0, 0, 0, 0, now.Location())
+	}
+	today := domain.Day(now)
+
+	switch view {
+	case domain.DueToday:
+		return domain.DueWindow{From: midnight(0), To: midnight(1), FromDay: today, ToDay: today.AddDate(0, 0, 1)}, nil
+	case domain.DueUpcoming:
+		if days == 0 {
+			days = defaultUpcomingDays
+		}
+		if days < 1 || days > maxUpcomingDays {
+			return domain.DueWindow{}, errors.New("days must be between 1 and 90")
+		}
+		return domain.DueWindow{From: midnight(1), To: midnight(1 + days), FromDay: today.AddDate(0, 0, 1), ToDay: today.AddDate(0, 0, 1+days)}, nil
+	case domain.DueOverdue:
+		return domain.DueWindow{To: now, ToDay: today}, nil
+	}
+	return domain.DueWindow{}, errors.New("view must be today, upcoming or overdue")
+}
+
+// PublishOverdue publishes task.overdue for the open tasks that fell
+// overdue in the day before now, once for each due date. Date-only tasks
+// fall overdue when their day has ended in UTC.
+func (tc *TaskUsecase) PublishOverdue(now time.Time) (int, error) {
+
+	since := now.Add(-overdueCatchUp)
+	window := domain.DueWindow{From: since, To: now, FromDay: domain.Day(since.UTC()), ToDay: domain.Day(now.UTC())}
+	tasks, err := tc.repository.GetNewlyOverdue(window)
+	if err != nil {
+		return 0, err
+	}
+	published := 0
+	for i := range *tasks {
+		task := &(*tasks)[i]
+		if err := tc.repository.MarkOverdue(task.ID.Hex(), task.DueDate); err != nil {
+			return published, err
+		}
+		task.OverdueFor = task.DueDate
+		tc.events.Publish(domain.EventTaskOverdue, task)
+		published++
+	}
+	return published, nil
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"task8/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDueWindow(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	// A Sunday morning; clocks went forward at 2:00 that night.
+	now := time.Date(2024, time.March, 10, 9, 30, 0, 0, newYork)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	local := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, newYork)
+	}
+
+	tests := []struct {
+		name string
+		view string
+		days int
+		want domain.DueWindow
+	}{
+		{"today is 23 hours long", domain.DueToday, 0, domain.DueWindow{From: local(3, 10), To: local(3, 11), FromDay: date(3, 10), ToDay: date(3, 11)}},
+		{"upcoming defaults to a week", domain.DueUpcoming, 0, domain.DueWindow{From: local(3, 11), To: local(3, 18), FromDay: date(3, 11), ToDay: date(3, 18)}},
+		{"upcoming days", domain.DueUpcoming, 30, domain.DueWindow{From: local(3, 11), To: local(4, 10), FromDay: date(3, 11), ToDay: date(4, 10)}},
+		{"overdue", domain.DueOverdue, 0, domain.DueWindow{To: now, ToDay: date(3, 10)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dueWindow(tt.view, now, tt.days)
+
+			assert.NoError(t, err)
+			assert.True(t, tt.want.From.Equal(got.From), "from %v, want %v", got.From, tt.want.From)
+			assert.True(t, tt.want.To.Equal(got.To), "to %v, want %v", got.To, tt.want.To)
+			assert.Equal(t, tt.want.FromDay, got.FromDay)
+			assert.Equal(t, tt.want.ToDay, got.ToDay)
+		})
+	}
+
+	window, _ := dueWindow(domain.DueToday, now, 0)
+	assert.Equal(t, 23*time.Hour, window.To.Sub(window.From))
+
+	// Late in the evening the UTC date has moved on, but today has not.
+	window, _ = dueWindow(domain.DueToday, time.Date(2024, time.March, 10, 22, 0, 0, 0, newYork), 0)
+	assert.Equal(t, date(3, 10), window.FromDay)
+
+	_, err := dueWindow(domain.DueUpcoming, now, 91)
+	assert.EqualError(t, err, "days must be between 1 and 90")
+	_, err = dueWindow(domain.DueUpcoming, now, -1)
+	assert.Error(t, err)
+	_, err = dueWindow("someday", now, 0)
+	assert.EqualError(t, err, "view must be today, upcoming or overdue")
+}
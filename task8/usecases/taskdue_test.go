@@ -0,0 +1,129 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetDueTasks(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	userID := primitive.NewObjectID().Hex()
+
+	t.Run("today in the user's timezone", func(t *testing.T) {
+		tokyo, _ := time.LoadLocation("Asia/Tokyo")
+		now := time.Now().In(tokyo)
+		midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, tokyo)
+		mockUsers.On("GetUserByID", userID).Return(&domain.User{Timezone: "Asia/Tokyo"}, nil).Once()
+		mockRepo.On("GetDueTasks", userID, mock.MatchedBy(func(w domain.DueWindow) bool {
+			return w.From.Equal(midnight) && w.To.Equal(midnight.AddDate(0, 0, 1)) && w.FromDay.Equal(domain.Day(now))
+		})).Return(&[]domain.Task{{Title: "Call"}}, nil).Once()
+
+		tasks, err := taskUsecase.GetDueTasks(userID, domain.DueToday, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, *tasks, 1)
+	})
+
+	t.Run("bad input", func(t *testing.T) {
+		mockUsers.On("GetUserByID", userID).Return(&domain.User{}, nil).Once()
+
+		_, err := taskUsecase.GetDueTasks(userID, domain.DueUpcoming, 365)
+		assert.EqualError(t, err, "days must be between 1 and 90")
+
+		_, err = taskUsecase.GetDueTasks("invalid", domain.DueToday, 0)
+		assert.EqualError(t, err, "user ID is not a valid ObjectID")
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockUsers.AssertExpectations(t)
+}
+
+func TestCreateTask_DateOnly(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	userID := primitive.NewObjectID().Hex()
+
+	t.Run("a day in words is date-only", func(t *testing.T) {
+		tokyo, _ := time.LoadLocation("Asia/Tokyo")
+		tomorrow := time.Now().In(tokyo).AddDate(0, 0, 1)
+		mockUsers.On("GetUserByID", userID).Return(&domain.User{Timezone: "Asia/Tokyo"}, nil).Once()
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("CreateTask", mock.MatchedBy(func(task *domain.Task) bool {
+			return task.DueDateOnly && task.DueDate.Equal(time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 0, 0, 0, 0, time.UTC))
+		}), userID).Return(nil).Once()
+
+		err := taskUsecase.CreateTask(&domain.Task{Title: "Call", Description: "Call", Status: "todo", DueText: "tomorrow"}, userID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("midnight in words has a time", func(t *testing.T) {
+		mockUsers.On("GetUserByID", userID).Return(&domain.User{}, nil).Once()
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("CreateTask", mock.MatchedBy(func(task *domain.Task) bool {
+			return !task.DueDateOnly && task.DueDate.Hour() == 0
+		}), userID).Return(nil).Once()
+
+		err := taskUsecase.CreateTask(&domain.Task{Title: "Deploy", Description: "Deploy", Status: "todo", DueText: "tomorrow 0:00"}, userID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("date-only keeps the date", func(t *testing.T) {
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("CreateTask", mock.MatchedBy(func(task *domain.Task) bool {
+			return task.DueDateOnly && task.DueDate.Equal(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))
+		}), userID).Return(nil).Once()
+
+		berlin, _ := time.LoadLocation("Europe/Berlin")
+		err := taskUsecase.CreateTask(&domain.Task{Title: "Pay", Description: "Pay", Status: "todo", DueDate: time.Date(2024, 5, 2, 0, 0, 0, 0, berlin), DueDateOnly: true}, userID)
+
+		assert.NoError(t, err)
+	})
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPublishOverdue(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: new(mocks.UserRepositoryInterface), Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	now := time.Date(2026, time.October, 19, 9, 30, 0, 0, time.UTC)
+	task := domain.Task{ID: primitive.NewObjectID(), Title: "Call", DueDate: now.Add(-time.Hour)}
+	mockRepo.On("GetNewlyOverdue", mock.MatchedBy(func(w domain.DueWindow) bool {
+		return w.To.Equal(now) && w.From.Equal(now.Add(-24*time.Hour)) && w.ToDay.Equal(domain.Day(now))
+	})).Return(&[]domain.Task{task}, nil).Once()
+	mockRepo.On("MarkOverdue", task.ID.Hex(), task.DueDate).Return(nil).Once()
+	mockEvents.On("Publish", domain.EventTaskOverdue, mock.MatchedBy(func(published *domain.Task) bool {
+		return published.ID == task.ID && published.OverdueFor.Equal(task.DueDate)
+	})).Once()
+
+	published, err := taskUsecase.PublishOverdue(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, published)
+	mockRepo.AssertExpectations(t)
+	mockEvents.AssertExpectations(t)
+}
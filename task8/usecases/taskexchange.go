@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"task8/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const maxImportRows = 5000
+
+// ExportTasks hands every task of the user to each, one at a time. Tasks
+// without an external ID are exported with their own ID as one, so that
+// importing an export back updates them rather than copying them.
+func (tc *TaskUsecase) ExportTasks(userID string, each func(*domain.Task) error) error {
+
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	return tc.repository.StreamTasks(userID, func(task *domain.Task) error {
+		if task.ExternalID == "" {
+			task.ExternalID = task.ID.Hex()
+		}
+		return each(task)
+	})
+}
+
+// ImportTasks creates a task per record, or updates the one of the user
+// already carrying its external ID, so the same file can be imported again
+// without duplicating anything. Rows are checked like single tasks and fail
+// on their own. A dry run checks every row and writes nothing.
+func (tc *TaskUsecase) ImportTasks(records []domain.ImportRecord, userID string, dryRun bool) (*domain.ImportResult, error) {
+
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no tasks to import")
+	}
+	if len(records) > maxImportRows {
+		return nil, fmt.Errorf("an import can hold at most %d tasks", maxImportRows)
+	}
+
+	keys := []string{}
+	for i := range records {
+		records[i].Task.ExternalID = strings.TrimSpace(records[i].Task.ExternalID)
+		if records[i].Task.ExternalID != "" {
+			keys = append(keys, records[i].Task.ExternalID)
+		}
+	}
+	existing, err := tc.repository.GetImportedTasks(userID, keys)
+	if err != nil {
+		return nil, err
+	}
+	// An external ID wins over a task ID that happens to be the same.
+	known := map[string]*domain.Task{}
+	for i := range *existing {
+		known[(*existing)[i].ID.Hex()] = &(*existing)[i]
+	}
+	for i := range *existing {
+		if (*existing)[i].ExternalID != "" {
+			known[(*existing)[i].ExternalID] = &(*existing)[i]
+		}
+	}
+
+	result := &domain.ImportResult{DryRun: dryRun, Rows: make([]domain.ImportRow, 0, len(records))}
+	seen := map[string]int{}
+	for _, record := range records {
+		row := domain.ImportRow{Row: record.Row, ExternalID: record.Task.ExternalID}
+		action, id, err := tc.importRecord(record, known, seen, userID, dryRun)
+		if err != nil {
+			row.Action = domain.ImportFailed
+			row.Error = err.Error()
+			result.Failed++
+		} else {
+			row.Action = action
+			row.ID = id
+			if action == domain.ImportCreated {
+				result.Created++
+			} else {
+				result.Updated++
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
+
+func (tc *TaskUsecase) importRecord(record domain.ImportRecord, known map[string]*domain.Task, seen map[string]int, userID string, dryRun bool) (string, string, error) {
+
+	if record.Error != "" {
+		return "", "", errors.New(record.Error)
+	}
+	key := record.Task.ExternalID
+	if key == "" {
+		return "", "", errors.New("external_id is required")
+	}
+	if row, ok := seen[key]; ok {
+		return "", "", fmt.Errorf("external_id is already used on row %d", row)
+	}
+	seen[key] = record.Row
+
+	task := record.Task
+	if stored, ok := known[key]; ok {
+		// The _id in the file may be another user's or instance's; the
+		// external_id is what matched.
+		task.ID = stored.ID
+		id := stored.ID.Hex()
+		if dryRun {
+			_, err := tc.prepareUpdate(id, &task, userID)
+			return domain.ImportUpdated, id, err
+		}
+		_, err := tc.updateTask(id, &task, userID)
+		return domain.ImportUpdated, id, err
+	}
+
+	task.ID = primitive.NilObjectID
+	if dryRun {
+		return domain.ImportCreated, "", tc.prepareCreate(&task, userID)
+	}
+	if err := tc.CreateTask(&task, userID); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return "", "", errors.New("a task with this external_id was imported meanwhile")
+		}
+		return "", "", err
+	}
+	return domain.ImportCreated, task.ID.Hex(), nil
+}
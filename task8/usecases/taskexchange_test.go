@@ -0,0 +1,135 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestExportTasks(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: new(mocks.UserRepositoryInterface), Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: new(mocks.CustomFieldRepositoryInterface), Events: new(mocks.EventPublisher)})
+	userID := primitive.NewObjectID().Hex()
+	plain := &domain.Task{ID: primitive.NewObjectID()}
+	imported := &domain.Task{ID: primitive.NewObjectID(), ExternalID: "jira-1"}
+
+	mockRepo.On("StreamTasks", userID, mock.Anything).Return(func(userid string, each func(*domain.Task) error) error {
+		for _, task := range []*domain.Task{plain, imported} {
+			if err := each(task); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Once()
+
+	keys := []string{}
+	err := taskUsecase.ExportTasks(userID, func(task *domain.Task) error {
+		keys = append(keys, task.ExternalID)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{plain.ID.Hex(), "jira-1"}, keys)
+}
+
+func TestImportTasks(t *testing.T) {
+	ownerID := primitive.NewObjectID()
+	stored := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, Title: "Old", Description: "d", Status: "todo", ExternalID: "jira-1"}
+
+	setup := func() (*usecases.TaskUsecase, *mocks.TaskRepositoryInterface) {
+		mockRepo := new(mocks.TaskRepositoryInterface)
+		mockEvents := new(mocks.EventPublisher)
+		mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+		mockRepo.On("GetTask", stored.ID.Hex()).Return(stored, nil).Maybe()
+		mockRepo.On("GetImportedTasks", ownerID.Hex(), mock.Anything).Return(&[]domain.Task{*stored}, nil).Once()
+		taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: new(mocks.UserRepositoryInterface), Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+		return taskUsecase, mockRepo
+	}
+	records := []domain.ImportRecord{
+		{Row: 1, Task: domain.Task{ExternalID: " jira-1 ", Title: "Renamed", Description: "d", Status: "doing"}},
+		{Row: 2, Task: domain.Task{ExternalID: "jira-2", Title: "New", Description: "d", Status: "todo"}},
+		{Row: 3, Task: domain.Task{ExternalID: "jira-2", Title: "Again", Description: "d", Status: "todo"}},
+		{Row: 4, Task: domain.Task{Title: "No key", Description: "d", Status: "todo"}},
+		{Row: 5, Error: "duedate: cannot parse"},
+		{Row: 6, Task: domain.Task{ExternalID: "jira-6", Title: "Incomplete"}},
+	}
+
+	t.Run("creates new keys and updates known ones", func(t *testing.T) {
+		taskUsecase, mockRepo := setup()
+		mockRepo.On("UpdateTask", stored.ID.Hex(), mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Title == "Renamed" && task.ExternalID == "jira-1"
+		})).Return(nil).Once()
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("CreateTask", mock.MatchedBy(func(task *domain.Task) bool {
+			return task.ExternalID == "jira-2"
+		}), ownerID.Hex()).Return(nil).Once()
+
+		result, err := taskUsecase.ImportTasks(append([]domain.ImportRecord{}, records...), ownerID.Hex(), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, 4, result.Failed)
+		assert.Equal(t, domain.ImportUpdated, result.Rows[0].Action)
+		assert.Equal(t, stored.ID.Hex(), result.Rows[0].ID)
+		assert.Equal(t, domain.ImportCreated, result.Rows[1].Action)
+		assert.Equal(t, "external_id is already used on row 2", result.Rows[2].Error)
+		assert.Equal(t, "external_id is required", result.Rows[3].Error)
+		assert.Equal(t, "duedate: cannot parse", result.Rows[4].Error)
+		assert.Equal(t, "incomplete information", result.Rows[5].Error)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		taskUsecase, mockRepo := setup()
+
+		result, err := taskUsecase.ImportTasks(append([]domain.ImportRecord{}, records...), ownerID.Hex(), true)
+
+		assert.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 1, result.Updated)
+		mockRepo.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a concurrent import of the same key", func(t *testing.T) {
+		taskUsecase, mockRepo := setup()
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("CreateTask", mock.Anything, ownerID.Hex()).Return(mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}).Once()
+
+		result, err := taskUsecase.ImportTasks([]domain.ImportRecord{records[1]}, ownerID.Hex(), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "a task with this external_id was imported meanwhile", result.Rows[0].Error)
+	})
+
+	t.Run("a foreign _id is matched by external_id", func(t *testing.T) {
+		taskUsecase, mockRepo := setup()
+		mockRepo.On("UpdateTask", stored.ID.Hex(), mock.MatchedBy(func(task *domain.Task) bool {
+			return task.ID == stored.ID && task.Title == "Renamed"
+		})).Return(nil).Once()
+		foreign := domain.ImportRecord{Row: 1, Task: domain.Task{ID: primitive.NewObjectID(), ExternalID: "jira-1", Title: "Renamed", Description: "d", Status: "doing"}}
+
+		result, err := taskUsecase.ImportTasks([]domain.ImportRecord{foreign}, ownerID.Hex(), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, stored.ID.Hex(), result.Rows[0].ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty import", func(t *testing.T) {
+		taskUsecase, _ := setup()
+
+		_, err := taskUsecase.ImportTasks(nil, ownerID.Hex(), false)
+
+		assert.EqualError(t, err, "no tasks to import")
+	})
+}
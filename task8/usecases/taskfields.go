@@ -0,0 +1,187 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"task8/domain"
+	"task8/infrastructure/naturaldate"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const fieldSortPrefix = "field."
+
+// taskSorts are the task fields GET /tasks sorts by, besides custom fields.
+var taskSorts = []string{"title", "due_date", "created_at"}
+
+// customFields are the custom fields defined for the tasks of the owner, by
+// key: those of the workspace, or the owner's own in the personal one.
+func (tc *TaskUsecase) customFields(ownerID string) (map[string]domain.CustomField, error) {
+
+	fields, err := tc.fields.GetFields(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]domain.CustomField, len(*fields))
+	for _, field := range *fields {
+		byKey[field.Key] = field
+	}
+	return byKey, nil
+}
+
+// checkFields validates the custom field values of a task and stores each
+// as its type does. Dates are read in the caller's timezone and "me" is the
+// caller; a null value leaves the field out.
+func (tc *TaskUsecase) checkFields(task *domain.Task, ownerID string, userID string) error {
+
+	if len(task.Fields) == 0 {
+		return nil
+	}
+	fields, err := tc.customFields(ownerID)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]interface{}, len(task.Fields))
+	for key, value := range task.Fields {
+		field, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("unknown field %s", key)
+		}
+		if value == nil {
+			continue
+		}
+		stored, err := tc.fieldValue(field, value, userID)
+		if err != nil {
+			return err
+		}
+		if field.Type == domain.FieldUser {
+			if _, err := tc.users.GetUserByID(stored.(primitive.ObjectID).Hex()); err != nil {
+				return fmt.Errorf("%s: user not found", key)
+			}
+		}
+		values[key] = stored
+	}
+	task.Fields = values
+	return nil
+}
+
+// fieldValue reads a value given for a custom field as its type stores it.
+// Values read back from the database are taken as they are.
+func (tc *TaskUsecase) fieldValue(field domain.CustomField, value interface{}, userID string) (interface{}, error) {
+
+	switch field.Type {
+	case domain.FieldText:
+		if text, ok := value.(string); ok {
+			return strings.TrimSpace(text), nil
+		}
+		return nil, fmt.Errorf("%s must be text", field.Key)
+
+	case domain.FieldNumber:
+		switch number := value.(type) {
+		case float64:
+			return number, nil
+		case int32:
+			return float64(number), nil
+		case int64:
+			return float64(number), nil
+		case int:
+			return float64(number), nil
+		}
+		return nil, fmt.Errorf("%s must be a number", field.Key)
+
+	case domain.FieldDate:
+		switch date := value.(type) {
+		case string:
+			day, err := naturaldate.Parse(date, time.Now().In(userLocation(tc.users, userID)))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", field.Key, err)
+			}
+			return domain.Day(day), nil
+		case time.Time:
+			return domain.Day(date.UTC()), nil
+		case primitive.DateTime:
+			return domain.Day(date.Time().UTC()), nil
+		}
+		return nil, fmt.Errorf("%s must be a date", field.Key)
+
+	case domain.FieldEnum:
+		if option, ok := value.(string); ok && slices.Contains(field.Options, option) {
+			return option, nil
+		}
+		return nil, fmt.Errorf("%s must be one of %s", field.Key, strings.Join(field.Options, ", "))
+
+	case domain.FieldUser:
+		switch user := value.(type) {
+		case primitive.ObjectID:
+			return user, nil
+		case string:
+			if user == domain.AssigneeMe {
+				user = userID
+			}
+			if id, err := primitive.ObjectIDFromHex(user); err == nil {
+				return id, nil
+			}
+		}
+		return nil, fmt.Errorf("%s must be me or a user ID", field.Key)
+	}
+	return nil, fmt.Errorf("%s has an unknown type", field.Key)
+}
+
+// resolveFields resolves the custom field filters of a filter, and checks
+// that a custom field it sorts by exists.
+func (tc *TaskUsecase) resolveFields(filter *domain.TaskFilter, userID string) error {
+
+	sortField, sortsByField := strings.CutPrefix(strings.TrimPrefix(filter.Sort, "-"), fieldSortPrefix)
+	if len(filter.Fields) == 0 && !sortsByField {
+		return nil
+	}
+	fields, err := tc.customFields(userID)
+	if err != nil {
+		return err
+	}
+	if _, ok := fields[sortField]; sortsByField && !ok {
+		return fmt.Errorf("unknown field %s", sortField)
+	}
+	filter.FieldValues = make(map[string]interface{}, len(filter.Fields))
+	for key, text := range filter.Fields {
+		field, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("unknown field %s", key)
+		}
+		switch field.Type {
+		case domain.FieldText:
+			filter.FieldValues[key] = primitive.Regex{Pattern: regexp.QuoteMeta(text), Options: "i"}
+		case domain.FieldNumber:
+			number, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return fmt.Errorf("%s must be a number", key)
+			}
+			filter.FieldValues[key] = number
+		default:
+			value, err := tc.fieldValue(field, text, userID)
+			if err != nil {
+				return err
+			}
+			filter.FieldValues[key] = value
+		}
+	}
+	return nil
+}
+
+// checkTaskSort rejects a sort GET /tasks does not know.
+func checkTaskSort(sort string) error {
+
+	name := strings.TrimPrefix(sort, "-")
+	if sort == "" || slices.Contains(taskSorts, name) {
+		return nil
+	}
+	if key, ok := strings.CutPrefix(name, fieldSortPrefix); ok && key != "" {
+		return nil
+	}
+	return errors.New("sort must be title, due_date, created_at or field.<key>, with - for descending")
+}
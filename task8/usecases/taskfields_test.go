@@ -0,0 +1,145 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var testFields = []domain.CustomField{
+	{Key: "customer", Type: domain.FieldText},
+	{Key: "estimate", Type: domain.FieldNumber},
+	{Key: "launch", Type: domain.FieldDate},
+	{Key: "sprint", Type: domain.FieldEnum, Options: []string{"24", "25"}},
+	{Key: "reviewer", Type: domain.FieldUser},
+}
+
+func TestTaskCustomFields(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockFields := new(mocks.CustomFieldRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: mockUsers, Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: mockFields, Events: mockEvents})
+
+	userID := primitive.NewObjectID()
+	reviewerID := primitive.NewObjectID()
+	mockFields.On("GetFields", userID.Hex()).Return(&testFields, nil)
+
+	t.Run("stores each value as its type", func(t *testing.T) {
+		mockUsers.On("GetUserByID", userID.Hex()).Return(&domain.User{ID: userID, Timezone: "Asia/Tokyo"}, nil).Once()
+		mockUsers.On("GetUserByID", reviewerID.Hex()).Return(&domain.User{ID: reviewerID}, nil).Once()
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("CreateTask", mock.MatchedBy(func(task *domain.Task) bool {
+			return assert.ObjectsAreEqual(map[string]interface{}{
+				"customer": "Acme",
+				"estimate": 3.5,
+				"launch":   time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+				"sprint":   "25",
+				"reviewer": reviewerID,
+			}, task.Fields)
+		}), userID.Hex()).Return(nil).Once()
+
+		err := taskUsecase.CreateTask(&domain.Task{Title: "Ship", Description: "Ship", Status: "todo", Fields: map[string]interface{}{
+			"customer": " Acme ",
+			"estimate": 3.5,
+			"launch":   "2026-11-02",
+			"sprint":   "25",
+			"reviewer": reviewerID.Hex(),
+		}}, userID.Hex())
+
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+		err    string
+	}{
+		{"unknown field", map[string]interface{}{"owner": nil}, "unknown field owner"},
+		{"not a number", map[string]interface{}{"estimate": "three"}, "estimate must be a number"},
+		{"not an option", map[string]interface{}{"sprint": "26"}, "sprint must be one of 24, 25"},
+		{"not a user", map[string]interface{}{"reviewer": "bob"}, "reviewer must be me or a user ID"},
+		{"not text", map[string]interface{}{"customer": 7.0}, "customer must be text"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := taskUsecase.CreateTask(&domain.Task{Title: "Ship", Description: "Ship", Status: "todo", Fields: test.fields}, userID.Hex())
+
+			assert.EqualError(t, err, test.err)
+		})
+	}
+
+	t.Run("an update without fields keeps them", func(t *testing.T) {
+		taskID := primitive.NewObjectID()
+		stored := map[string]interface{}{"estimate": 3.0, "launch": primitive.NewDateTimeFromTime(time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC))}
+		mockRepo.On("GetTask", taskID.Hex()).Return(&domain.Task{ID: taskID, UserID: userID, Status: "todo", Fields: stored}, nil).Once()
+		mockRepo.On("UpdateTask", taskID.Hex(), mock.MatchedBy(func(task *domain.Task) bool {
+			return assert.ObjectsAreEqual(stored, task.Fields)
+		})).Return(nil).Once()
+
+		err := taskUsecase.UpdateTask(taskID.Hex(), &domain.Task{Title: "Ship", Description: "Ship", Status: "todo"}, userID.Hex())
+
+		assert.NoError(t, err)
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockUsers.AssertExpectations(t)
+}
+
+func TestFilterTasks_CustomFields(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockFields := new(mocks.CustomFieldRepositoryInterface)
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: mockUsers, Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: mockFields, Events: new(mocks.EventPublisher)})
+
+	userID := primitive.NewObjectID()
+	mockFields.On("GetFields", userID.Hex()).Return(&testFields, nil)
+
+	t.Run("reads the values by type", func(t *testing.T) {
+		mockRepo.On("FilterTasks", userID.Hex(), mock.MatchedBy(func(filter domain.TaskFilter) bool {
+			return assert.ObjectsAreEqual(map[string]interface{}{
+				"customer": primitive.Regex{Pattern: `acme\.com`, Options: "i"},
+				"estimate": 8.0,
+				"reviewer": userID,
+			}, filter.FieldValues) && filter.Sort == "-field.estimate"
+		})).Return(&[]domain.Task{}, nil).Once()
+
+		_, err := taskUsecase.FilterTasks(userID.Hex(), domain.TaskFilter{
+			Fields: map[string]string{"customer": "acme.com", "estimate": "8", "reviewer": "me"},
+			Sort:   " -field.estimate ",
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown sort field", func(t *testing.T) {
+		_, err := taskUsecase.FilterTasks(userID.Hex(), domain.TaskFilter{Sort: "field.owner"})
+
+		assert.EqualError(t, err, "unknown field owner")
+	})
+
+	t.Run("unknown sort", func(t *testing.T) {
+		_, err := taskUsecase.FilterTasks(userID.Hex(), domain.TaskFilter{Sort: "rank"})
+
+		assert.EqualError(t, err, "sort must be title, due_date, created_at or field.<key>, with - for descending")
+	})
+
+	t.Run("a sort without fields does not look them up", func(t *testing.T) {
+		mockRepo.On("CountTasks", userID.Hex(), mock.Anything).Return(int64(2), nil).Once()
+
+		count, err := taskUsecase.CountTasks(userID.Hex(), domain.TaskFilter{Sort: "title"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockFields.AssertNumberOfCalls(t, "GetFields", 2)
+}
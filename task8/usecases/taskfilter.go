@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"task8/domain"
+	"task8/infrastructure/naturaldate"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (tc *TaskUsecase) FilterTasks(userID string, filter domain.TaskFilter) (*[]domain.Task, error) {
+
+	if err := tc.resolveFilter(&filter, userID); err != nil {
+		return nil, err
+	}
+	return tc.repository.FilterTasks(userID, filter)
+}
+
+func (tc *TaskUsecase) CountTasks(userID string, filter domain.TaskFilter) (int64, error) {
+
+	if err := tc.resolveFilter(&filter, userID); err != nil {
+		return 0, err
+	}
+	return tc.repository.CountTasks(userID, filter)
+}
+
+// resolveFilter checks a filter and resolves what depends on the caller:
+// "me" as the assignee, the custom fields and the due range, read in the
+// user's timezone.
+func (tc *TaskUsecase) resolveFilter(filter *domain.TaskFilter, userID string) error {
+
+	if err := checkTaskFilter(filter); err != nil {
+		return err
+	}
+	if filter.AssigneeID == domain.AssigneeMe {
+		filter.AssigneeID = userID
+	}
+	if err := tc.resolveFields(filter, userID); err != nil {
+		return err
+	}
+	if filter.DueFrom != "" || filter.DueTo != "" {
+		window, err := filterDue(*filter, time.Now().In(userLocation(tc.users, userID)))
+		if err != nil {
+			return err
+		}
+		filter.Due = window
+	}
+	return nil
+}
+
+// checkTaskFilter normalizes a filter and rejects one that cannot run.
+func checkTaskFilter(filter *domain.TaskFilter) error {
+
+	filter.Tags = normalizeTags(filter.Tags)
+	if filter.Statuses != nil {
+		statuses := []string{}
+		for _, status := range filter.Statuses {
+			if status = strings.TrimSpace(status); status != "" {
+				statuses = append(statuses, status)
+			}
+		}
+		filter.Statuses = statuses
+	}
+	filter.Text = strings.TrimSpace(filter.Text)
+	for i, priority := range filter.Priorities {
+		if filter.Priorities[i] = normalizePriority(priority); !slices.Contains(domain.Priorities, filter.Priorities[i]) {
+			return errPriority
+		}
+	}
+	switch filter.AssigneeID {
+	case "", domain.AssigneeMe, domain.AssigneeNone:
+	default:
+		if _, err := primitive.ObjectIDFromHex(filter.AssigneeID); err != nil {
+			return errors.New("assignee must be me, none or a user ID")
+		}
+	}
+	if _, err := filterDue(*filter, time.Now()); err != nil {
+		return err
+	}
+	filter.Sort = strings.TrimSpace(filter.Sort)
+	if err := checkTaskSort(filter.Sort); err != nil {
+		return err
+	}
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return errors.New("limit and offset must not be negative")
+	}
+	return nil
+}
+
+// filterDue reads the due range of a filter at now. A DueTo naming a day,
+// which resolves to its midnight, runs to the end of that day.
+func filterDue(filter domain.TaskFilter, now time.Time) (domain.DueWindow, error) {
+
+	var window domain.DueWindow
+	if filter.DueFrom != "" {
+		from, err := naturaldate.Parse(filter.DueFrom, now)
+		if err != nil {
+			return window, err
+		}
+		window.From = from
+		window.FromDay = domain.Day(from)
+	}
+	if filter.DueTo != "" {
+		to, err := naturaldate.Parse(filter.DueTo, now)
+		if err != nil {
+			return window, err
+		}
+		year, month, day := to.Date()
+		if to.Hour() == 0 && to.Minute() == 0 {
+			to = time.Date(year, month, day+1, 0, 0, 0, 0, to.Location())
+		}
+		window.To = to
+		window.ToDay = time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+	}
+	if !window.From.IsZero() && !window.To.IsZero() && !window.From.Before(window.To) {
+		return window, errors.New("due_from must be before due_to")
+	}
+	return window, nil
+}
@@ -0,0 +1,60 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFilterTasks_Resolve(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: mockUsers, Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	userID := primitive.NewObjectID()
+
+	t.Run("reads the due range in the user's timezone", func(t *testing.T) {
+		mockUsers.On("GetUserByID", userID.Hex()).Return(&domain.User{ID: userID, Timezone: "America/New_York"}, nil).Once()
+		mockRepo.On("FilterTasks", userID.Hex(), mock.MatchedBy(func(filter domain.TaskFilter) bool {
+			newYork, _ := time.LoadLocation("America/New_York")
+			return filter.AssigneeID == userID.Hex() &&
+				filter.Due.From.Equal(time.Date(2026, 11, 2, 0, 0, 0, 0, newYork)) &&
+				filter.Due.To.Equal(time.Date(2026, 11, 7, 0, 0, 0, 0, newYork)) &&
+				filter.Due.FromDay.Equal(time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)) &&
+				filter.Due.ToDay.Equal(time.Date(2026, 11, 7, 0, 0, 0, 0, time.UTC)) &&
+				filter.Limit == 20
+		})).Return(&[]domain.Task{}, nil).Once()
+
+		_, err := taskUsecase.FilterTasks(userID.Hex(), domain.TaskFilter{DueFrom: "2026-11-02", DueTo: "2026-11-06", AssigneeID: "me", Limit: 20})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid assignee", func(t *testing.T) {
+		_, err := taskUsecase.CountTasks(userID.Hex(), domain.TaskFilter{AssigneeID: "someone"})
+
+		assert.EqualError(t, err, "assignee must be me, none or a user ID")
+	})
+
+	t.Run("empty due range", func(t *testing.T) {
+		_, err := taskUsecase.FilterTasks(userID.Hex(), domain.TaskFilter{DueFrom: "2026-11-06", DueTo: "2026-11-02"})
+
+		assert.EqualError(t, err, "due_from must be before due_to")
+	})
+
+	t.Run("unreadable due date", func(t *testing.T) {
+		_, err := taskUsecase.FilterTasks(userID.Hex(), domain.TaskFilter{DueFrom: "someday"})
+
+		assert.EqualError(t, err, `could not read "someday" as a date`)
+	})
+	mockRepo.AssertExpectations(t)
+	mockUsers.AssertExpectations(t)
+}
@@ -0,0 +1,220 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultNextTasks = 10
+	maxNextTasks     = 100
+	maxNextWeight    = 10
+	// dueHorizon is how far ahead a due date starts to count; closer
+	// counts more, and overdue the most.
+	dueHorizon = 14 * 24 * time.Hour
+	// ageHorizon is the age at which waiting counts the most.
+	ageHorizon = 30 * 24 * time.Hour
+)
+
+// priorityValues are what the priority levels are worth. A task without a
+// priority counts as medium.
+var priorityValues = map[string]float64{
+	"":                    1.0 / 3,
+	domain.PriorityLow:    0,
+	domain.PriorityMedium: 1.0 / 3,
+	domain.PriorityHigh:   2.0 / 3,
+	domain.PriorityUrgent: 1,
+}
+
+// NextTasks ranks the open tasks the user created or is assigned by what
+// to do next: by priority, how close their due date is and how long they
+// have waited, against being blocked, as the user weighs them.
+func (tc *TaskUsecase) NextTasks(userID string, limit int) (*[]domain.NextTask, error) {
+
+	if limit == 0 {
+		limit = defaultNextTasks
+	}
+	if limit < 1 || limit > maxNextTasks {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxNextTasks)
+	}
+	user, err := tc.users.GetUserByID(userID)
+	if err != nil || user == nil {
+		return nil, errors.New("user not found")
+	}
+	weights := domain.DefaultNextWeights
+	if user.NextWeights != nil {
+		weights = *user.NextWeights
+	}
+	tasks, err := tc.repository.GetOpenTasks(userID)
+	if err != nil {
+		return nil, err
+	}
+	blockers, err := tc.openBlockers(*tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(timezoneOf(user))
+	ranked := make([]domain.NextTask, 0, len(*tasks))
+	for _, task := range *tasks {
+		ranked = append(ranked, scoreTask(task, weights, blockers[task.ID], now))
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Task.CreatedAt.Before(ranked[j].Task.CreatedAt)
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return &ranked, nil
+}
+
+// openBlockers counts the open tasks blocking each of the tasks, which are
+// open themselves; other blockers are looked up.
+func (tc *TaskUsecase) openBlockers(tasks []domain.Task) (map[primitive.ObjectID]int, error) {
+
+	open := make(map[primitive.ObjectID]bool, len(tasks))
+	for _, task := range tasks {
+		open[task.ID] = true
+	}
+	lookup := []primitive.ObjectID{}
+	for _, task := range tasks {
+		for _, id := range task.BlockedBy {
+			if !open[id] {
+				lookup = append(lookup, id)
+			}
+		}
+	}
+	if len(lookup) > 0 {
+		blockers, err := tc.repository.GetTasksByIDs(lookup)
+		if err != nil {
+			return nil, err
+		}
+		for _, blocker := range *blockers {
+			if !domain.IsDone(blocker.Status) {
+				open[blocker.ID] = true
+			}
+		}
+	}
+
+	counts := make(map[primitive.ObjectID]int)
+	for _, task := range tasks {
+		for _, id := range task.BlockedBy {
+			if open[id] {
+				counts[task.ID]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// scoreTask weighs the factors of a task at now, in the user's timezone.
+func scoreTask(task domain.Task, weights domain.NextWeights, blockers int, now time.Time) domain.NextTask {
+
+	priority := "no priority"
+	if task.Priority != "" {
+		priority = "priority " + task.Priority
+	}
+	due, dueReason := dueValue(task, now)
+	blocked, blockedReason := 0.0, "not blocked"
+	if blockers > 0 {
+		blocked, blockedReason = 1, "blocked by "+plural(blockers, "open task")
+	}
+	age := math.Min(1, now.Sub(task.CreatedAt).Hours()/ageHorizon.Hours())
+	if task.CreatedAt.IsZero() || age < 0 {
+		age = 0
+	}
+
+	factors := []domain.ScoreFactor{
+		scoreFactor(domain.FactorPriority, priorityValues[task.Priority], weights.Priority, priority),
+		scoreFactor(domain.FactorDue, due, weights.Due, dueReason),
+		scoreFactor(domain.FactorBlocked, blocked, -weights.Blocked, blockedReason),
+		scoreFactor(domain.FactorAge, age, weights.Age, "open for "+plural(int(now.Sub(task.CreatedAt).Hours()/24), "day")),
+	}
+	if task.CreatedAt.IsZero() {
+		factors[3].Reason = "age unknown"
+	}
+	score := 0.0
+	for _, factor := range factors {
+		score += factor.Points
+	}
+	return domain.NextTask{Task: task, Score: round2(score), Factors: factors}
+}
+
+// dueValue is how pressing the due date of a task is at now. A date-only
+// task is due at the end of its day.
+func dueValue(task domain.Task, now time.Time) (float64, string) {
+
+	if task.DueDate.IsZero() {
+		return 0, "no due date"
+	}
+	deadline := task.DueDate
+	if task.DueDateOnly {
+		year, month, day := task.DueDate.Date()
+		deadline = time.Date(year, month, day+1, 0, 0, 0, 0, now.Location())
+	}
+	left := deadline.Sub(now)
+	switch {
+	case left <= 0:
+		return 1, "overdue by " + describeDuration(-left)
+	case left >= dueHorizon:
+		return 0, "due in " + describeDuration(left)
+	}
+	return 1 - left.Hours()/dueHorizon.Hours(), "due in " + describeDuration(left)
+}
+
+// scoreFactor weighs the value of a factor; a negative weight counts it
+// against the task.
+func scoreFactor(factor string, value float64, weight float64, reason string) domain.ScoreFactor {
+	points := 0.0
+	if value != 0 {
+		points = round2(value * weight)
+	}
+	return domain.ScoreFactor{Factor: factor, Value: round2(value), Weight: math.Abs(weight), Points: points, Reason: reason}
+}
+
+// describeDuration reads a duration in hours under a day, in days above.
+func describeDuration(d time.Duration) string {
+	if d < 24*time.Hour {
+		return plural(int(math.Max(1, d.Hours())), "hour")
+	}
+	return plural(int(d.Hours()/24), "day")
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+var errPriority = errors.New("priority must be low, medium, high or urgent")
+
+// checkPriority normalizes the priority of a task, which may be left
+// empty.
+func checkPriority(task *domain.Task) error {
+
+	task.Priority = normalizePriority(task.Priority)
+	if task.Priority != "" && !slices.Contains(domain.Priorities, task.Priority) {
+		return errPriority
+	}
+	return nil
+}
+
+func normalizePriority(priority string) string {
+	return strings.ToLower(strings.TrimSpace(priority))
+}
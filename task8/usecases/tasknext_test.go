@@ -0,0 +1,122 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNextTasks(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: mockUsers, Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: new(mocks.CustomFieldRepositoryInterface), Events: new(mocks.EventPublisher)})
+
+	userID := primitive.NewObjectID().Hex()
+	now := time.Now().UTC()
+	doneBlocker := primitive.NewObjectID()
+	overdue := domain.Task{ID: primitive.NewObjectID(), Title: "Overdue", Priority: domain.PriorityUrgent, DueDate: now.Add(-49 * time.Hour), CreatedAt: now.AddDate(0, 0, -15)}
+	blocked := domain.Task{ID: primitive.NewObjectID(), Title: "Blocked", Priority: domain.PriorityHigh, BlockedBy: []primitive.ObjectID{overdue.ID}, CreatedAt: now.AddDate(0, 0, -1)}
+	unblocked := domain.Task{ID: primitive.NewObjectID(), Title: "Unblocked", BlockedBy: []primitive.ObjectID{doneBlocker}, CreatedAt: now.AddDate(0, 0, -30)}
+	open := &[]domain.Task{blocked, unblocked, overdue}
+
+	t.Run("ranks and explains the scores", func(t *testing.T) {
+		mockUsers.On("GetUserByID", userID).Return(&domain.User{}, nil).Once()
+		mockRepo.On("GetOpenTasks", userID).Return(open, nil).Once()
+		mockRepo.On("GetTasksByIDs", []primitive.ObjectID{doneBlocker}).Return(&[]domain.Task{{ID: doneBlocker, Status: "done"}}, nil).Once()
+
+		ranked, err := taskUsecase.NextTasks(userID, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, *ranked, 3)
+		first, second, last := (*ranked)[0], (*ranked)[1], (*ranked)[2]
+		assert.Equal(t, "Overdue", first.Task.Title)
+		assert.Equal(t, 7.5, first.Score)
+		assert.Equal(t, []string{"priority urgent", "overdue by 2 days", "not blocked", "open for 15 days"},
+			[]string{first.Factors[0].Reason, first.Factors[1].Reason, first.Factors[2].Reason, first.Factors[3].Reason})
+		assert.Equal(t, "Unblocked", second.Task.Title)
+		assert.Equal(t, domain.ScoreFactor{Factor: domain.FactorPriority, Value: 0.33, Weight: 3, Points: 1, Reason: "no priority"}, second.Factors[0])
+		assert.Equal(t, "not blocked", second.Factors[2].Reason)
+		assert.Equal(t, "Blocked", last.Task.Title)
+		assert.Equal(t, domain.ScoreFactor{Factor: domain.FactorBlocked, Value: 1, Weight: 5, Points: -5, Reason: "blocked by 1 open task"}, last.Factors[2])
+		assert.Less(t, last.Score, 0.0)
+	})
+
+	t.Run("with the user's weights", func(t *testing.T) {
+		mockUsers.On("GetUserByID", userID).Return(&domain.User{NextWeights: &domain.NextWeights{Age: 1}}, nil).Once()
+		mockRepo.On("GetOpenTasks", userID).Return(open, nil).Once()
+		mockRepo.On("GetTasksByIDs", mock.Anything).Return(&[]domain.Task{}, nil).Once()
+
+		ranked, err := taskUsecase.NextTasks(userID, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, *ranked, 2)
+		assert.Equal(t, "Unblocked", (*ranked)[0].Task.Title)
+		assert.Equal(t, 1.0, (*ranked)[0].Score)
+		assert.Equal(t, "Overdue", (*ranked)[1].Task.Title)
+	})
+
+	t.Run("bad input", func(t *testing.T) {
+		_, err := taskUsecase.NextTasks(userID, 101)
+		assert.EqualError(t, err, "limit must be between 1 and 100")
+
+		mockUsers.On("GetUserByID", "unknown").Return(nil, nil).Once()
+
+		_, err = taskUsecase.NextTasks("unknown", 5)
+		assert.EqualError(t, err, "user not found")
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockUsers.AssertExpectations(t)
+}
+
+func TestTaskPriority(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	mockUsers.On("GetUserByID", mock.Anything).Return(&domain.User{}, nil).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: mockUsers, Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	taskID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+
+	t.Run("normalizes the priority", func(t *testing.T) {
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("CreateTask", mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Priority == domain.PriorityHigh
+		}), userID.Hex()).Return(nil).Once()
+
+		err := taskUsecase.CreateTask(&domain.Task{Title: "Call", Description: "Call", Status: "todo", Priority: " High "}, userID.Hex())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("an update without a priority keeps it", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(&domain.Task{ID: taskID, UserID: userID, Priority: domain.PriorityUrgent}, nil).Once()
+		mockRepo.On("UpdateTask", taskID.Hex(), mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Priority == domain.PriorityUrgent
+		})).Return(nil).Once()
+
+		err := taskUsecase.UpdateTask(taskID.Hex(), &domain.Task{Title: "Call", Description: "Call", Status: "todo"}, userID.Hex())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown priority", func(t *testing.T) {
+		err := taskUsecase.CreateTask(&domain.Task{Title: "Call", Description: "Call", Status: "todo", Priority: "asap"}, userID.Hex())
+		assert.EqualError(t, err, "priority must be low, medium, high or urgent")
+
+		mockRepo.On("GetTask", taskID.Hex()).Return(&domain.Task{ID: taskID, UserID: userID}, nil).Once()
+
+		err = taskUsecase.UpdateTask(taskID.Hex(), &domain.Task{Title: "Call", Description: "Call", Status: "todo", Priority: "asap"}, userID.Hex())
+		assert.EqualError(t, err, "priority must be low, medium, high or urgent")
+	})
+
+	mockRepo.AssertExpectations(t)
+}
@@ -2,37 +2,778 @@ package usecases
 
 import (
 	"errors"
+	"strings"
 	"task8/domain"
+	"task8/infrastructure"
+	"task8/infrastructure/naturaldate"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+const maxStatsRange = 366 * 24 * time.Hour
+
 type TaskUsecase struct {
-	repository domain.TaskRepositoryInterface
+	repository  domain.TaskRepositoryInterface
+	projects    domain.ProjectRepositoryInterface
+	users       domain.UserRepositoryInterface
+	attachments domain.AttachmentRepositoryInterface
+	reminders   domain.ReminderRepositoryInterface
+	comments    domain.CommentRepositoryInterface
+	timeEntries domain.TimeEntryRepositoryInterface
+	fields      domain.CustomFieldRepositoryInterface
+	events      domain.EventPublisher
 }
 
-func NewTaskUsecase(repository domain.TaskRepositoryInterface) *TaskUsecase {
-	return &TaskUsecase{repository: repository}
+// TaskUsecaseDeps are what a TaskUsecase is built from.
+type TaskUsecaseDeps struct {
+	Tasks       domain.TaskRepositoryInterface
+	Projects    domain.ProjectRepositoryInterface
+	Users       domain.UserRepositoryInterface
+	Attachments domain.AttachmentRepositoryInterface
+	Reminders   domain.ReminderRepositoryInterface
+	Comments    domain.CommentRepositoryInterface
+	TimeEntries domain.TimeEntryRepositoryInterface
+	Fields      domain.CustomFieldRepositoryInterface
+	Events      domain.EventPublisher
+}
+
+func NewTaskUsecase(deps TaskUsecaseDeps) *TaskUsecase {
+	return &TaskUsecase{
+		repository:  deps.Tasks,
+		projects:    deps.Projects,
+		users:       deps.Users,
+		attachments: deps.Attachments,
+		reminders:   deps.Reminders,
+		comments:    deps.Comments,
+		timeEntries: deps.TimeEntries,
+		fields:      deps.Fields,
+		events:      deps.Events,
+	}
+}
+
+// InWorkspace is the usecase limited to one workspace, the personal one for
+// an empty ID: tasks and projects elsewhere are not found and new ones are
+// created in it. Membership of the workspace is for the caller to check.
+func (tc *TaskUsecase) InWorkspace(workspaceID string) (domain.TaskUsecaseInterface, error) {
+
+	wid, err := workspaceObjectID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	scoped := *tc
+	scoped.repository = tc.repository.InWorkspace(wid)
+	scoped.projects = tc.projects.InWorkspace(wid)
+	scoped.fields = tc.fields.InWorkspace(wid)
+	return &scoped, nil
+}
+
+// WithEvents is the usecase publishing its events to events instead, which
+// automations use to follow the changes they make.
+func (tc *TaskUsecase) WithEvents(events domain.EventPublisher) domain.TaskUsecaseInterface {
+
+	scoped := *tc
+	scoped.events = events
+	return &scoped
 }
 
 func (tc *TaskUsecase) CreateTask(newtask *domain.Task, userid string) error {
 
+	if err := tc.prepareCreate(newtask, userid); err != nil {
+		return err
+	}
+	rank, err := tc.lastRank(newtask, userid)
+	if err != nil {
+		return err
+	}
+	newtask.Rank = rank
+	if err := tc.repository.CreateTask(newtask, userid); err != nil {
+		return err
+	}
+	tc.events.Publish(domain.EventTaskCreated, newtask)
+	return nil
+}
+
+// prepareCreate validates a new task and fills in what the server owns.
+func (tc *TaskUsecase) prepareCreate(newtask *domain.Task, userid string) error {
+
 	if newtask.Description == "" || newtask.Status == "" || newtask.Title == "" {
 		return errors.New("incomplete information")
 	}
-	return tc.repository.CreateTask(newtask, userid)
+	if err := tc.resolveDue(newtask, userid); err != nil {
+		return err
+	}
+	if !newtask.ProjectID.IsZero() {
+		if err := tc.checkProject(newtask.ProjectID.Hex(), userid, domain.ProjectEditor); err != nil {
+			return err
+		}
+	}
+	if err := tc.checkUsers(newtask.Watchers); err != nil {
+		return err
+	}
+	if err := tc.checkFields(newtask, userid, userid); err != nil {
+		return err
+	}
+	if err := checkPriority(newtask); err != nil {
+		return err
+	}
+	newtask.SeriesID = primitive.NilObjectID
+	if newtask.RRule != "" {
+		if err := checkRRule(newtask); err != nil {
+			return err
+		}
+		newtask.ID = primitive.NewObjectID()
+		newtask.SeriesID = newtask.ID
+	}
+	newtask.History = nil
+	newtask.CommentCount = 0
+	newtask.CreatedAt = time.Now()
+	newtask.CompletedAt = time.Time{}
+	newtask.Rank = 0
+	newtask.BlockedBy = nil
+	if domain.IsDone(newtask.Status) {
+		newtask.CompletedAt = newtask.CreatedAt
+	}
+	if !newtask.AssigneeID.IsZero() {
+		event, err := tc.assignment(primitive.NilObjectID, newtask.AssigneeID.Hex(), userid)
+		if err != nil {
+			return err
+		}
+		newtask.History = []domain.TaskEvent{event}
+	}
+	newtask.Tags = normalizeTags(newtask.Tags)
+	newtask.ExternalID = strings.TrimSpace(newtask.ExternalID)
+	return nil
 }
 
-func (tc *TaskUsecase) GetTask(id string) (*domain.Task, error) {
-	return tc.repository.GetTask(id)
+func (tc *TaskUsecase) GetTask(id string, userID string) (*domain.Task, error) {
+	return tc.authorize(id, userID, domain.ProjectViewer)
+}
+
+// GetEditableTask is GetTask for a user who may also edit the task, for
+// changes stored alongside it.
+func (tc *TaskUsecase) GetEditableTask(id string, userID string) (*domain.Task, error) {
+	return tc.authorize(id, userID, domain.ProjectEditor)
+}
+
+// GetTasksByIDs looks several tasks up at once, keeping those the user can
+// see. Tasks that do not exist are left out.
+func (tc *TaskUsecase) GetTasksByIDs(ids []primitive.ObjectID, userID string) (*[]domain.Task, error) {
+
+	tasks, err := tc.repository.GetTasksByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	visible := []domain.Task{}
+	for _, task := range *tasks {
+		if tc.allowed(&task, userID, domain.ProjectViewer) == nil {
+			visible = append(visible, task)
+		}
+	}
+	return &visible, nil
 }
 
 func (tc *TaskUsecase) GetTasks(userID string) (*[]domain.Task, error) {
 	return tc.repository.GetTasks(userID)
 }
 
-func (tc *TaskUsecase) UpdateTask(id string, updatedTask *domain.Task) error {
-	return tc.repository.UpdateTask(id, updatedTask)
+func (tc *TaskUsecase) GetProjectTasks(projectID string, userID string) (*[]domain.Task, error) {
+
+	if err := tc.checkProject(projectID, userID, domain.ProjectViewer); err != nil {
+		return nil, err
+	}
+	return tc.repository.GetProjectTasks(projectID)
+}
+
+func (tc *TaskUsecase) GetAssignedTasks(userID string) (*[]domain.Task, error) {
+	return tc.repository.GetAssignedTasks(userID)
+}
+
+func (tc *TaskUsecase) UpdateTask(id string, updatedTask *domain.Task, userID string) error {
+
+	_, err := tc.updateTask(id, updatedTask, userID)
+	return err
+}
+
+// updateTask is UpdateTask, returning the task as stored.
+func (tc *TaskUsecase) updateTask(id string, updatedTask *domain.Task, userID string) (*domain.Task, error) {
+
+	change, err := tc.prepareUpdate(id, updatedTask, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tc.repository.UpdateTask(id, updatedTask); err != nil {
+		return nil, err
+	}
+	if change.reassigned {
+		if err := tc.repository.AssignTask(id, updatedTask.AssigneeID.Hex(), change.event); err != nil {
+			return nil, err
+		}
+	}
+	return tc.finishUpdate(change)
+}
+
+// taskChange is an update checked by prepareUpdate, to be written and then
+// followed up by finishUpdate.
+type taskChange struct {
+	task       *domain.Task
+	updated    *domain.Task
+	reassigned bool
+	event      domain.TaskEvent
+}
+
+func (tc *TaskUsecase) prepareUpdate(id string, updatedTask *domain.Task, userID string) (*taskChange, error) {
+
+	task, err := tc.authorize(id, userID, domain.ProjectEditor)
+	if err != nil {
+		return nil, err
+	}
+	if err := tc.resolveDue(updatedTask, userID); err != nil {
+		return nil, err
+	}
+	reassigned := !updatedTask.AssigneeID.IsZero() && updatedTask.AssigneeID != task.AssigneeID
+	moved := !updatedTask.ProjectID.IsZero() && updatedTask.ProjectID != task.ProjectID
+	if reassigned || moved {
+		if err := tc.allowed(task, userID, manageTask); err != nil {
+			return nil, err
+		}
+	}
+	if moved {
+		if err := tc.checkProject(updatedTask.ProjectID.Hex(), userID, domain.ProjectEditor); err != nil {
+			return nil, err
+		}
+	}
+	if err := tc.checkUsers(updatedTask.Watchers); err != nil {
+		return nil, err
+	}
+	if updatedTask.Fields == nil {
+		updatedTask.Fields = task.Fields
+	} else if err := tc.checkFields(updatedTask, task.UserID.Hex(), userID); err != nil {
+		return nil, err
+	}
+	if updatedTask.Priority == "" {
+		updatedTask.Priority = task.Priority
+	} else if err := checkPriority(updatedTask); err != nil {
+		return nil, err
+	}
+	if updatedTask.RRule != "" && updatedTask.RRule != task.RRule {
+		return nil, errors.New("the recurrence rule can only be changed for the whole series")
+	}
+	if !domain.IsDone(task.Status) && domain.IsDone(updatedTask.Status) {
+		if err := tc.checkBlockers(task); err != nil {
+			return nil, err
+		}
+	}
+
+	var event domain.TaskEvent
+	if reassigned {
+		if event, err = tc.assignment(task.AssigneeID, updatedTask.AssigneeID.Hex(), userID); err != nil {
+			return nil, err
+		}
+	}
+
+	updatedTask.ID = task.ID
+	updatedTask.SeriesID = task.SeriesID
+	updatedTask.History = nil
+	updatedTask.CommentCount = 0
+	updatedTask.CreatedAt = task.CreatedAt
+	updatedTask.CompletedAt = completedAt(task, updatedTask.Status)
+	updatedTask.Rank = 0
+	updatedTask.BlockedBy = nil
+	updatedTask.ExternalID = task.ExternalID
+	updatedTask.CalendarUID = task.CalendarUID
+	updatedTask.CalendarName = task.CalendarName
+	updatedTask.WorkspaceID = task.WorkspaceID
+	updatedTask.Tags = normalizeTags(updatedTask.Tags)
+	return &taskChange{task: task, updated: updatedTask, reassigned: reassigned, event: event}, nil
+}
+
+// finishUpdate runs what follows a stored update: moving reminders with the
+// due date, publishing events and scheduling the next occurrence.
+func (tc *TaskUsecase) finishUpdate(change *taskChange) (*domain.Task, error) {
+
+	task, updatedTask := change.task, change.updated
+	if !updatedTask.DueDate.IsZero() && !updatedTask.DueDate.Equal(task.DueDate) {
+		if err := tc.reminders.RescheduleReminders(task.ID.Hex(), updatedTask.DueDate); err != nil {
+			return nil, err
+		}
+	}
+
+	stored := storedTask(task, updatedTask)
+	tc.events.Publish(domain.EventTaskUpdated, stored)
+	if !domain.IsDone(task.Status) && domain.IsDone(updatedTask.Status) {
+		tc.events.Publish(domain.EventTaskCompleted, stored)
+		if task.RRule != "" {
+			return stored, tc.scheduleNext(task)
+		}
+	}
+	return stored, nil
+}
+
+// UpdateSeries edits every open occurrence of a recurring task, and the
+// occurrences still to come, instead of just the one task.
+func (tc *TaskUsecase) UpdateSeries(id string, updatedTask *domain.Task, userID string) error {
+
+	task, err := tc.authorize(id, userID, manageTask)
+	if err != nil {
+		return err
+	}
+	if task.SeriesID.IsZero() {
+		return errors.New("task is not recurring")
+	}
+	if updatedTask.Description == "" || updatedTask.Title == "" {
+		return errors.New("incomplete information")
+	}
+	if err := tc.resolveDue(updatedTask, userID); err != nil {
+		return err
+	}
+	if !updatedTask.DueDate.IsZero() && (!updatedTask.DueDate.Equal(task.DueDate) || updatedTask.DueDateOnly != task.DueDateOnly) {
+		return errors.New("the due date can only be changed for one occurrence")
+	}
+	if updatedTask.RRule == "" {
+		updatedTask.RRule = task.RRule
+	}
+	if _, err := infrastructure.ParseRRule(updatedTask.RRule); err != nil {
+		return err
+	}
+	updatedTask.Tags = normalizeTags(updatedTask.Tags)
+	if err := tc.repository.UpdateSeries(task.SeriesID.Hex(), task.UserID.Hex(), updatedTask); err != nil {
+		return err
+	}
+	tc.events.Publish(domain.EventTaskUpdated, storedTask(task, updatedTask))
+	return nil
+}
+
+// GetOccurrences previews the next due dates of a recurring task, starting
+// with its own.
+func (tc *TaskUsecase) GetOccurrences(id string, userID string, limit int) ([]time.Time, error) {
+
+	task, err := tc.authorize(id, userID, domain.ProjectViewer)
+	if err != nil {
+		return nil, err
+	}
+	if task.RRule == "" {
+		return nil, errors.New("task is not recurring")
+	}
+	template := tc.seriesTemplate(task)
+	rule, err := infrastructure.ParseRRule(template.RRule)
+	if err != nil {
+		return nil, err
+	}
+	dates := rule.Occurrences(tc.seriesStart(template), task.DueDate.Add(-time.Nanosecond), limit)
+	for i := range dates {
+		dates[i] = dates[i].UTC()
+	}
+	return dates, nil
+}
+
+// scheduleNext creates the occurrence following a completed one, once for
+// each occurrence however often it is reopened and completed. Its content
+// comes from the series template and its due date from the rule, counted
+// from the template's due date.
+func (tc *TaskUsecase) scheduleNext(task *domain.Task) error {
+
+	if task.NextScheduled {
+		return nil
+	}
+	template := tc.seriesTemplate(task)
+	rule, err := infrastructure.ParseRRule(template.RRule)
+	if err != nil {
+		return err
+	}
+	dates := rule.Occurrences(tc.seriesStart(template), task.DueDate, 1)
+	if len(dates) == 0 {
+		return nil
+	}
+	first, err := tc.repository.MarkNextScheduled(task.ID.Hex())
+	if err != nil || !first {
+		return err
+	}
+
+	next := &domain.Task{
+		Title:       template.Title,
+		Description: template.Description,
+		DueDate:     dates[0],
+		DueDateOnly: template.DueDateOnly,
+		Status:      task.Status,
+		Tags:        template.Tags,
+		Priority:    template.Priority,
+		Fields:      template.Fields,
+		ProjectID:   task.ProjectID,
+		AssigneeID:  task.AssigneeID,
+		Watchers:    task.Watchers,
+		RRule:       template.RRule,
+		SeriesID:    task.SeriesID,
+		WorkspaceID: task.WorkspaceID,
+		CreatedAt:   time.Now(),
+	}
+	if domain.IsDone(next.Status) {
+		next.CompletedAt = next.CreatedAt
+	}
+	rank, err := tc.lastRank(next, task.UserID.Hex())
+	if err != nil {
+		return err
+	}
+	next.Rank = rank
+	if err := tc.repository.CreateTask(next, task.UserID.Hex()); err != nil {
+		return err
+	}
+	tc.events.Publish(domain.EventTaskCreated, next)
+	return nil
+}
+
+// seriesStart is the due date of the series template in the timezone of its
+// creator, which the rule keeps the weekdays and the time of day in. Dates
+// of date-only tasks are UTC midnights and stay in UTC.
+func (tc *TaskUsecase) seriesStart(template *domain.Task) time.Time {
+
+	if template.DueDateOnly {
+		return template.DueDate.UTC()
+	}
+	return template.DueDate.In(userLocation(tc.users, template.UserID.Hex()))
+}
+
+// seriesTemplate returns the first task of the series, falling back to the
+// given task when the first one has been deleted.
+func (tc *TaskUsecase) seriesTemplate(task *domain.Task) *domain.Task {
+
+	if task.SeriesID.IsZero() || task.SeriesID == task.ID {
+		return task
+	}
+	template, err := tc.repository.GetTask(task.SeriesID.Hex())
+	if err != nil {
+		return task
+	}
+	return template
+}
+
+// AssignTask hands the task to another user, or unassigns it when assigneeID
+// is empty, and records the change in the task history.
+func (tc *TaskUsecase) AssignTask(id string, assigneeID string, userID string) (*domain.Task, error) {
+
+	task, err := tc.authorize(id, userID, manageTask)
+	if err != nil {
+		return nil, err
+	}
+	event, err := tc.assignment(task.AssigneeID, assigneeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tc.repository.AssignTask(id, assigneeID, event); err != nil {
+		return nil, err
+	}
+	task, err = tc.repository.GetTask(id)
+	if err != nil {
+		return nil, err
+	}
+	tc.events.Publish(domain.EventTaskUpdated, task)
+	return task, nil
+}
+
+func (tc *TaskUsecase) RemoveTask(id string, userID string) error {
+
+	task, err := tc.authorize(id, userID, manageTask)
+	if err != nil {
+		return err
+	}
+	if err := tc.removeTaskData(id); err != nil {
+		return err
+	}
+	if err := tc.repository.RemoveTask(id); err != nil {
+		return err
+	}
+	if err := tc.repository.ClearBlocker(id); err != nil {
+		return err
+	}
+	tc.events.Publish(domain.EventTaskDeleted, task)
+	return nil
+}
+
+// removeTaskData deletes what is stored alongside a task.
+func (tc *TaskUsecase) removeTaskData(id string) error {
+
+	if err := tc.attachments.RemoveTaskAttachments(id); err != nil {
+		return err
+	}
+	if err := tc.comments.RemoveTaskComments(id); err != nil {
+		return err
+	}
+	if err := tc.timeEntries.RemoveTaskEntries(id); err != nil {
+		return err
+	}
+	return tc.reminders.RemoveTaskReminders(id)
+}
+
+// GetStats summarises the tasks the user created over filter's range.
+func (tc *TaskUsecase) GetStats(userID string, filter domain.StatsFilter) (*domain.TaskStats, error) {
+
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	filter.UserID = userID
+	if err := checkStatsFilter(&filter); err != nil {
+		return nil, err
+	}
+	filter.Today = domain.Day(time.Now().In(userLocation(tc.users, userID)))
+	return tc.repository.GetStats(filter)
+}
+
+// GetAllStats is GetStats across all users, or for the one in filter.UserID.
+func (tc *TaskUsecase) GetAllStats(filter domain.StatsFilter) (*domain.TaskStats, error) {
+
+	if err := checkStatsFilter(&filter); err != nil {
+		return nil, err
+	}
+	return tc.repository.GetStats(filter)
+}
+
+// checkStatsFilter fills in the defaults, the last 30 days by day and today
+// in UTC, and bounds the range so the daily series stays small.
+func checkStatsFilter(filter *domain.StatsFilter) error {
+
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if filter.Today.IsZero() {
+		filter.Today = domain.Day(time.Now().UTC())
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.AddDate(0, 0, -30)
+	}
+	filter.Interval = strings.ToLower(filter.Interval)
+	if filter.Interval == "" {
+		filter.Interval = "day"
+	}
+	if filter.Interval != "day" && filter.Interval != "week" {
+		return errors.New("interval must be day or week")
+	}
+	if !filter.From.Before(filter.To) {
+		return errors.New("from must be before to")
+	}
+	if filter.To.Sub(filter.From) > maxStatsRange {
+		return errors.New("the range cannot exceed 366 days")
+	}
+	return nil
+}
+
+// authorize loads a task and checks that the user holds at least the given
+// role on it, see allowed.
+func (tc *TaskUsecase) authorize(id string, userID string, role string) (*domain.Task, error) {
+
+	task, err := tc.repository.GetTask(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tc.allowed(task, userID, role); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// manageTask is the access deleting, reassigning or moving a task to
+// another project needs: that of a project editor, or of the creator of a
+// private task. Being the assignee is not enough.
+const manageTask = "manage"
+
+// allowed checks that the user holds at least the given role on the task's
+// project. Tasks outside any project are private to their creator. The
+// assignee may always read and edit the task, but not manage it, and
+// watchers may always read it.
+func (tc *TaskUsecase) allowed(task *domain.Task, userID string, role string) error {
+
+	assignee := !task.AssigneeID.IsZero() && task.AssigneeID.Hex() == userID
+	if role == manageTask {
+		if err := tc.member(task, userID, domain.ProjectEditor); err != nil {
+			if assignee {
+				return errors.New("only the creator or a project editor can delete, reassign or move the task")
+			}
+			return err
+		}
+		return nil
+	}
+	if assignee {
+		return nil
+	}
+	if role == domain.ProjectViewer && isWatcher(task, userID) {
+		return nil
+	}
+	return tc.member(task, userID, role)
+}
+
+// member checks the role of the user on the task's project, or that they
+// created the task when it is private.
+func (tc *TaskUsecase) member(task *domain.Task, userID string, role string) error {
+
+	if task.ProjectID.IsZero() {
+		if task.UserID.Hex() != userID {
+			return domain.ErrTaskNotFound
+		}
+		return nil
+	}
+	return tc.checkProject(task.ProjectID.Hex(), userID, role)
+}
+
+func (tc *TaskUsecase) checkProject(projectID string, userID string, role string) error {
+
+	project, err := tc.projects.GetProject(projectID)
+	if err != nil {
+		return err
+	}
+	if !hasProjectRole(project, userID, role) {
+		return domain.ErrNoPermission
+	}
+	return nil
+}
+
+// assignment validates the new assignee and builds the history entry for
+// moving the task from the previous one.
+func (tc *TaskUsecase) assignment(previous primitive.ObjectID, assigneeID string, userID string) (domain.TaskEvent, error) {
+
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.TaskEvent{}, errors.New("user ID is not a valid ObjectID")
+	}
+	if assigneeID != "" {
+		if _, err := tc.users.GetUserByID(assigneeID); err != nil {
+			return domain.TaskEvent{}, errors.New("assignee not found")
+		}
+	}
+	event := domain.TaskEvent{Action: "assigned", UserID: uid, To: assigneeID, At: time.Now()}
+	if !previous.IsZero() {
+		event.From = previous.Hex()
+	}
+	if assigneeID == "" {
+		event.Action = "unassigned"
+	}
+	return event, nil
+}
+
+func (tc *TaskUsecase) checkUsers(ids []primitive.ObjectID) error {
+	for _, id := range ids {
+		if _, err := tc.users.GetUserByID(id.Hex()); err != nil {
+			return errors.New("watcher not found")
+		}
+	}
+	return nil
+}
+
+// taskAudience lists the users involved in a task: its creator, assignee and
+// watchers and, for project tasks, the project members. For a workspace task
+// only current members of the workspace are kept, as a removed member may
+// still be left on the task or its project.
+func taskAudience(projects domain.ProjectRepositoryInterface, workspaces domain.WorkspaceRepositoryInterface, task *domain.Task) ([]primitive.ObjectID, error) {
+
+	audience := []primitive.ObjectID{task.UserID}
+	if !task.AssigneeID.IsZero() {
+		audience = append(audience, task.AssigneeID)
+	}
+	audience = append(audience, task.Watchers...)
+	if !task.ProjectID.IsZero() {
+		project, err := projects.GetProject(task.ProjectID.Hex())
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		if project != nil {
+			for _, member := range project.Members {
+				audience = append(audience, member.UserID)
+			}
+		}
+	}
+	if task.WorkspaceID.IsZero() {
+		return audience, nil
+	}
+	workspace, err := workspaces.GetWorkspace(task.WorkspaceID.Hex())
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	members := []primitive.ObjectID{}
+	for _, userID := range audience {
+		if workspaceRole(workspace, userID.Hex()) != "" {
+			members = append(members, userID)
+		}
+	}
+	return members, nil
+}
+
+func isWatcher(task *domain.Task, userID string) bool {
+	for _, watcher := range task.Watchers {
+		if watcher.Hex() == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func checkRRule(task *domain.Task) error {
+	if task.DueDate.IsZero() {
+		return errors.New("a recurring task needs a due date")
+	}
+	_, err := infrastructure.ParseRRule(task.RRule)
+	return err
+}
+
+// resolveDue settles the due date of a task being written. Words are read
+// in the user's timezone, a day without a time of day becoming a date-only
+// due date, and date-only due dates are kept as midnight UTC.
+func (tc *TaskUsecase) resolveDue(task *domain.Task, userID string) error {
+
+	if task.DueText != "" {
+		due, dayOnly, err := naturaldate.ParseDay(task.DueText, time.Now().In(userLocation(tc.users, userID)))
+		if err != nil {
+			return err
+		}
+		task.DueDate = due
+		task.DueText = ""
+		task.DueDateOnly = dayOnly
+	}
+	if task.DueDate.IsZero() {
+		task.DueDateOnly = false
+	}
+	if task.DueDateOnly {
+		task.DueDate = domain.Day(task.DueDate)
+	}
+	return nil
 }
 
-func (tc *TaskUsecase) RemoveTask(id string) error {
-	return tc.repository.RemoveTask(id)
+// storedTask is the task as saved after an update: UpdateTask only
+// overwrites the fields that were given.
+func storedTask(task *domain.Task, updatedTask *domain.Task) *domain.Task {
+
+	stored := *updatedTask
+	stored.ID = task.ID
+	stored.UserID = task.UserID
+	stored.History = task.History
+	stored.CommentCount = task.CommentCount
+	stored.Rank = task.Rank
+	stored.BlockedBy = task.BlockedBy
+	if stored.ProjectID.IsZero() {
+		stored.ProjectID = task.ProjectID
+	}
+	if stored.AssigneeID.IsZero() {
+		stored.AssigneeID = task.AssigneeID
+	}
+	if stored.RRule == "" {
+		stored.RRule = task.RRule
+	}
+	if stored.SeriesID.IsZero() {
+		stored.SeriesID = task.SeriesID
+	}
+	return &stored
+}
+
+// completedAt is when the task was completed once its status becomes the
+// given one: kept while it stays done, now when it gets done, zero otherwise.
+func completedAt(task *domain.Task, status string) time.Time {
+	switch {
+	case !domain.IsDone(status):
+		return time.Time{}
+	case domain.IsDone(task.Status) && !task.CompletedAt.IsZero():
+		return task.CompletedAt
+	default:
+		return time.Now()
+	}
 }
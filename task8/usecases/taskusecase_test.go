@@ -1,10 +1,12 @@
 package usecases_test
 
 import (
+	"errors"
 	"task8/domain"
 	"task8/mocks"
 	"task8/usecases"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -13,7 +15,13 @@ import (
 
 func TestCreateTask(t *testing.T) {
 	mockRepo := new(mocks.TaskRepositoryInterface)
-	taskUsecase := usecases.NewTaskUsecase(mockRepo)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
 
 	task := &domain.Task{
 		Title:       "Sample Task",
@@ -21,21 +29,66 @@ func TestCreateTask(t *testing.T) {
 		Status:      "pending",
 	}
 
+	mockRepo.On("LastRank", domain.BoardColumn{Status: "pending"}).Return(2048.0, nil)
 	mockRepo.On("CreateTask", mock.AnythingOfType("*domain.Task"), "userID").Return(nil)
 
 	err := taskUsecase.CreateTask(task, "userID")
 
 	assert.NoError(t, err)
+	assert.Equal(t, 3072.0, task.Rank)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateTask_DueDateInWords(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: mockUsers, Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+	userID := primitive.NewObjectID().Hex()
+	mockUsers.On("GetUserByID", userID).Return(&domain.User{Timezone: "America/New_York"}, nil)
+
+	t.Run("resolved in the user's timezone", func(t *testing.T) {
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("CreateTask", mock.AnythingOfType("*domain.Task"), userID).Return(nil).Once()
+		task := &domain.Task{Title: "Call", Description: "Call", Status: "todo", DueText: "tomorrow 5pm"}
+
+		err := taskUsecase.CreateTask(task, userID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, task.DueText)
+		newYork, _ := time.LoadLocation("America/New_York")
+		due := task.DueDate.In(newYork)
+		tomorrow := time.Now().In(newYork).AddDate(0, 0, 1)
+		assert.Equal(t, tomorrow.Format("2006-01-02"), due.Format("2006-01-02"))
+		assert.Equal(t, "17:00", due.Format("15:04"))
+	})
+
+	t.Run("words it cannot read", func(t *testing.T) {
+		task := &domain.Task{Title: "Call", Description: "Call", Status: "todo", DueText: "sometime"}
+
+		err := taskUsecase.CreateTask(task, userID)
+
+		assert.EqualError(t, err, `could not read "sometime" as a date`)
+	})
 	mockRepo.AssertExpectations(t)
 }
 
 func TestGetTask(t *testing.T) {
 	mockRepo := new(mocks.TaskRepositoryInterface)
-	taskUsecase := usecases.NewTaskUsecase(mockRepo)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
 
 	taskID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
 	task := &domain.Task{
 		ID:          taskID,
+		UserID:      userID,
 		Title:       "Sample Task",
 		Description: "This is a sample task",
 		Status:      "pending",
@@ -43,16 +96,40 @@ func TestGetTask(t *testing.T) {
 
 	mockRepo.On("GetTask", taskID.Hex()).Return(task, nil)
 
-	result, err := taskUsecase.GetTask(taskID.Hex())
+	result, err := taskUsecase.GetTask(taskID.Hex(), userID.Hex())
 
 	assert.NoError(t, err)
 	assert.Equal(t, task, result)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestGetTasksByIDs(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: new(mocks.UserRepositoryInterface), Fields: new(mocks.CustomFieldRepositoryInterface), Events: new(mocks.EventPublisher)})
+
+	userID := primitive.NewObjectID()
+	mine := domain.Task{ID: primitive.NewObjectID(), UserID: userID, Title: "Mine"}
+	assigned := domain.Task{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), AssigneeID: userID, Title: "Assigned"}
+	private := domain.Task{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), Title: "Private"}
+	ids := []primitive.ObjectID{mine.ID, assigned.ID, private.ID}
+
+	mockRepo.On("GetTasksByIDs", ids).Return(&[]domain.Task{mine, assigned, private}, nil)
+
+	tasks, err := taskUsecase.GetTasksByIDs(ids, userID.Hex())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &[]domain.Task{mine, assigned}, tasks)
+}
+
 func TestGetTasks(t *testing.T) {
 	mockRepo := new(mocks.TaskRepositoryInterface)
-	taskUsecase := usecases.NewTaskUsecase(mockRepo)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
 
 	userID := primitive.NewObjectID()
 	tasks := &[]domain.Task{
@@ -83,18 +160,26 @@ func TestGetTasks(t *testing.T) {
 
 func TestUpdateTask(t *testing.T) {
 	mockRepo := new(mocks.TaskRepositoryInterface)
-	taskUsecase := usecases.NewTaskUsecase(mockRepo)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
 
 	taskID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
 	updatedTask := &domain.Task{
 		Title:       "Updated Task",
 		Description: "This is the updated task",
 		Status:      "in-progress",
 	}
 
+	mockRepo.On("GetTask", taskID.Hex()).Return(&domain.Task{ID: taskID, UserID: userID}, nil)
 	mockRepo.On("UpdateTask", taskID.Hex(), updatedTask).Return(nil)
 
-	err := taskUsecase.UpdateTask(taskID.Hex(), updatedTask)
+	err := taskUsecase.UpdateTask(taskID.Hex(), updatedTask, userID.Hex())
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
@@ -102,14 +187,418 @@ func TestUpdateTask(t *testing.T) {
 
 func TestRemoveTask(t *testing.T) {
 	mockRepo := new(mocks.TaskRepositoryInterface)
-	taskUsecase := usecases.NewTaskUsecase(mockRepo)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockComments := new(mocks.CommentRepositoryInterface)
+	mockEntries := new(mocks.TimeEntryRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Comments: mockComments, TimeEntries: mockEntries, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
 
 	taskID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
 
+	mockRepo.On("GetTask", taskID.Hex()).Return(&domain.Task{ID: taskID, UserID: userID}, nil)
+	mockAttachments.On("RemoveTaskAttachments", taskID.Hex()).Return(nil)
+	mockReminders.On("RemoveTaskReminders", taskID.Hex()).Return(nil)
+	mockComments.On("RemoveTaskComments", taskID.Hex()).Return(nil)
+	mockEntries.On("RemoveTaskEntries", taskID.Hex()).Return(nil)
 	mockRepo.On("RemoveTask", taskID.Hex()).Return(nil)
+	mockRepo.On("ClearBlocker", taskID.Hex()).Return(nil)
 
-	err := taskUsecase.RemoveTask(taskID.Hex())
+	err := taskUsecase.RemoveTask(taskID.Hex(), userID.Hex())
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
+	mockAttachments.AssertExpectations(t)
+	mockReminders.AssertExpectations(t)
+	mockComments.AssertExpectations(t)
+}
+
+func TestFilterTasks(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	userID := primitive.NewObjectID()
+	tasks := &[]domain.Task{{Title: "Task 1", Tags: []string{"urgent"}}}
+
+	mockRepo.On("FilterTasks", userID.Hex(), domain.TaskFilter{Tags: []string{"urgent", "client-x"}}).Return(tasks, nil)
+
+	result, err := taskUsecase.FilterTasks(userID.Hex(), domain.TaskFilter{Tags: []string{" Urgent", "client-x", "urgent"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, tasks, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProjectTaskAccess(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	editorID := primitive.NewObjectID()
+	viewerID := primitive.NewObjectID()
+	projectID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	task := &domain.Task{ID: taskID, UserID: editorID, ProjectID: projectID, Title: "Shared"}
+
+	mockRepo.On("GetTask", taskID.Hex()).Return(task, nil)
+	mockProjects.On("GetProject", projectID.Hex()).Return(&domain.Project{ID: projectID, Members: []domain.ProjectMember{
+		{UserID: editorID, Role: domain.ProjectEditor},
+		{UserID: viewerID, Role: domain.ProjectViewer},
+	}}, nil)
+
+	t.Run("viewer can read", func(t *testing.T) {
+		result, err := taskUsecase.GetTask(taskID.Hex(), viewerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, task, result)
+	})
+
+	t.Run("viewer cannot delete", func(t *testing.T) {
+		err := taskUsecase.RemoveTask(taskID.Hex(), viewerID.Hex())
+
+		assert.EqualError(t, err, "insufficient project permissions")
+		mockRepo.AssertNotCalled(t, "RemoveTask", taskID.Hex())
+	})
+
+	t.Run("non-member cannot read", func(t *testing.T) {
+		_, err := taskUsecase.GetTask(taskID.Hex(), primitive.NewObjectID().Hex())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("viewer cannot create in the project", func(t *testing.T) {
+		err := taskUsecase.CreateTask(&domain.Task{Title: "New", Description: "d", Status: "pending", ProjectID: projectID}, viewerID.Hex())
+
+		assert.EqualError(t, err, "insufficient project permissions")
+	})
+}
+
+func TestPrivateTaskAccess(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	taskID := primitive.NewObjectID()
+	mockRepo.On("GetTask", taskID.Hex()).Return(&domain.Task{ID: taskID, UserID: primitive.NewObjectID()}, nil)
+
+	_, err := taskUsecase.GetTask(taskID.Hex(), primitive.NewObjectID().Hex())
+
+	assert.EqualError(t, err, "task not found")
+}
+
+func TestAssignTask(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	ownerID := primitive.NewObjectID()
+	previousID := primitive.NewObjectID()
+	assigneeID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	task := &domain.Task{ID: taskID, UserID: ownerID, AssigneeID: previousID}
+
+	t.Run("records the reassignment", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Twice()
+		mockUsers.On("GetUserByID", assigneeID.Hex()).Return(&domain.User{ID: assigneeID}, nil).Once()
+		mockRepo.On("AssignTask", taskID.Hex(), assigneeID.Hex(), mock.MatchedBy(func(event domain.TaskEvent) bool {
+			return event.Action == "assigned" && event.From == previousID.Hex() && event.To == assigneeID.Hex() && event.UserID == ownerID
+		})).Return(nil).Once()
+
+		_, err := taskUsecase.AssignTask(taskID.Hex(), assigneeID.Hex(), ownerID.Hex())
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an unknown assignee", func(t *testing.T) {
+		unknownID := primitive.NewObjectID()
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Once()
+		mockUsers.On("GetUserByID", unknownID.Hex()).Return(nil, errors.New("mongo: no documents in result")).Once()
+
+		_, err := taskUsecase.AssignTask(taskID.Hex(), unknownID.Hex(), ownerID.Hex())
+
+		assert.EqualError(t, err, "assignee not found")
+	})
+
+	t.Run("assignee can see the task", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Once()
+
+		result, err := taskUsecase.GetTask(taskID.Hex(), previousID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, task, result)
+	})
+
+	t.Run("assignee edits the task but does not manage it", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil)
+		mockRepo.On("UpdateTask", taskID.Hex(), mock.AnythingOfType("*domain.Task")).Return(nil).Once()
+
+		err := taskUsecase.UpdateTask(taskID.Hex(), &domain.Task{Title: "Call", Description: "Call", Status: "todo"}, previousID.Hex())
+		assert.NoError(t, err)
+
+		err = taskUsecase.UpdateTask(taskID.Hex(), &domain.Task{Title: "Call", Description: "Call", Status: "todo", AssigneeID: assigneeID}, previousID.Hex())
+		assert.EqualError(t, err, "only the creator or a project editor can delete, reassign or move the task")
+
+		_, err = taskUsecase.AssignTask(taskID.Hex(), "", previousID.Hex())
+		assert.EqualError(t, err, "only the creator or a project editor can delete, reassign or move the task")
+
+		err = taskUsecase.RemoveTask(taskID.Hex(), previousID.Hex())
+		assert.EqualError(t, err, "only the creator or a project editor can delete, reassign or move the task")
+	})
+}
+
+func TestRecurringTask(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	ownerID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	due := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	task := &domain.Task{
+		ID:          taskID,
+		UserID:      ownerID,
+		Title:       "Standup notes",
+		Description: "Write them up",
+		DueDate:     due,
+		Status:      "pending",
+		RRule:       "FREQ=WEEKLY;BYDAY=MO,TH",
+		SeriesID:    taskID,
+		Priority:    domain.PriorityHigh,
+		Fields:      map[string]interface{}{"estimate": 3.0},
+	}
+	mockUsers.On("GetUserByID", ownerID.Hex()).Return(&domain.User{}, nil).Maybe()
+
+	t.Run("completing an occurrence schedules the next one", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Once()
+		mockRepo.On("UpdateTask", taskID.Hex(), mock.AnythingOfType("*domain.Task")).Return(nil).Once()
+		mockRepo.On("MarkNextScheduled", taskID.Hex()).Return(true, nil).Once()
+		mockRepo.On("LastRank", mock.Anything).Return(0.0, nil).Maybe()
+		mockRepo.On("CreateTask", mock.MatchedBy(func(next *domain.Task) bool {
+			return next.SeriesID == taskID && next.Status == "pending" && next.Priority == domain.PriorityHigh &&
+				next.Fields["estimate"] == 3.0 && next.DueDate.Equal(time.Date(2024, time.January, 4, 9, 0, 0, 0, time.UTC))
+		}), ownerID.Hex()).Return(nil).Once()
+
+		err := taskUsecase.UpdateTask(taskID.Hex(), &domain.Task{Title: task.Title, DueDate: due, Status: "done"}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("completing it again does not", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Once()
+		mockRepo.On("UpdateTask", taskID.Hex(), mock.AnythingOfType("*domain.Task")).Return(nil).Once()
+		mockRepo.On("MarkNextScheduled", taskID.Hex()).Return(false, nil).Once()
+
+		err := taskUsecase.UpdateTask(taskID.Hex(), &domain.Task{Title: task.Title, DueDate: due, Status: "done"}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rule changes need the series scope", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Once()
+
+		err := taskUsecase.UpdateTask(taskID.Hex(), &domain.Task{Title: task.Title, RRule: "FREQ=DAILY"}, ownerID.Hex())
+
+		assert.EqualError(t, err, "the recurrence rule can only be changed for the whole series")
+	})
+
+	t.Run("a forged series ID is ignored", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Once()
+		mockRepo.On("UpdateTask", taskID.Hex(), mock.MatchedBy(func(updated *domain.Task) bool {
+			return updated.SeriesID == taskID && updated.ID == taskID
+		})).Return(nil).Once()
+
+		err := taskUsecase.UpdateTask(taskID.Hex(), &domain.Task{ID: primitive.NewObjectID(), Title: task.Title, SeriesID: primitive.NewObjectID()}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("updates the whole series", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Once()
+		mockRepo.On("UpdateSeries", taskID.Hex(), ownerID.Hex(), mock.MatchedBy(func(updated *domain.Task) bool {
+			return updated.RRule == task.RRule && updated.Title == "Retro notes"
+		})).Return(nil).Once()
+
+		err := taskUsecase.UpdateSeries(taskID.Hex(), &domain.Task{Title: "Retro notes", Description: "Write them up"}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("due dates change per occurrence", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Once()
+
+		err := taskUsecase.UpdateSeries(taskID.Hex(), &domain.Task{Title: "Retro notes", Description: "Write them up", DueDate: due.AddDate(0, 0, 1)}, ownerID.Hex())
+
+		assert.EqualError(t, err, "the due date can only be changed for one occurrence")
+	})
+
+	t.Run("keeps the time of day in the creator's timezone", func(t *testing.T) {
+		newYork, _ := time.LoadLocation("America/New_York")
+		travellerID := primitive.NewObjectID()
+		weekly := &domain.Task{ID: primitive.NewObjectID(), UserID: travellerID, Title: "Call", Description: "Call", Status: "pending",
+			DueDate: time.Date(2024, time.March, 7, 21, 30, 0, 0, newYork).UTC(), RRule: "FREQ=WEEKLY;BYDAY=TH"}
+		weekly.SeriesID = weekly.ID
+		mockUsers.On("GetUserByID", travellerID.Hex()).Return(&domain.User{Timezone: "America/New_York"}, nil).Once()
+		mockRepo.On("GetTask", weekly.ID.Hex()).Return(weekly, nil).Once()
+
+		dates, err := taskUsecase.GetOccurrences(weekly.ID.Hex(), travellerID.Hex(), 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{weekly.DueDate, time.Date(2024, time.March, 15, 1, 30, 0, 0, time.UTC)}, dates)
+	})
+
+	t.Run("previews occurrences", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Once()
+
+		dates, err := taskUsecase.GetOccurrences(taskID.Hex(), ownerID.Hex(), 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			due,
+			time.Date(2024, time.January, 4, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC),
+		}, dates)
+	})
+
+	t.Run("rejects an invalid rule", func(t *testing.T) {
+		err := taskUsecase.CreateTask(&domain.Task{Title: "Bad", DueDate: due, RRule: "FREQ=YEARLY"}, ownerID.Hex())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTaskEvents(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockComments := new(mocks.CommentRepositoryInterface)
+	mockEntries := new(mocks.TimeEntryRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: new(mocks.ProjectRepositoryInterface), Users: new(mocks.UserRepositoryInterface), Attachments: mockAttachments, Reminders: mockReminders, Comments: mockComments, TimeEntries: mockEntries, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	ownerID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	task := &domain.Task{ID: taskID, UserID: ownerID, Title: "Report", Status: "pending"}
+
+	t.Run("completing a task emits updated and completed", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Once()
+		mockRepo.On("UpdateTask", taskID.Hex(), mock.AnythingOfType("*domain.Task")).Return(nil).Once()
+		isStored := mock.MatchedBy(func(published *domain.Task) bool {
+			return published.ID == taskID && published.UserID == ownerID && published.Status == "done"
+		})
+		mockEvents.On("Publish", domain.EventTaskUpdated, isStored).Once()
+		mockEvents.On("Publish", domain.EventTaskCompleted, isStored).Once()
+
+		err := taskUsecase.UpdateTask(taskID.Hex(), &domain.Task{Title: "Report", Status: "done"}, ownerID.Hex())
+
+		assert.NoError(t, err)
+		mockEvents.AssertExpectations(t)
+	})
+
+	t.Run("removing a task emits deleted", func(t *testing.T) {
+		mockRepo.On("GetTask", taskID.Hex()).Return(task, nil).Once()
+		mockAttachments.On("RemoveTaskAttachments", taskID.Hex()).Return(nil).Once()
+		mockReminders.On("RemoveTaskReminders", taskID.Hex()).Return(nil).Once()
+		mockComments.On("RemoveTaskComments", taskID.Hex()).Return(nil).Once()
+		mockEntries.On("RemoveTaskEntries", taskID.Hex()).Return(nil).Once()
+		mockRepo.On("RemoveTask", taskID.Hex()).Return(nil).Once()
+		mockRepo.On("ClearBlocker", taskID.Hex()).Return(nil).Once()
+		mockEvents.On("Publish", domain.EventTaskDeleted, task).Once()
+
+		err := taskUsecase.RemoveTask(taskID.Hex(), ownerID.Hex())
+
+		assert.NoError(t, err)
+		mockEvents.AssertExpectations(t)
+	})
+}
+
+func TestGetStats(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockAttachments := new(mocks.AttachmentRepositoryInterface)
+	mockReminders := new(mocks.ReminderRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: mockUsers, Attachments: mockAttachments, Reminders: mockReminders, Fields: new(mocks.CustomFieldRepositoryInterface), Events: mockEvents})
+
+	userID := primitive.NewObjectID().Hex()
+
+	t.Run("defaults to the last 30 days by day", func(t *testing.T) {
+		mockUsers.On("GetUserByID", userID).Return(&domain.User{Timezone: "Pacific/Kiritimati"}, nil).Once()
+		kiritimati, _ := time.LoadLocation("Pacific/Kiritimati")
+		today := domain.Day(time.Now().In(kiritimati))
+		mockRepo.On("GetStats", mock.MatchedBy(func(f domain.StatsFilter) bool {
+			return f.UserID == userID && f.Interval == "day" && f.To.Sub(f.From) == 30*24*time.Hour && f.Today.Equal(today)
+		})).Return(&domain.TaskStats{Total: 2}, nil).Once()
+
+		stats, err := taskUsecase.GetStats(userID, domain.StatsFilter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, stats.Total)
+	})
+
+	t.Run("rejects bad filters", func(t *testing.T) {
+		from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		cases := []struct {
+			filter domain.StatsFilter
+			err    string
+		}{
+			{domain.StatsFilter{From: from, To: from.AddDate(0, 1, 0), Interval: "month"}, "interval must be day or week"},
+			{domain.StatsFilter{From: from, To: from}, "from must be before to"},
+			{domain.StatsFilter{From: from, To: from.AddDate(2, 0, 0)}, "the range cannot exceed 366 days"},
+		}
+		for _, c := range cases {
+			_, err := taskUsecase.GetStats(userID, c.filter)
+			assert.EqualError(t, err, c.err)
+		}
+		_, err := taskUsecase.GetStats("invalid", domain.StatsFilter{})
+		assert.EqualError(t, err, "user ID is not a valid ObjectID")
+	})
+
+	t.Run("all users", func(t *testing.T) {
+		mockRepo.On("GetStats", mock.MatchedBy(func(f domain.StatsFilter) bool {
+			return f.UserID == "" && f.Interval == "week"
+		})).Return(&domain.TaskStats{Total: 9}, nil).Once()
+
+		stats, err := taskUsecase.GetAllStats(domain.StatsFilter{Interval: "WEEK"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 9, stats.Total)
+	})
+
+	mockRepo.AssertExpectations(t)
 }
@@ -0,0 +1,289 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"task8/domain"
+	"task8/infrastructure/naturaldate"
+	"time"
+)
+
+const defaultTemplateStatus = "pending"
+
+var (
+	templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+	dueOffsetPattern        = regexp.MustCompile(`^([+-]?)(\d{1,4})([hdw])$`)
+)
+
+type TemplateUsecase struct {
+	repository domain.TemplateRepositoryInterface
+	tasks      domain.TaskUsecaseInterface
+	users      domain.UserRepositoryInterface
+}
+
+func NewTemplateUsecase(repository domain.TemplateRepositoryInterface, tasks domain.TaskUsecaseInterface, users domain.UserRepositoryInterface) *TemplateUsecase {
+	return &TemplateUsecase{repository: repository, tasks: tasks, users: users}
+}
+
+// InWorkspace is the usecase limited to the templates of one workspace,
+// whose tasks it also instantiates them into.
+func (tu *TemplateUsecase) InWorkspace(workspaceID string) (domain.TemplateUsecaseInterface, error) {
+
+	wid, err := workspaceObjectID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := tu.tasks.InWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	scoped := *tu
+	scoped.repository = tu.repository.InWorkspace(wid)
+	scoped.tasks = tasks
+	return &scoped, nil
+}
+
+func (tu *TemplateUsecase) CreateTemplate(newtemplate *domain.Template, userid string) error {
+
+	if err := validateTemplate(newtemplate); err != nil {
+		return err
+	}
+	newtemplate.CreatedAt = time.Now()
+	return tu.repository.CreateTemplate(newtemplate, userid)
+}
+
+func (tu *TemplateUsecase) GetTemplate(id string, userid string) (*domain.Template, error) {
+	return tu.visibleTemplate(id, userid)
+}
+
+func (tu *TemplateUsecase) GetTemplates(userid string) (*[]domain.Template, error) {
+	return tu.repository.GetTemplates(userid)
+}
+
+func (tu *TemplateUsecase) UpdateTemplate(id string, updatedtemplate *domain.Template, userid string) error {
+
+	if _, err := tu.ownedTemplate(id, userid); err != nil {
+		return err
+	}
+	if err := validateTemplate(updatedtemplate); err != nil {
+		return err
+	}
+	return tu.repository.UpdateTemplate(id, updatedtemplate)
+}
+
+func (tu *TemplateUsecase) RemoveTemplate(id string, userid string) error {
+
+	if _, err := tu.ownedTemplate(id, userid); err != nil {
+		return err
+	}
+	return tu.repository.RemoveTemplate(id)
+}
+
+// Instantiate creates the task of a template, and a task for each checklist
+// item which the first one is blocked by. {{date}} is the start day unless
+// the caller gives it; any other variable left without a value fails the
+// whole instantiation before a task is created.
+func (tu *TemplateUsecase) Instantiate(id string, instance domain.TemplateInstance, userid string) (*domain.TemplateResult, error) {
+
+	template, err := tu.visibleTemplate(id, userid)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now().In(userLocation(tu.users, userid))
+	if instance.Start != "" {
+		if start, err = naturaldate.Parse(instance.Start, start); err != nil {
+			return nil, err
+		}
+	}
+	variables := map[string]string{"date": start.Format("2006-01-02")}
+	for name, value := range instance.Variables {
+		variables[name] = value
+	}
+	render := &templateRender{variables: variables}
+
+	task := &domain.Task{
+		Title:       render.text(template.Title),
+		Description: render.text(template.Description),
+		Status:      template.Status,
+		Tags:        append([]string{}, template.Tags...),
+		ProjectID:   instance.ProjectID,
+	}
+	if err := applyDueOffset(task, template.DueOffset, start); err != nil {
+		return nil, err
+	}
+	checklist := []domain.Task{}
+	for _, item := range template.Checklist {
+		itemTask := domain.Task{
+			Title:       render.text(item.Title),
+			Description: fmt.Sprintf("Checklist item of %s", task.Title),
+			Status:      template.Status,
+			Tags:        append([]string{}, template.Tags...),
+			ProjectID:   instance.ProjectID,
+		}
+		if err := applyDueOffset(&itemTask, item.DueOffset, start); err != nil {
+			return nil, err
+		}
+		checklist = append(checklist, itemTask)
+	}
+	if err := render.check(); err != nil {
+		return nil, err
+	}
+
+	created := []string{}
+	rollback := func(err error) (*domain.TemplateResult, error) {
+		for _, id := range created {
+			tu.tasks.RemoveTask(id, userid)
+		}
+		return nil, err
+	}
+	if err := tu.tasks.CreateTask(task, userid); err != nil {
+		return nil, err
+	}
+	created = append(created, task.ID.Hex())
+	for i := range checklist {
+		if err := tu.tasks.CreateTask(&checklist[i], userid); err != nil {
+			return rollback(err)
+		}
+		created = append(created, checklist[i].ID.Hex())
+	}
+	for _, item := range checklist {
+		blocked, err := tu.tasks.AddBlocker(task.ID.Hex(), item.ID.Hex(), userid)
+		if err != nil {
+			return rollback(err)
+		}
+		task = blocked
+	}
+	return &domain.TemplateResult{Task: task, Checklist: checklist}, nil
+}
+
+// visibleTemplate finds a template of the workspace, or a personal one of
+// the user.
+func (tu *TemplateUsecase) visibleTemplate(id string, userid string) (*domain.Template, error) {
+
+	template, err := tu.repository.GetTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	if template.WorkspaceID.IsZero() && template.UserID.Hex() != userid {
+		return nil, errors.New("template not found")
+	}
+	return template, nil
+}
+
+// ownedTemplate is a visible template the user created; members of a
+// workspace use each other's templates but only change their own.
+func (tu *TemplateUsecase) ownedTemplate(id string, userid string) (*domain.Template, error) {
+
+	template, err := tu.visibleTemplate(id, userid)
+	if err != nil {
+		return nil, err
+	}
+	if template.UserID.Hex() != userid {
+		return nil, errors.New("only the creator can change a template")
+	}
+	return template, nil
+}
+
+func validateTemplate(template *domain.Template) error {
+
+	template.Name = strings.TrimSpace(template.Name)
+	if template.Name == "" || template.Title == "" || template.Description == "" {
+		return errors.New("incomplete information")
+	}
+	if template.Status == "" {
+		template.Status = defaultTemplateStatus
+	}
+	template.Tags = normalizeTags(template.Tags)
+	if _, _, err := parseDueOffset(template.DueOffset); err != nil {
+		return err
+	}
+	for _, item := range template.Checklist {
+		if strings.TrimSpace(item.Title) == "" {
+			return errors.New("checklist items need a title")
+		}
+		if _, _, err := parseDueOffset(item.DueOffset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseDueOffset reads an offset such as "+3d", "-1w" or "+4h" into a
+// signed count and its unit. The empty offset is no due date.
+func parseDueOffset(offset string) (int, string, error) {
+
+	if offset == "" {
+		return 0, "", nil
+	}
+	match := dueOffsetPattern.FindStringSubmatch(strings.TrimSpace(offset))
+	if match == nil {
+		return 0, "", errors.New("due offset must look like +3d, +2w or +4h")
+	}
+	count, _ := strconv.Atoi(match[2])
+	if match[1] == "-" {
+		count = -count
+	}
+	return count, match[3], nil
+}
+
+// applyDueOffset sets the due date of a task an offset away from start.
+// Days and weeks give a date-only due date on the start's calendar, hours
+// a moment.
+func applyDueOffset(task *domain.Task, offset string, start time.Time) error {
+
+	count, unit, err := parseDueOffset(offset)
+	if err != nil {
+		return err
+	}
+	switch unit {
+	case "h":
+		task.DueDate = start.Add(time.Duration(count) * time.Hour)
+	case "w":
+		count *= 7
+		fallthrough
+	case "d":
+		task.DueDate = time.Date(start.Year(), start.Month(), start.Day()+count, 0, 0, 0, 0, time.UTC)
+		task.DueDateOnly = true
+	}
+	return nil
+}
+
+// templateRender substitutes {{variables}}, remembering those it has no
+// value for.
+type templateRender struct {
+	variables map[string]string
+	missing   map[string]bool
+}
+
+func (r *templateRender) text(text string) string {
+
+	return templateVariablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateVariablePattern.FindStringSubmatch(match)[1]
+		value, ok := r.variables[name]
+		if !ok {
+			if r.missing == nil {
+				r.missing = make(map[string]bool)
+			}
+			r.missing[name] = true
+			return match
+		}
+		return value
+	})
+}
+
+func (r *templateRender) check() error {
+
+	if len(r.missing) == 0 {
+		return nil
+	}
+	names := []string{}
+	for name := range r.missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("missing variables: %s", strings.Join(names, ", "))
+}
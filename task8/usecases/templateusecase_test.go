@@ -0,0 +1,168 @@
+package usecases_test
+
+import (
+	"errors"
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateTemplate(t *testing.T) {
+	mockRepo := new(mocks.TemplateRepositoryInterface)
+	templateUsecase := usecases.NewTemplateUsecase(mockRepo, new(mocks.TaskUsecaseInterface), new(mocks.UserRepositoryInterface))
+	userID := primitive.NewObjectID().Hex()
+
+	t.Run("fills in the defaults", func(t *testing.T) {
+		mockRepo.On("CreateTemplate", mock.AnythingOfType("*domain.Template"), userID).Return(nil).Once()
+
+		template := &domain.Template{Name: " Onboarding ", Title: "Onboard {{name}}", Description: "Welcome", Tags: []string{"HR", "hr"}, DueOffset: "+1w"}
+		err := templateUsecase.CreateTemplate(template, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Onboarding", template.Name)
+		assert.Equal(t, "pending", template.Status)
+		assert.Equal(t, []string{"hr"}, template.Tags)
+		assert.False(t, template.CreatedAt.IsZero())
+	})
+
+	t.Run("invalid due offset", func(t *testing.T) {
+		template := &domain.Template{Name: "Release", Title: "Release", Description: "Ship it", Checklist: []domain.TemplateItem{{Title: "Tag", DueOffset: "3 days"}}}
+
+		err := templateUsecase.CreateTemplate(template, userID)
+
+		assert.EqualError(t, err, "due offset must look like +3d, +2w or +4h")
+	})
+
+	t.Run("incomplete information", func(t *testing.T) {
+		err := templateUsecase.CreateTemplate(&domain.Template{Name: "Release"}, userID)
+
+		assert.EqualError(t, err, "incomplete information")
+	})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTemplateVisibility(t *testing.T) {
+	mockRepo := new(mocks.TemplateRepositoryInterface)
+	templateUsecase := usecases.NewTemplateUsecase(mockRepo, new(mocks.TaskUsecaseInterface), new(mocks.UserRepositoryInterface))
+
+	ownerID := primitive.NewObjectID()
+	memberID := primitive.NewObjectID().Hex()
+	templateID := primitive.NewObjectID().Hex()
+
+	t.Run("personal templates are private", func(t *testing.T) {
+		mockRepo.On("GetTemplate", templateID).Return(&domain.Template{UserID: ownerID}, nil).Once()
+
+		_, err := templateUsecase.GetTemplate(templateID, memberID)
+
+		assert.EqualError(t, err, "template not found")
+	})
+
+	t.Run("workspace templates are shared", func(t *testing.T) {
+		template := &domain.Template{UserID: ownerID, WorkspaceID: primitive.NewObjectID()}
+		mockRepo.On("GetTemplate", templateID).Return(template, nil).Once()
+
+		result, err := templateUsecase.GetTemplate(templateID, memberID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, template, result)
+	})
+
+	t.Run("but only changed by their creator", func(t *testing.T) {
+		mockRepo.On("GetTemplate", templateID).Return(&domain.Template{UserID: ownerID, WorkspaceID: primitive.NewObjectID()}, nil).Once()
+
+		err := templateUsecase.RemoveTemplate(templateID, memberID)
+
+		assert.EqualError(t, err, "only the creator can change a template")
+	})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestInstantiate(t *testing.T) {
+	mockRepo := new(mocks.TemplateRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	templateUsecase := usecases.NewTemplateUsecase(mockRepo, mockTasks, mockUsers)
+
+	userID := primitive.NewObjectID()
+	templateID := primitive.NewObjectID().Hex()
+	projectID := primitive.NewObjectID()
+	template := &domain.Template{
+		UserID:      userID,
+		Title:       "Release {{version}}",
+		Description: "Ship {{ version }} on {{date}}",
+		Status:      "pending",
+		Tags:        []string{"release"},
+		DueOffset:   "+3d",
+		Checklist: []domain.TemplateItem{
+			{Title: "Freeze {{version}}", DueOffset: "-1w"},
+			{Title: "Announce", DueOffset: "+4h"},
+		},
+	}
+	mockUsers.On("GetUserByID", userID.Hex()).Return(&domain.User{ID: userID}, nil)
+
+	t.Run("creates the task blocked by its checklist", func(t *testing.T) {
+		mockRepo.On("GetTemplate", templateID).Return(template, nil).Once()
+		var created []*domain.Task
+		mockTasks.On("CreateTask", mock.AnythingOfType("*domain.Task"), userID.Hex()).Run(func(args mock.Arguments) {
+			task := args.Get(0).(*domain.Task)
+			task.ID = primitive.NewObjectID()
+			created = append(created, task)
+		}).Return(nil).Times(3)
+		mockTasks.On("AddBlocker", mock.Anything, mock.Anything, userID.Hex()).Return(func(id string, blockerID string, userID string) *domain.Task {
+			blockers := append(created[0].BlockedBy, primitive.NewObjectID())
+			created[0].BlockedBy = blockers
+			return created[0]
+		}, nil).Times(2)
+
+		result, err := templateUsecase.Instantiate(templateID, domain.TemplateInstance{
+			Variables: map[string]string{"version": "2.0"},
+			Start:     "2026-11-02",
+			ProjectID: projectID,
+		}, userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Release 2.0", result.Task.Title)
+		assert.Equal(t, "Ship 2.0 on 2026-11-02", result.Task.Description)
+		assert.Equal(t, time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC), result.Task.DueDate)
+		assert.True(t, result.Task.DueDateOnly)
+		assert.Equal(t, projectID, result.Task.ProjectID)
+		assert.Len(t, result.Task.BlockedBy, 2)
+		assert.Len(t, result.Checklist, 2)
+		assert.Equal(t, "Freeze 2.0", result.Checklist[0].Title)
+		assert.Equal(t, "Checklist item of Release 2.0", result.Checklist[0].Description)
+		assert.Equal(t, time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC), result.Checklist[0].DueDate)
+		assert.Equal(t, time.Date(2026, 11, 2, 4, 0, 0, 0, time.UTC), result.Checklist[1].DueDate)
+		assert.False(t, result.Checklist[1].DueDateOnly)
+		assert.Equal(t, []string{"release"}, result.Checklist[1].Tags)
+	})
+
+	t.Run("missing variables create nothing", func(t *testing.T) {
+		mockRepo.On("GetTemplate", templateID).Return(template, nil).Once()
+
+		_, err := templateUsecase.Instantiate(templateID, domain.TemplateInstance{}, userID.Hex())
+
+		assert.EqualError(t, err, "missing variables: version")
+	})
+
+	t.Run("created tasks are removed when one fails", func(t *testing.T) {
+		mockRepo.On("GetTemplate", templateID).Return(template, nil).Once()
+		taskID := primitive.NewObjectID()
+		mockTasks.On("CreateTask", mock.AnythingOfType("*domain.Task"), userID.Hex()).Run(func(args mock.Arguments) {
+			args.Get(0).(*domain.Task).ID = taskID
+		}).Return(nil).Once()
+		mockTasks.On("CreateTask", mock.AnythingOfType("*domain.Task"), userID.Hex()).Return(errors.New("project not found")).Once()
+		mockTasks.On("RemoveTask", taskID.Hex(), userID.Hex()).Return(nil).Once()
+
+		_, err := templateUsecase.Instantiate(templateID, domain.TemplateInstance{Variables: map[string]string{"version": "2.0"}}, userID.Hex())
+
+		assert.EqualError(t, err, "project not found")
+	})
+	mockRepo.AssertExpectations(t)
+	mockTasks.AssertExpectations(t)
+}
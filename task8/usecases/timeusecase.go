@@ -0,0 +1,252 @@
+package usecases
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	maxTimeEntry      = 24 * time.Hour
+	maxTimeEntryNote  = 500
+	maxTimesheetRange = 366 * 24 * time.Hour
+)
+
+type TimeUsecase struct {
+	repository domain.TimeEntryRepositoryInterface
+	tasks      domain.TaskUsecaseInterface
+	users      domain.UserRepositoryInterface
+}
+
+func NewTimeUsecase(repository domain.TimeEntryRepositoryInterface, tasks domain.TaskUsecaseInterface, users domain.UserRepositoryInterface) *TimeUsecase {
+	return &TimeUsecase{repository: repository, tasks: tasks, users: users}
+}
+
+// InWorkspace is the usecase limited to the tasks of one workspace.
+func (tu *TimeUsecase) InWorkspace(workspaceID string) (domain.TimeUsecaseInterface, error) {
+
+	tasks, err := tu.tasks.InWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	scoped := *tu
+	scoped.tasks = tasks
+	return &scoped, nil
+}
+
+// StartTimer starts tracking time on a task the user can see. A user has one
+// timer at a time, so the running one has to be stopped first.
+func (tu *TimeUsecase) StartTimer(taskid string, userid string) (*domain.TimeEntry, error) {
+
+	task, err := tu.tasks.GetTask(taskid, userid)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	running, err := tu.repository.GetRunningTimer(userid)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil && running.TaskID == task.ID {
+		return nil, errors.New("a timer is already running on this task")
+	}
+	if running != nil {
+		return nil, errors.New("another timer is already running, stop it first")
+	}
+
+	entry := &domain.TimeEntry{TaskID: task.ID, UserID: uid, Start: time.Now(), Running: true}
+	err = tu.repository.CreateTimeEntry(entry)
+
+	// A concurrent start got in between; the unique index turned this one away.
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, errors.New("another timer is already running, stop it first")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (tu *TimeUsecase) StopTimer(taskid string, userid string) (*domain.TimeEntry, error) {
+
+	if _, err := primitive.ObjectIDFromHex(userid); err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	if _, err := primitive.ObjectIDFromHex(taskid); err != nil {
+		return nil, errors.New("task ID is not a valid ObjectID")
+	}
+	entry, err := tu.repository.StopTimer(userid, taskid, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, errors.New("no timer is running on this task")
+	}
+	return entry, nil
+}
+
+// GetRunningTimer returns the user's running timer, or nil when none is.
+func (tu *TimeUsecase) GetRunningTimer(userid string) (*domain.TimeEntry, error) {
+
+	if _, err := primitive.ObjectIDFromHex(userid); err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	return tu.repository.GetRunningTimer(userid)
+}
+
+// CreateTimeEntry records time spent on a task after the fact.
+func (tu *TimeUsecase) CreateTimeEntry(taskid string, newentry *domain.TimeEntry, userid string) error {
+
+	task, err := tu.tasks.GetTask(taskid, userid)
+	if err != nil {
+		return err
+	}
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	if newentry.Start.IsZero() || newentry.End.IsZero() {
+		return errors.New("start and end are required")
+	}
+	if !newentry.End.After(newentry.Start) {
+		return errors.New("end must be after start")
+	}
+	if newentry.End.Sub(newentry.Start) > maxTimeEntry {
+		return errors.New("a time entry cannot exceed 24 hours")
+	}
+	if newentry.End.After(time.Now()) {
+		return errors.New("a time entry cannot end in the future")
+	}
+	if len(newentry.Note) > maxTimeEntryNote {
+		return errors.New("note is too long")
+	}
+
+	newentry.ID = primitive.NilObjectID
+	newentry.TaskID = task.ID
+	newentry.UserID = uid
+	newentry.Seconds = int64(newentry.End.Sub(newentry.Start) / time.Second)
+	newentry.Running = false
+	newentry.Manual = true
+	return tu.repository.CreateTimeEntry(newentry)
+}
+
+// GetTimeEntries lists the time the user logged on a task.
+func (tu *TimeUsecase) GetTimeEntries(taskid string, userid string) (*[]domain.TimeEntry, error) {
+
+	if _, err := tu.tasks.GetTask(taskid, userid); err != nil {
+		return nil, err
+	}
+	return tu.repository.GetTimeEntries(domain.TimeEntryFilter{UserID: userid, TaskID: taskid})
+}
+
+// RemoveTimeEntry deletes one of the user's entries; removing a running
+// timer discards it.
+func (tu *TimeUsecase) RemoveTimeEntry(taskid string, id string, userid string) error {
+
+	entry, err := tu.repository.GetTimeEntry(id)
+	if err != nil || entry.UserID.Hex() != userid || entry.TaskID.Hex() != taskid {
+		return errors.New("time entry not found")
+	}
+	return tu.repository.RemoveTimeEntry(id)
+}
+
+// GetTimesheet totals the user's time per task and per day, in their time
+// zone, over
+// [from, to), the last 30 days by default. Entries crossing midnight are
+// split between the days, and running timers count up to now.
+func (tu *TimeUsecase) GetTimesheet(userid string, from time.Time, to time.Time) (*domain.Timesheet, error) {
+
+	if _, err := primitive.ObjectIDFromHex(userid); err != nil {
+		return nil, errors.New("user ID is not a valid ObjectID")
+	}
+	now := time.Now()
+	if to.IsZero() {
+		to = now
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+	if !from.Before(to) {
+		return nil, errors.New("from must be before to")
+	}
+	if to.Sub(from) > maxTimesheetRange {
+		return nil, errors.New("the range cannot exceed 366 days")
+	}
+
+	entries, err := tu.repository.GetTimeEntries(domain.TimeEntryFilter{UserID: userid, From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+
+	location := userLocation(tu.users, userid)
+	type key struct {
+		day  string
+		task primitive.ObjectID
+	}
+	days := map[key]time.Duration{}
+	totals := map[primitive.ObjectID]time.Duration{}
+	for _, entry := range *entries {
+		start, end := entry.Start, entry.End
+		if entry.Running {
+			end = now
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		for start.Before(end) {
+			local := start.In(location)
+			day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+			until := day.AddDate(0, 0, 1)
+			if until.After(end) {
+				until = end
+			}
+			days[key{day.Format("2006-01-02"), entry.TaskID}] += until.Sub(start)
+			totals[entry.TaskID] += until.Sub(start)
+			start = until
+		}
+	}
+
+	// Time logged on a task since deleted, or no longer shared, keeps
+	// counting but loses its title.
+	ids := make([]primitive.ObjectID, 0, len(totals))
+	for taskid := range totals {
+		ids = append(ids, taskid)
+	}
+	titles := map[primitive.ObjectID]string{}
+	if len(ids) > 0 {
+		tasks, err := tu.tasks.GetTasksByIDs(ids, userid)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range *tasks {
+			titles[task.ID] = task.Title
+		}
+	}
+
+	timesheet := &domain.Timesheet{From: from, To: to, Days: []domain.TimesheetRow{}, Tasks: []domain.TimesheetRow{}}
+	for k, spent := range days {
+		timesheet.Days = append(timesheet.Days, domain.TimesheetRow{Day: k.day, TaskID: k.task, Title: titles[k.task], Seconds: int64(spent / time.Second)})
+	}
+	for taskid, spent := range totals {
+		timesheet.Tasks = append(timesheet.Tasks, domain.TimesheetRow{TaskID: taskid, Title: titles[taskid], Seconds: int64(spent / time.Second)})
+		timesheet.TotalSeconds += int64(spent / time.Second)
+	}
+	slices.SortFunc(timesheet.Days, func(a, b domain.TimesheetRow) int {
+		return cmp.Or(cmp.Compare(a.Day, b.Day), cmp.Compare(a.Title, b.Title), cmp.Compare(a.TaskID.Hex(), b.TaskID.Hex()))
+	})
+	slices.SortFunc(timesheet.Tasks, func(a, b domain.TimesheetRow) int {
+		return cmp.Or(cmp.Compare(b.Seconds, a.Seconds), cmp.Compare(a.Title, b.Title), cmp.Compare(a.TaskID.Hex(), b.TaskID.Hex()))
+	})
+	return timesheet, nil
+}
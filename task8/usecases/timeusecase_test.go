@@ -0,0 +1,184 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestStartTimer(t *testing.T) {
+	mockRepo := new(mocks.TimeEntryRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	timeUsecase := usecases.NewTimeUsecase(mockRepo, mockTasks, new(mocks.UserRepositoryInterface))
+
+	userID := primitive.NewObjectID().Hex()
+	taskID := primitive.NewObjectID()
+	mockTasks.On("GetTask", taskID.Hex(), userID).Return(&domain.Task{ID: taskID}, nil)
+
+	t.Run("starts a timer", func(t *testing.T) {
+		mockRepo.On("GetRunningTimer", userID).Return(nil, nil).Once()
+		mockRepo.On("CreateTimeEntry", mock.MatchedBy(func(e *domain.TimeEntry) bool {
+			return e.TaskID == taskID && e.Running && !e.Start.IsZero()
+		})).Return(nil).Once()
+
+		entry, err := timeUsecase.StartTimer(taskID.Hex(), userID)
+
+		assert.NoError(t, err)
+		assert.True(t, entry.Running)
+	})
+
+	t.Run("one running timer per user", func(t *testing.T) {
+		mockRepo.On("GetRunningTimer", userID).Return(&domain.TimeEntry{TaskID: taskID, Running: true}, nil).Once()
+
+		_, err := timeUsecase.StartTimer(taskID.Hex(), userID)
+		assert.EqualError(t, err, "a timer is already running on this task")
+
+		mockRepo.On("GetRunningTimer", userID).Return(&domain.TimeEntry{TaskID: primitive.NewObjectID(), Running: true}, nil).Once()
+
+		_, err = timeUsecase.StartTimer(taskID.Hex(), userID)
+		assert.EqualError(t, err, "another timer is already running, stop it first")
+	})
+
+	t.Run("concurrent start", func(t *testing.T) {
+		mockRepo.On("GetRunningTimer", userID).Return(nil, nil).Once()
+		mockRepo.On("CreateTimeEntry", mock.AnythingOfType("*domain.TimeEntry")).Return(mongo.WriteException{
+			WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key error"}},
+		}).Once()
+
+		_, err := timeUsecase.StartTimer(taskID.Hex(), userID)
+
+		assert.EqualError(t, err, "another timer is already running, stop it first")
+	})
+
+	t.Run("stop without a timer", func(t *testing.T) {
+		mockRepo.On("StopTimer", userID, taskID.Hex(), mock.AnythingOfType("time.Time")).Return(nil, nil).Once()
+
+		_, err := timeUsecase.StopTimer(taskID.Hex(), userID)
+
+		assert.EqualError(t, err, "no timer is running on this task")
+	})
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateTimeEntry(t *testing.T) {
+	mockRepo := new(mocks.TimeEntryRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	timeUsecase := usecases.NewTimeUsecase(mockRepo, mockTasks, new(mocks.UserRepositoryInterface))
+
+	userID := primitive.NewObjectID().Hex()
+	taskID := primitive.NewObjectID()
+	mockTasks.On("GetTask", taskID.Hex(), userID).Return(&domain.Task{ID: taskID}, nil)
+	start := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+
+	t.Run("manual entry", func(t *testing.T) {
+		mockRepo.On("CreateTimeEntry", mock.AnythingOfType("*domain.TimeEntry")).Return(nil).Once()
+
+		entry := &domain.TimeEntry{Start: start, End: start.Add(90 * time.Minute), Running: true, Note: "call with client"}
+		err := timeUsecase.CreateTimeEntry(taskID.Hex(), entry, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5400), entry.Seconds)
+		assert.True(t, entry.Manual)
+		assert.False(t, entry.Running)
+		assert.Equal(t, taskID, entry.TaskID)
+	})
+
+	cases := []struct {
+		entry domain.TimeEntry
+		err   string
+	}{
+		{domain.TimeEntry{Start: start}, "start and end are required"},
+		{domain.TimeEntry{Start: start, End: start}, "end must be after start"},
+		{domain.TimeEntry{Start: start, End: start.Add(25 * time.Hour)}, "a time entry cannot exceed 24 hours"},
+		{domain.TimeEntry{Start: time.Now(), End: time.Now().Add(time.Hour)}, "a time entry cannot end in the future"},
+	}
+	for _, c := range cases {
+		err := timeUsecase.CreateTimeEntry(taskID.Hex(), &c.entry, userID)
+		assert.EqualError(t, err, c.err)
+	}
+}
+
+func TestRemoveTimeEntry(t *testing.T) {
+	mockRepo := new(mocks.TimeEntryRepositoryInterface)
+	timeUsecase := usecases.NewTimeUsecase(mockRepo, new(mocks.TaskUsecaseInterface), new(mocks.UserRepositoryInterface))
+
+	userID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	entryID := primitive.NewObjectID()
+	mockRepo.On("GetTimeEntry", entryID.Hex()).Return(&domain.TimeEntry{ID: entryID, TaskID: taskID, UserID: userID}, nil)
+	mockRepo.On("RemoveTimeEntry", entryID.Hex()).Return(nil).Once()
+
+	assert.EqualError(t, timeUsecase.RemoveTimeEntry(taskID.Hex(), entryID.Hex(), primitive.NewObjectID().Hex()), "time entry not found")
+	assert.NoError(t, timeUsecase.RemoveTimeEntry(taskID.Hex(), entryID.Hex(), userID.Hex()))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetTimesheet(t *testing.T) {
+	mockRepo := new(mocks.TimeEntryRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	timeUsecase := usecases.NewTimeUsecase(mockRepo, mockTasks, mockUsers)
+
+	userID := primitive.NewObjectID().Hex()
+	design := primitive.NewObjectID()
+	review := primitive.NewObjectID()
+	gone := primitive.NewObjectID()
+	at := func(d, h, m int) time.Time { return time.Date(2024, time.March, d, h, m, 0, 0, time.UTC) }
+	from, to := at(1, 0, 0), at(4, 0, 0)
+
+	mockUsers.On("GetUserByID", userID).Return(&domain.User{}, nil).Once()
+	mockTasks.On("GetTasksByIDs", mock.MatchedBy(func(ids []primitive.ObjectID) bool { return len(ids) == 3 }), userID).
+		Return(&[]domain.Task{{ID: design, Title: "Design"}, {ID: review, Title: "Review"}}, nil).Once()
+	mockRepo.On("GetTimeEntries", domain.TimeEntryFilter{UserID: userID, From: from, To: to}).Return(&[]domain.TimeEntry{
+		// Starts before the range: only the part inside counts.
+		{TaskID: design, Start: at(0, 23, 0), End: at(1, 1, 0)},
+		// Crosses midnight: split between the two days.
+		{TaskID: design, Start: at(1, 23, 30), End: at(2, 0, 45)},
+		{TaskID: review, Start: at(2, 9, 0), End: at(2, 9, 20)},
+		{TaskID: gone, Start: at(3, 10, 0), End: at(3, 10, 1)},
+	}, nil).Once()
+
+	timesheet, err := timeUsecase.GetTimesheet(userID, from, to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.TimesheetRow{
+		{Day: "2024-03-01", TaskID: design, Title: "Design", Seconds: 5400},
+		{Day: "2024-03-02", TaskID: design, Title: "Design", Seconds: 2700},
+		{Day: "2024-03-02", TaskID: review, Title: "Review", Seconds: 1200},
+		{Day: "2024-03-03", TaskID: gone, Seconds: 60},
+	}, timesheet.Days)
+	assert.Equal(t, []domain.TimesheetRow{
+		{TaskID: design, Title: "Design", Seconds: 8100},
+		{TaskID: review, Title: "Review", Seconds: 1200},
+		{TaskID: gone, Seconds: 60},
+	}, timesheet.Tasks)
+	assert.Equal(t, int64(9360), timesheet.TotalSeconds)
+
+	t.Run("days follow the user's time zone", func(t *testing.T) {
+		mockUsers.On("GetUserByID", userID).Return(&domain.User{Timezone: "America/New_York"}, nil).Once()
+		mockTasks.On("GetTasksByIDs", []primitive.ObjectID{design}, userID).Return(&[]domain.Task{{ID: design, Title: "Design"}}, nil).Once()
+		// 03:30 UTC on the 2nd is still the evening of the 1st in New York.
+		mockRepo.On("GetTimeEntries", domain.TimeEntryFilter{UserID: userID, From: from, To: to}).Return(&[]domain.TimeEntry{
+			{TaskID: design, Start: at(2, 3, 30), End: at(2, 5, 30)},
+		}, nil).Once()
+
+		timesheet, err := timeUsecase.GetTimesheet(userID, from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.TimesheetRow{
+			{Day: "2024-03-01", TaskID: design, Title: "Design", Seconds: 5400},
+			{Day: "2024-03-02", TaskID: design, Title: "Design", Seconds: 1800},
+		}, timesheet.Days)
+	})
+
+	_, err = timeUsecase.GetTimesheet(userID, to, from)
+	assert.EqualError(t, err, "from must be before to")
+}
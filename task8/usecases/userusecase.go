@@ -1,8 +1,12 @@
 package usecases
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"task8/domain"
 	"task8/infrastructure"
+	"time"
 )
 
 type UserUsecase struct {
@@ -30,7 +34,7 @@ func (us *UserUsecase) Login(user *domain.User) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	token, err := us.js.NewToken(user.ID.Hex(), user.Email, role)
+	token, err := us.js.NewToken(user.ID.Hex(), user.Email, role, "")
 	if err != nil {
 		return "", err
 	}
@@ -56,3 +60,77 @@ func (us *UserUsecase) GetUsers() (*[]domain.User, error) {
 	}
 	return users, nil
 }
+
+func (us *UserUsecase) SetTimezone(userID string, timezone string) (*domain.User, error) {
+
+	location, err := loadTimezone(timezone)
+	if err != nil {
+		return nil, err
+	}
+	user, err := us.repository.SetTimezone(userID, location.String())
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// SetNextWeights sets the weights GET /tasks/next scores the user's tasks
+// with.
+func (us *UserUsecase) SetNextWeights(userID string, weights domain.NextWeights) (*domain.User, error) {
+
+	for _, weight := range []float64{weights.Priority, weights.Due, weights.Blocked, weights.Age} {
+		if weight < 0 || weight > maxNextWeight {
+			return nil, fmt.Errorf("weights must be between 0 and %d", maxNextWeight)
+		}
+	}
+	if weights.Priority+weights.Due+weights.Age == 0 {
+		return nil, errors.New("at least one of priority, due and age must have a weight")
+	}
+	user, err := us.repository.SetNextWeights(userID, weights)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// loadTimezone loads an IANA time zone given by a user. The server's own
+// zone, which "Local" and "" stand for, is not one.
+func loadTimezone(timezone string) (*time.Location, error) {
+
+	timezone = strings.TrimSpace(timezone)
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" || timezone == "Local" {
+		return nil, errors.New("timezone must be an IANA time zone such as Europe/Berlin")
+	}
+	return location, nil
+}
+
+// userLocation is the time zone of the user, UTC when they have not set one
+// or cannot be found.
+func userLocation(users domain.UserRepositoryInterface, userID string) *time.Location {
+
+	user, err := users.GetUserByID(userID)
+	if err != nil {
+		return time.UTC
+	}
+	return timezoneOf(user)
+}
+
+// timezoneOf is the time zone of a user, UTC when they have not set one.
+func timezoneOf(user *domain.User) *time.Location {
+
+	if user == nil || user.Timezone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
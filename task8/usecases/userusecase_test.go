@@ -22,7 +22,7 @@ func TestRegister(t *testing.T) {
 	}
 
 	mockRepo.On("Register", user).Return(nil)
-	mockjs.On("NewToken", user.ID.Hex(), user.Email, user.Role).Return("token", nil)
+	mockjs.On("NewToken", user.ID.Hex(), user.Email, user.Role, "").Return("token", nil)
 
 	err := userUsecase.Register(user)
 
@@ -40,7 +40,7 @@ func TestLogin(t *testing.T) {
 	}
 
 	mockRepo.On("Login", user).Return("user", nil)
-	mockjs.On("NewToken", user.ID.Hex(), user.Email, "user").Return("token", nil)
+	mockjs.On("NewToken", user.ID.Hex(), user.Email, "user", "").Return("token", nil)
 	token, err := userUsecase.Login(user)
 	t.Logf("Token: %s err: %s", token, err)
 
@@ -60,7 +60,7 @@ func TestLoginInvalidCredentials(t *testing.T) {
 	}
 
 	mockRepo.On("Login", user).Return("", errors.New("invalid email or password"))
-	mockjs.On("NewToken", user.ID.Hex(), user.Email, user.Role).Return("token", nil)
+	mockjs.On("NewToken", user.ID.Hex(), user.Email, user.Role, "").Return("token", nil)
 
 	token, err := userUsecase.Login(user)
 
@@ -109,3 +109,51 @@ func TestGetUsers(t *testing.T) {
 	assert.Equal(t, expectedUsers, users)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestSetTimezone(t *testing.T) {
+	mockRepo := new(mocks.UserRepositoryInterface)
+	mockjs := new(mocks.JWTService)
+	userUsecase := NewUserUsecase(mockRepo, mockjs)
+
+	mockRepo.On("SetTimezone", "userID", "Europe/Berlin").Return(&domain.User{Timezone: "Europe/Berlin"}, nil).Once()
+
+	user, err := userUsecase.SetTimezone("userID", " Europe/Berlin ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", user.Timezone)
+
+	for _, timezone := range []string{"", "Local", "Mars/Olympus"} {
+		_, err := userUsecase.SetTimezone("userID", timezone)
+
+		assert.EqualError(t, err, "timezone must be an IANA time zone such as Europe/Berlin")
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetNextWeights(t *testing.T) {
+	mockRepo := new(mocks.UserRepositoryInterface)
+	mockjs := new(mocks.JWTService)
+	userUsecase := NewUserUsecase(mockRepo, mockjs)
+
+	weights := domain.NextWeights{Priority: 5, Due: 2}
+	mockRepo.On("SetNextWeights", "userID", weights).Return(&domain.User{NextWeights: &weights}, nil).Once()
+	mockRepo.On("SetNextWeights", "unknownID", weights).Return(nil, nil).Once()
+
+	user, err := userUsecase.SetNextWeights("userID", weights)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &weights, user.NextWeights)
+
+	_, err = userUsecase.SetNextWeights("unknownID", weights)
+	assert.EqualError(t, err, "user not found")
+
+	_, err = userUsecase.SetNextWeights("userID", domain.NextWeights{Priority: 11})
+	assert.EqualError(t, err, "weights must be between 0 and 10")
+
+	_, err = userUsecase.SetNextWeights("userID", domain.NextWeights{Due: -1})
+	assert.EqualError(t, err, "weights must be between 0 and 10")
+
+	_, err = userUsecase.SetNextWeights("userID", domain.NextWeights{Blocked: 5})
+	assert.EqualError(t, err, "at least one of priority, due and age must have a weight")
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,183 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"task8/domain"
+	"time"
+)
+
+type ViewUsecase struct {
+	repository domain.ViewRepositoryInterface
+	tasks      domain.TaskUsecaseInterface
+	fields     domain.CustomFieldRepositoryInterface
+}
+
+func NewViewUsecase(repository domain.ViewRepositoryInterface, tasks domain.TaskUsecaseInterface, fields domain.CustomFieldRepositoryInterface) *ViewUsecase {
+	return &ViewUsecase{repository: repository, tasks: tasks, fields: fields}
+}
+
+// InWorkspace is the usecase limited to the views of one workspace, which
+// run over the tasks of that workspace.
+func (vu *ViewUsecase) InWorkspace(workspaceID string) (domain.ViewUsecaseInterface, error) {
+
+	wid, err := workspaceObjectID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := vu.tasks.InWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	scoped := *vu
+	scoped.repository = vu.repository.InWorkspace(wid)
+	scoped.tasks = tasks
+	scoped.fields = vu.fields.InWorkspace(wid)
+	return &scoped, nil
+}
+
+func (vu *ViewUsecase) CreateView(newview *domain.View, userid string) error {
+
+	if err := validateView(newview); err != nil {
+		return err
+	}
+	newview.CreatedAt = time.Now()
+	return vu.repository.CreateView(newview, userid)
+}
+
+// GetViews lists the user's views with the number of tasks each matches
+// right now.
+func (vu *ViewUsecase) GetViews(userid string) (*[]domain.View, error) {
+
+	views, err := vu.repository.GetViews(userid)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *views {
+		filter, err := vu.liveFilter((*views)[i].Filter, userid)
+		if err != nil {
+			return nil, err
+		}
+		count, err := vu.tasks.CountTasks(userid, filter)
+		if err != nil {
+			return nil, err
+		}
+		(*views)[i].Count = count
+	}
+	return views, nil
+}
+
+func (vu *ViewUsecase) UpdateView(id string, updatedview *domain.View, userid string) error {
+
+	if _, err := vu.ownedView(id, userid); err != nil {
+		return err
+	}
+	if err := validateView(updatedview); err != nil {
+		return err
+	}
+	return vu.repository.UpdateView(id, updatedview)
+}
+
+func (vu *ViewUsecase) PinView(id string, pinned bool, userid string) (*domain.View, error) {
+
+	view, err := vu.ownedView(id, userid)
+	if err != nil {
+		return nil, err
+	}
+	if err := vu.repository.PinView(id, pinned); err != nil {
+		return nil, err
+	}
+	view.Pinned = pinned
+	return view, nil
+}
+
+func (vu *ViewUsecase) RemoveView(id string, userid string) error {
+
+	if _, err := vu.ownedView(id, userid); err != nil {
+		return err
+	}
+	return vu.repository.RemoveView(id)
+}
+
+// GetViewTasks runs the filter of a view. A page, given by a limit, comes
+// with the number of tasks on every page; otherwise that is the length of
+// the list.
+func (vu *ViewUsecase) GetViewTasks(id string, userid string, limit int, offset int) (*[]domain.Task, int64, error) {
+
+	view, err := vu.ownedView(id, userid)
+	if err != nil {
+		return nil, 0, err
+	}
+	live, err := vu.liveFilter(view.Filter, userid)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter := live
+	filter.Limit = limit
+	filter.Offset = offset
+	tasks, err := vu.tasks.FilterTasks(userid, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if limit == 0 {
+		return tasks, int64(len(*tasks)), nil
+	}
+	total, err := vu.tasks.CountTasks(userid, live)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tasks, total, nil
+}
+
+// liveFilter is the filter of a view without its clauses on custom fields
+// or enum options removed since it was saved, which no task can match.
+func (vu *ViewUsecase) liveFilter(filter domain.TaskFilter, userid string) (domain.TaskFilter, error) {
+
+	sortField, sortsByField := strings.CutPrefix(strings.TrimPrefix(filter.Sort, "-"), fieldSortPrefix)
+	if len(filter.Fields) == 0 && !sortsByField {
+		return filter, nil
+	}
+	fields, err := vu.fields.GetFields(userid)
+	if err != nil {
+		return filter, err
+	}
+	byKey := make(map[string]domain.CustomField, len(*fields))
+	for _, field := range *fields {
+		byKey[field.Key] = field
+	}
+	if _, ok := byKey[sortField]; sortsByField && !ok {
+		filter.Sort = ""
+	}
+	live := make(map[string]string, len(filter.Fields))
+	for key, value := range filter.Fields {
+		field, ok := byKey[key]
+		if !ok || (field.Type == domain.FieldEnum && !slices.Contains(field.Options, value)) {
+			continue
+		}
+		live[key] = value
+	}
+	filter.Fields = live
+	return filter, nil
+}
+
+func (vu *ViewUsecase) ownedView(id string, userid string) (*domain.View, error) {
+
+	view, err := vu.repository.GetView(id)
+	if err != nil {
+		return nil, err
+	}
+	if view.UserID.Hex() != userid {
+		return nil, errors.New("view not found")
+	}
+	return view, nil
+}
+
+func validateView(view *domain.View) error {
+
+	view.Name = strings.TrimSpace(view.Name)
+	if view.Name == "" {
+		return errors.New("incomplete information")
+	}
+	return checkTaskFilter(&view.Filter)
+}
@@ -0,0 +1,145 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateView(t *testing.T) {
+	mockRepo := new(mocks.ViewRepositoryInterface)
+	viewUsecase := usecases.NewViewUsecase(mockRepo, new(mocks.TaskUsecaseInterface), new(mocks.CustomFieldRepositoryInterface))
+	userID := primitive.NewObjectID().Hex()
+
+	t.Run("normalizes the filter", func(t *testing.T) {
+		mockRepo.On("CreateView", mock.AnythingOfType("*domain.View"), userID).Return(nil).Once()
+
+		view := &domain.View{Name: " Urgent ", Filter: domain.TaskFilter{Tags: []string{"Urgent "}, Text: " invoice "}}
+		err := viewUsecase.CreateView(view, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Urgent", view.Name)
+		assert.Equal(t, domain.TaskFilter{Tags: []string{"urgent"}, Text: "invoice"}, view.Filter)
+	})
+
+	t.Run("rejects a filter that cannot run", func(t *testing.T) {
+		err := viewUsecase.CreateView(&domain.View{Name: "Soon", Filter: domain.TaskFilter{DueTo: "soonish"}}, userID)
+
+		assert.EqualError(t, err, `could not read "soonish" as a date`)
+	})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetViews(t *testing.T) {
+	mockRepo := new(mocks.ViewRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	viewUsecase := usecases.NewViewUsecase(mockRepo, mockTasks, new(mocks.CustomFieldRepositoryInterface))
+	userID := primitive.NewObjectID().Hex()
+
+	urgent := domain.TaskFilter{Tags: []string{"urgent"}}
+	mine := domain.TaskFilter{AssigneeID: "me"}
+	mockRepo.On("GetViews", userID).Return(&[]domain.View{{Name: "Urgent", Filter: urgent, Pinned: true}, {Name: "Mine", Filter: mine}}, nil).Once()
+	mockTasks.On("CountTasks", userID, urgent).Return(int64(3), nil).Once()
+	mockTasks.On("CountTasks", userID, mine).Return(int64(8), nil).Once()
+
+	views, err := viewUsecase.GetViews(userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), (*views)[0].Count)
+	assert.Equal(t, int64(8), (*views)[1].Count)
+	mockRepo.AssertExpectations(t)
+	mockTasks.AssertExpectations(t)
+}
+
+// A view saved with a custom field or option removed since keeps working,
+// without that clause.
+func TestGetViewsStaleFields(t *testing.T) {
+	mockRepo := new(mocks.ViewRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	mockFields := new(mocks.CustomFieldRepositoryInterface)
+	viewUsecase := usecases.NewViewUsecase(mockRepo, mockTasks, mockFields)
+	userID := primitive.NewObjectID().Hex()
+
+	stale := domain.TaskFilter{Fields: map[string]string{"customer": "acme", "stage": "lost", "priority": "high"}, Sort: "-field.customer"}
+	mockRepo.On("GetViews", userID).Return(&[]domain.View{{Name: "Stale", Filter: stale}}, nil).Once()
+	mockFields.On("GetFields", userID).Return(&[]domain.CustomField{
+		{Key: "stage", Type: domain.FieldEnum, Options: []string{"open", "won"}},
+		{Key: "priority", Type: domain.FieldEnum, Options: []string{"low", "high"}},
+	}, nil).Once()
+	live := domain.TaskFilter{Fields: map[string]string{"priority": "high"}}
+	mockTasks.On("CountTasks", userID, live).Return(int64(2), nil).Once()
+
+	views, err := viewUsecase.GetViews(userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), (*views)[0].Count)
+	mockFields.AssertExpectations(t)
+	mockTasks.AssertExpectations(t)
+}
+
+func TestGetViewTasks(t *testing.T) {
+	mockRepo := new(mocks.ViewRepositoryInterface)
+	mockTasks := new(mocks.TaskUsecaseInterface)
+	viewUsecase := usecases.NewViewUsecase(mockRepo, mockTasks, new(mocks.CustomFieldRepositoryInterface))
+
+	userID := primitive.NewObjectID()
+	viewID := primitive.NewObjectID().Hex()
+	filter := domain.TaskFilter{Statuses: []string{"pending"}}
+	view := &domain.View{UserID: userID, Filter: filter}
+
+	t.Run("runs a page of the stored filter", func(t *testing.T) {
+		mockRepo.On("GetView", viewID).Return(view, nil).Once()
+		paged := filter
+		paged.Limit = 10
+		paged.Offset = 20
+		mockTasks.On("FilterTasks", userID.Hex(), paged).Return(&[]domain.Task{{Title: "Task 21"}}, nil).Once()
+		mockTasks.On("CountTasks", userID.Hex(), filter).Return(int64(21), nil).Once()
+
+		tasks, total, err := viewUsecase.GetViewTasks(viewID, userID.Hex(), 10, 20)
+
+		assert.NoError(t, err)
+		assert.Len(t, *tasks, 1)
+		assert.Equal(t, int64(21), total)
+	})
+
+	t.Run("every task without a limit", func(t *testing.T) {
+		mockRepo.On("GetView", viewID).Return(view, nil).Once()
+		mockTasks.On("FilterTasks", userID.Hex(), filter).Return(&[]domain.Task{{}, {}}, nil).Once()
+
+		_, total, err := viewUsecase.GetViewTasks(viewID, userID.Hex(), 0, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+	})
+
+	t.Run("views of other users are not found", func(t *testing.T) {
+		mockRepo.On("GetView", viewID).Return(view, nil).Once()
+
+		_, _, err := viewUsecase.GetViewTasks(viewID, primitive.NewObjectID().Hex(), 0, 0)
+
+		assert.EqualError(t, err, "view not found")
+	})
+	mockRepo.AssertExpectations(t)
+	mockTasks.AssertExpectations(t)
+}
+
+func TestPinView(t *testing.T) {
+	mockRepo := new(mocks.ViewRepositoryInterface)
+	viewUsecase := usecases.NewViewUsecase(mockRepo, new(mocks.TaskUsecaseInterface), new(mocks.CustomFieldRepositoryInterface))
+
+	userID := primitive.NewObjectID()
+	viewID := primitive.NewObjectID().Hex()
+	mockRepo.On("GetView", viewID).Return(&domain.View{UserID: userID, Name: "Urgent"}, nil).Once()
+	mockRepo.On("PinView", viewID, true).Return(nil).Once()
+
+	view, err := viewUsecase.PinView(viewID, true, userID.Hex())
+
+	assert.NoError(t, err)
+	assert.True(t, view.Pinned)
+	mockRepo.AssertExpectations(t)
+}
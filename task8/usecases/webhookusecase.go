@@ -0,0 +1,234 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/url"
+	"slices"
+	"task8/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	deliveryLease        = time.Minute
+	maxDeliveryAttempts  = 8
+	firstDeliveryBackoff = 30 * time.Second
+	maxDeliveryBackoff   = 6 * time.Hour
+	maxDeliveriesPerRun  = 100
+)
+
+type WebhookUsecase struct {
+	repository domain.WebhookRepositoryInterface
+	projects   domain.ProjectRepositoryInterface
+	workspaces domain.WorkspaceRepositoryInterface
+	sender     domain.WebhookSender
+}
+
+func NewWebhookUsecase(repository domain.WebhookRepositoryInterface, projects domain.ProjectRepositoryInterface, workspaces domain.WorkspaceRepositoryInterface, sender domain.WebhookSender) *WebhookUsecase {
+	return &WebhookUsecase{repository: repository, projects: projects, workspaces: workspaces, sender: sender}
+}
+
+// CreateWebhook registers an endpoint for the user. Unless one is given, a
+// signing secret is generated; it is only shown in this response.
+func (wu *WebhookUsecase) CreateWebhook(newwebhook *domain.Webhook, userid string) error {
+
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	target, err := url.Parse(newwebhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return errors.New("webhook URL must be an absolute http(s) URL")
+	}
+	if len(newwebhook.Events) == 0 {
+		return errors.New("at least one event is required")
+	}
+	for _, event := range newwebhook.Events {
+		if !slices.Contains(domain.TaskEvents, event) {
+			return errors.New("unknown event " + event)
+		}
+	}
+	if newwebhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return err
+		}
+		newwebhook.Secret = hex.EncodeToString(secret)
+	}
+
+	newwebhook.ID = primitive.NilObjectID
+	newwebhook.UserID = uid
+	newwebhook.CreatedAt = time.Now()
+	return wu.repository.CreateWebhook(newwebhook)
+}
+
+func (wu *WebhookUsecase) GetWebhooks(userid string) (*[]domain.Webhook, error) {
+
+	webhooks, err := wu.repository.GetWebhooks(userid)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *webhooks {
+		(*webhooks)[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (wu *WebhookUsecase) RemoveWebhook(id string, userid string) error {
+
+	webhook, err := wu.repository.GetWebhook(id)
+	if err != nil || webhook.UserID.Hex() != userid {
+		return errors.New("webhook not found")
+	}
+	return wu.repository.RemoveWebhook(id)
+}
+
+func (wu *WebhookUsecase) GetDeliveries(filter domain.DeliveryFilter) (*[]domain.WebhookDelivery, error) {
+	return wu.repository.GetDeliveries(filter)
+}
+
+// ReplayDelivery queues a delivery again, whatever its state, with a fresh
+// set of attempts.
+func (wu *WebhookUsecase) ReplayDelivery(id string) (*domain.WebhookDelivery, error) {
+
+	delivery, err := wu.repository.GetDelivery(id)
+	if err != nil {
+		return nil, errors.New("delivery not found")
+	}
+	if delivery.Status == domain.DeliverySending {
+		return nil, errors.New("delivery is in progress")
+	}
+	delivery.Status = domain.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttempt = time.Now()
+	delivery.DeliveredAt = time.Time{}
+	if err := wu.repository.UpdateDelivery(id, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Publish queues a delivery of the event to every subscribed webhook of the
+// users involved in the task. Failures are logged; they never fail the
+// change that triggered the event.
+func (wu *WebhookUsecase) Publish(event string, task *domain.Task) {
+
+	if err := wu.enqueue(event, task); err != nil {
+		log.Printf("webhooks: %s for task %s: %v", event, task.ID.Hex(), err)
+	}
+}
+
+func (wu *WebhookUsecase) enqueue(event string, task *domain.Task) error {
+
+	audience, err := taskAudience(wu.projects, wu.workspaces, task)
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := wu.repository.FindSubscribers(event, audience)
+	if err != nil || len(*webhooks) == 0 {
+		return err
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(struct {
+		Event      string       `json:"event"`
+		OccurredAt time.Time    `json:"occurred_at"`
+		Task       *domain.Task `json:"task"`
+	}{event, now, task})
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]domain.WebhookDelivery, 0, len(*webhooks))
+	for _, webhook := range *webhooks {
+		deliveries = append(deliveries, domain.WebhookDelivery{
+			WebhookID:   webhook.ID,
+			UserID:      webhook.UserID,
+			URL:         webhook.URL,
+			Event:       event,
+			Payload:     string(payload),
+			Status:      domain.DeliveryPending,
+			NextAttempt: now,
+			CreatedAt:   now,
+		})
+	}
+	return wu.repository.CreateDeliveries(deliveries)
+}
+
+// DispatchDeliveries sends the deliveries that are due at now and reports
+// how many succeeded. It is run by the background scheduler. A failed
+// delivery is retried with exponential backoff and dead-lettered after
+// maxDeliveryAttempts.
+func (wu *WebhookUsecase) DispatchDeliveries(now time.Time) (int, error) {
+
+	delivered := 0
+	for i := 0; i < maxDeliveriesPerRun; i++ {
+		delivery, err := wu.repository.ClaimDelivery(now, deliveryLease)
+		if err != nil {
+			return delivered, err
+		}
+		if delivery == nil {
+			return delivered, nil
+		}
+		if wu.attempt(delivery, now) {
+			delivered++
+		}
+		if err := wu.repository.UpdateDelivery(delivery.ID.Hex(), delivery); err != nil {
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
+
+// attempt sends one claimed delivery and moves it to its next state.
+func (wu *WebhookUsecase) attempt(delivery *domain.WebhookDelivery, now time.Time) bool {
+
+	webhook, err := wu.repository.GetWebhook(delivery.WebhookID.Hex())
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		delivery.Status = domain.DeliveryDead
+		delivery.LastError = "webhook was removed"
+		return false
+	}
+
+	code := 0
+	if err == nil {
+		code, err = wu.sender.Send(delivery, webhook.Secret)
+	}
+	delivery.ResponseCode = code
+	if err == nil {
+		delivery.Status = domain.DeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = time.Now()
+		return true
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= maxDeliveryAttempts {
+		delivery.Status = domain.DeliveryDead
+		return false
+	}
+	delivery.Status = domain.DeliveryPending
+	delivery.NextAttempt = now.Add(deliveryBackoff(delivery.Attempts))
+	return false
+}
+
+// deliveryBackoff is the wait after the given failed attempt: 30s, 1m, 2m, ...
+// capped at maxDeliveryBackoff.
+func deliveryBackoff(attempts int) time.Duration {
+
+	backoff := firstDeliveryBackoff
+	for i := 1; i < attempts && backoff < maxDeliveryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxDeliveryBackoff {
+		backoff = maxDeliveryBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,165 @@
+package usecases_test
+
+import (
+	"errors"
+	"strings"
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	mockRepo := new(mocks.WebhookRepositoryInterface)
+	webhookUsecase := usecases.NewWebhookUsecase(mockRepo, new(mocks.ProjectRepositoryInterface), new(mocks.WorkspaceRepositoryInterface), new(mocks.WebhookSender))
+	userID := primitive.NewObjectID()
+
+	t.Run("generates a secret", func(t *testing.T) {
+		mockRepo.On("CreateWebhook", mock.AnythingOfType("*domain.Webhook")).Return(nil).Once()
+
+		webhook := &domain.Webhook{URL: "https://example.com/hook", Events: []string{domain.EventTaskCompleted}}
+		err := webhookUsecase.CreateWebhook(webhook, userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, webhook.Secret, 64)
+		assert.Equal(t, userID, webhook.UserID)
+	})
+
+	t.Run("rejects unknown events", func(t *testing.T) {
+		err := webhookUsecase.CreateWebhook(&domain.Webhook{URL: "https://example.com/hook", Events: []string{"task.exploded"}}, userID.Hex())
+
+		assert.EqualError(t, err, "unknown event task.exploded")
+	})
+
+	t.Run("rejects a relative URL", func(t *testing.T) {
+		err := webhookUsecase.CreateWebhook(&domain.Webhook{URL: "/hook", Events: []string{domain.EventTaskCreated}}, userID.Hex())
+
+		assert.EqualError(t, err, "webhook URL must be an absolute http(s) URL")
+	})
+}
+
+func TestPublishTaskEvent(t *testing.T) {
+	mockRepo := new(mocks.WebhookRepositoryInterface)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	webhookUsecase := usecases.NewWebhookUsecase(mockRepo, mockProjects, new(mocks.WorkspaceRepositoryInterface), new(mocks.WebhookSender))
+
+	ownerID := primitive.NewObjectID()
+	memberID := primitive.NewObjectID()
+	projectID := primitive.NewObjectID()
+	webhookID := primitive.NewObjectID()
+	task := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, ProjectID: projectID, Title: "Report"}
+
+	mockProjects.On("GetProject", projectID.Hex()).Return(&domain.Project{Members: []domain.ProjectMember{{UserID: memberID}}}, nil)
+	mockRepo.On("FindSubscribers", domain.EventTaskCompleted, []primitive.ObjectID{ownerID, memberID}).
+		Return(&[]domain.Webhook{{ID: webhookID, UserID: memberID, URL: "https://example.com/hook"}}, nil)
+	mockRepo.On("CreateDeliveries", mock.MatchedBy(func(deliveries []domain.WebhookDelivery) bool {
+		return len(deliveries) == 1 && deliveries[0].WebhookID == webhookID &&
+			deliveries[0].Status == domain.DeliveryPending &&
+			strings.Contains(deliveries[0].Payload, `"event":"task.completed"`) &&
+			strings.Contains(deliveries[0].Payload, `"title":"Report"`)
+	})).Return(nil).Once()
+
+	webhookUsecase.Publish(domain.EventTaskCompleted, task)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// A member removed from the workspace gets no more of its task events, even
+// through a project they were left on.
+func TestPublishTaskEventWorkspace(t *testing.T) {
+	mockRepo := new(mocks.WebhookRepositoryInterface)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockWorkspaces := new(mocks.WorkspaceRepositoryInterface)
+	webhookUsecase := usecases.NewWebhookUsecase(mockRepo, mockProjects, mockWorkspaces, new(mocks.WebhookSender))
+
+	ownerID := primitive.NewObjectID()
+	formerID := primitive.NewObjectID()
+	projectID := primitive.NewObjectID()
+	workspace := &domain.Workspace{ID: primitive.NewObjectID(), Members: []domain.WorkspaceMembership{{UserID: ownerID, Role: domain.WorkspaceOwner}}}
+	task := &domain.Task{ID: primitive.NewObjectID(), UserID: ownerID, ProjectID: projectID, WorkspaceID: workspace.ID, Watchers: []primitive.ObjectID{formerID}}
+
+	mockProjects.On("GetProject", projectID.Hex()).Return(&domain.Project{Members: []domain.ProjectMember{{UserID: formerID}}}, nil)
+	mockWorkspaces.On("GetWorkspace", workspace.ID.Hex()).Return(workspace, nil).Once()
+	mockRepo.On("FindSubscribers", domain.EventTaskUpdated, []primitive.ObjectID{ownerID}).Return(&[]domain.Webhook{}, nil).Once()
+
+	webhookUsecase.Publish(domain.EventTaskUpdated, task)
+
+	mockRepo.AssertExpectations(t)
+	mockWorkspaces.AssertExpectations(t)
+}
+
+func TestDispatchDeliveries(t *testing.T) {
+	now := time.Now()
+	webhook := &domain.Webhook{ID: primitive.NewObjectID(), Secret: "s3cret"}
+
+	setup := func(delivery *domain.WebhookDelivery) (*mocks.WebhookRepositoryInterface, *mocks.WebhookSender, *usecases.WebhookUsecase) {
+		mockRepo := new(mocks.WebhookRepositoryInterface)
+		mockSender := new(mocks.WebhookSender)
+		mockRepo.On("ClaimDelivery", now, mock.Anything).Return(delivery, nil).Once()
+		mockRepo.On("ClaimDelivery", now, mock.Anything).Return(nil, nil).Once()
+		mockRepo.On("GetWebhook", webhook.ID.Hex()).Return(webhook, nil)
+		return mockRepo, mockSender, usecases.NewWebhookUsecase(mockRepo, new(mocks.ProjectRepositoryInterface), new(mocks.WorkspaceRepositoryInterface), mockSender)
+	}
+
+	t.Run("marks a successful delivery", func(t *testing.T) {
+		delivery := &domain.WebhookDelivery{ID: primitive.NewObjectID(), WebhookID: webhook.ID, Attempts: 1}
+		mockRepo, mockSender, webhookUsecase := setup(delivery)
+		mockSender.On("Send", delivery, "s3cret").Return(200, nil).Once()
+		mockRepo.On("UpdateDelivery", delivery.ID.Hex(), delivery).Return(nil).Once()
+
+		delivered, err := webhookUsecase.DispatchDeliveries(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, domain.DeliveryDelivered, delivery.Status)
+		assert.Equal(t, 200, delivery.ResponseCode)
+	})
+
+	t.Run("backs off exponentially", func(t *testing.T) {
+		delivery := &domain.WebhookDelivery{ID: primitive.NewObjectID(), WebhookID: webhook.ID, Attempts: 3}
+		mockRepo, mockSender, webhookUsecase := setup(delivery)
+		mockSender.On("Send", delivery, "s3cret").Return(500, errors.New("webhook answered 500 Internal Server Error")).Once()
+		mockRepo.On("UpdateDelivery", delivery.ID.Hex(), delivery).Return(nil).Once()
+
+		_, err := webhookUsecase.DispatchDeliveries(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DeliveryPending, delivery.Status)
+		assert.Equal(t, now.Add(2*time.Minute), delivery.NextAttempt)
+	})
+
+	t.Run("dead-letters after the last attempt", func(t *testing.T) {
+		delivery := &domain.WebhookDelivery{ID: primitive.NewObjectID(), WebhookID: webhook.ID, Attempts: 8}
+		mockRepo, mockSender, webhookUsecase := setup(delivery)
+		mockSender.On("Send", delivery, "s3cret").Return(0, errors.New("connection refused")).Once()
+		mockRepo.On("UpdateDelivery", delivery.ID.Hex(), delivery).Return(nil).Once()
+
+		_, err := webhookUsecase.DispatchDeliveries(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DeliveryDead, delivery.Status)
+		assert.Equal(t, "connection refused", delivery.LastError)
+	})
+}
+
+func TestReplayDelivery(t *testing.T) {
+	mockRepo := new(mocks.WebhookRepositoryInterface)
+	webhookUsecase := usecases.NewWebhookUsecase(mockRepo, new(mocks.ProjectRepositoryInterface), new(mocks.WorkspaceRepositoryInterface), new(mocks.WebhookSender))
+	deliveryID := primitive.NewObjectID()
+
+	mockRepo.On("GetDelivery", deliveryID.Hex()).Return(&domain.WebhookDelivery{ID: deliveryID, Status: domain.DeliveryDead, Attempts: 8}, nil).Once()
+	mockRepo.On("UpdateDelivery", deliveryID.Hex(), mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+		return d.Status == domain.DeliveryPending && d.Attempts == 0
+	})).Return(nil).Once()
+
+	delivery, err := webhookUsecase.ReplayDelivery(deliveryID.Hex())
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,216 @@
+package usecases
+
+import (
+	"errors"
+	"task8/domain"
+	"task8/infrastructure"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var workspaceRoleRank = map[string]int{
+	domain.WorkspaceMember: 1,
+	domain.WorkspaceAdmin:  2,
+	domain.WorkspaceOwner:  3,
+}
+
+type WorkspaceUsecase struct {
+	repository domain.WorkspaceRepositoryInterface
+	users      domain.UserRepositoryInterface
+	js         infrastructure.JWTService
+}
+
+func NewWorkspaceUsecase(repository domain.WorkspaceRepositoryInterface, users domain.UserRepositoryInterface, js infrastructure.JWTService) *WorkspaceUsecase {
+	return &WorkspaceUsecase{repository: repository, users: users, js: js}
+}
+
+func (wu *WorkspaceUsecase) CreateWorkspace(newworkspace *domain.Workspace, userid string) error {
+
+	if newworkspace.Name == "" {
+		return errors.New("incomplete information")
+	}
+	uid, err := primitive.ObjectIDFromHex(userid)
+	if err != nil {
+		return errors.New("user ID is not a valid ObjectID")
+	}
+	newworkspace.Members = []domain.WorkspaceMembership{{UserID: uid, Role: domain.WorkspaceOwner}}
+	newworkspace.CreatedAt = time.Now()
+	return wu.repository.CreateWorkspace(newworkspace)
+}
+
+func (wu *WorkspaceUsecase) GetWorkspace(id string, userid string) (*domain.Workspace, error) {
+	return wu.authorize(id, userid, domain.WorkspaceMember)
+}
+
+func (wu *WorkspaceUsecase) GetWorkspaces(userid string) (*[]domain.Workspace, error) {
+	return wu.repository.GetWorkspaces(userid)
+}
+
+func (wu *WorkspaceUsecase) UpdateWorkspace(id string, updatedworkspace *domain.Workspace, userid string) (*domain.Workspace, error) {
+
+	workspace, err := wu.authorize(id, userid, domain.WorkspaceAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if updatedworkspace.Name == "" {
+		return nil, errors.New("incomplete information")
+	}
+	workspace.Name = updatedworkspace.Name
+	if err := wu.repository.UpdateWorkspace(id, workspace); err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// RemoveWorkspace deletes the workspace with all its projects and tasks.
+func (wu *WorkspaceUsecase) RemoveWorkspace(id string, userid string) error {
+
+	if _, err := wu.authorize(id, userid, domain.WorkspaceOwner); err != nil {
+		return err
+	}
+	return wu.repository.RemoveWorkspace(id)
+}
+
+// AddMember adds the user with the given email to the workspace, or changes
+// their role when they already are a member. Admins manage members and
+// admins; only owners make or unmake owners.
+func (wu *WorkspaceUsecase) AddMember(id string, email string, role string, userid string) (*domain.Workspace, error) {
+
+	workspace, err := wu.authorize(id, userid, domain.WorkspaceAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if workspaceRoleRank[role] == 0 {
+		return nil, errors.New("role must be owner, admin or member")
+	}
+	user, err := wu.users.GetUser(email)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	owner := workspaceRole(workspace, userid) == domain.WorkspaceOwner
+	if !owner && (role == domain.WorkspaceOwner || workspaceRole(workspace, user.ID.Hex()) == domain.WorkspaceOwner) {
+		return nil, errors.New("insufficient workspace permissions")
+	}
+
+	found := false
+	for i := range workspace.Members {
+		if workspace.Members[i].UserID == user.ID {
+			workspace.Members[i].Role = role
+			found = true
+		}
+	}
+	if !found {
+		workspace.Members = append(workspace.Members, domain.WorkspaceMembership{UserID: user.ID, Role: role})
+	}
+	if !hasWorkspaceOwner(workspace) {
+		return nil, errors.New("a workspace needs at least one owner")
+	}
+
+	if err := wu.repository.UpdateWorkspace(id, workspace); err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// RemoveMember takes a user out of the workspace. Admins may remove members
+// and admins, owners anyone, and every member may leave, as long as an
+// owner remains. Their tasks stay in the workspace.
+func (wu *WorkspaceUsecase) RemoveMember(id string, memberid string, userid string) (*domain.Workspace, error) {
+
+	role := domain.WorkspaceAdmin
+	if memberid == userid {
+		role = domain.WorkspaceMember
+	}
+	workspace, err := wu.authorize(id, userid, role)
+	if err != nil {
+		return nil, err
+	}
+	if memberid != userid && workspaceRole(workspace, memberid) == domain.WorkspaceOwner && workspaceRole(workspace, userid) != domain.WorkspaceOwner {
+		return nil, errors.New("insufficient workspace permissions")
+	}
+
+	members := []domain.WorkspaceMembership{}
+	for _, member := range workspace.Members {
+		if member.UserID.Hex() != memberid {
+			members = append(members, member)
+		}
+	}
+	if len(members) == len(workspace.Members) {
+		return nil, errors.New("user is not a member of the workspace")
+	}
+	workspace.Members = members
+	if !hasWorkspaceOwner(workspace) {
+		return nil, errors.New("a workspace needs at least one owner")
+	}
+
+	if err := wu.repository.UpdateWorkspace(id, workspace); err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// SwitchWorkspace issues a token with the workspace as the active one. An
+// empty id switches back to the personal workspace.
+func (wu *WorkspaceUsecase) SwitchWorkspace(id string, userid string) (string, error) {
+
+	if id != "" {
+		if _, err := wu.authorize(id, userid, domain.WorkspaceMember); err != nil {
+			return "", err
+		}
+	}
+	user, err := wu.users.GetUserByID(userid)
+	if err != nil || user == nil {
+		return "", errors.New("user not found")
+	}
+	return wu.js.NewToken(userid, user.Email, user.Role, id)
+}
+
+func (wu *WorkspaceUsecase) authorize(id string, userid string, role string) (*domain.Workspace, error) {
+
+	workspace, err := wu.repository.GetWorkspace(id)
+	if err != nil {
+		return nil, errors.New("workspace not found")
+	}
+	if workspaceRole(workspace, userid) == "" {
+		return nil, errors.New("workspace not found")
+	}
+	if workspaceRoleRank[workspaceRole(workspace, userid)] < workspaceRoleRank[role] {
+		return nil, errors.New("insufficient workspace permissions")
+	}
+	return workspace, nil
+}
+
+// workspaceRole returns the role the user holds in the workspace, or "" when
+// they are not a member.
+func workspaceRole(workspace *domain.Workspace, userid string) string {
+	for _, member := range workspace.Members {
+		if member.UserID.Hex() == userid {
+			return member.Role
+		}
+	}
+	return ""
+}
+
+func hasWorkspaceOwner(workspace *domain.Workspace) bool {
+	for _, member := range workspace.Members {
+		if member.Role == domain.WorkspaceOwner {
+			return true
+		}
+	}
+	return false
+}
+
+// workspaceObjectID reads the workspace ID a usecase is limited to. Only
+// the empty string stands for the personal workspace.
+func workspaceObjectID(workspaceID string) (primitive.ObjectID, error) {
+
+	if workspaceID == "" {
+		return primitive.NilObjectID, nil
+	}
+	wid, err := primitive.ObjectIDFromHex(workspaceID)
+	if err != nil {
+		return primitive.NilObjectID, errors.New("workspace ID is not a valid ObjectID")
+	}
+	return wid, nil
+}
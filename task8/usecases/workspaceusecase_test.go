@@ -0,0 +1,256 @@
+package usecases_test
+
+import (
+	"task8/domain"
+	"task8/mocks"
+	"task8/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestCreateWorkspace(t *testing.T) {
+	mockRepo := new(mocks.WorkspaceRepositoryInterface)
+	workspaceUsecase := usecases.NewWorkspaceUsecase(mockRepo, new(mocks.UserRepositoryInterface), new(mocks.JWTService))
+
+	userID := primitive.NewObjectID()
+
+	t.Run("creator becomes the owner", func(t *testing.T) {
+		mockRepo.On("CreateWorkspace", mock.AnythingOfType("*domain.Workspace")).Return(nil).Once()
+
+		workspace := &domain.Workspace{Name: "Acme"}
+		err := workspaceUsecase.CreateWorkspace(workspace, userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.WorkspaceMembership{{UserID: userID, Role: domain.WorkspaceOwner}}, workspace.Members)
+		assert.False(t, workspace.CreatedAt.IsZero())
+	})
+
+	t.Run("name is required", func(t *testing.T) {
+		err := workspaceUsecase.CreateWorkspace(&domain.Workspace{}, userID.Hex())
+
+		assert.EqualError(t, err, "incomplete information")
+	})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetWorkspace(t *testing.T) {
+	mockRepo := new(mocks.WorkspaceRepositoryInterface)
+	workspaceUsecase := usecases.NewWorkspaceUsecase(mockRepo, new(mocks.UserRepositoryInterface), new(mocks.JWTService))
+
+	memberID := primitive.NewObjectID()
+	workspaceID := primitive.NewObjectID()
+	workspace := &domain.Workspace{ID: workspaceID, Members: []domain.WorkspaceMembership{{UserID: memberID, Role: domain.WorkspaceMember}}}
+
+	t.Run("members see the workspace", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(workspace, nil).Once()
+
+		result, err := workspaceUsecase.GetWorkspace(workspaceID.Hex(), memberID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, workspace, result)
+	})
+
+	t.Run("other users do not", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(workspace, nil).Once()
+
+		_, err := workspaceUsecase.GetWorkspace(workspaceID.Hex(), primitive.NewObjectID().Hex())
+
+		assert.EqualError(t, err, "workspace not found")
+	})
+
+	t.Run("members cannot remove it", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(workspace, nil).Once()
+
+		err := workspaceUsecase.RemoveWorkspace(workspaceID.Hex(), memberID.Hex())
+
+		assert.EqualError(t, err, "insufficient workspace permissions")
+	})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddWorkspaceMember(t *testing.T) {
+	mockRepo := new(mocks.WorkspaceRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	workspaceUsecase := usecases.NewWorkspaceUsecase(mockRepo, mockUsers, new(mocks.JWTService))
+
+	ownerID := primitive.NewObjectID()
+	adminID := primitive.NewObjectID()
+	newUserID := primitive.NewObjectID()
+	workspaceID := primitive.NewObjectID()
+
+	workspace := func() *domain.Workspace {
+		return &domain.Workspace{ID: workspaceID, Members: []domain.WorkspaceMembership{
+			{UserID: ownerID, Role: domain.WorkspaceOwner},
+			{UserID: adminID, Role: domain.WorkspaceAdmin},
+		}}
+	}
+
+	t.Run("admin adds a member", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(workspace(), nil).Once()
+		mockUsers.On("GetUser", "new@example.com").Return(&domain.User{ID: newUserID}, nil).Once()
+		mockRepo.On("UpdateWorkspace", workspaceID.Hex(), mock.AnythingOfType("*domain.Workspace")).Return(nil).Once()
+
+		result, err := workspaceUsecase.AddMember(workspaceID.Hex(), "new@example.com", domain.WorkspaceMember, adminID.Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Members, 3)
+	})
+
+	t.Run("admin cannot make owners", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(workspace(), nil).Once()
+		mockUsers.On("GetUser", "new@example.com").Return(&domain.User{ID: newUserID}, nil).Once()
+
+		_, err := workspaceUsecase.AddMember(workspaceID.Hex(), "new@example.com", domain.WorkspaceOwner, adminID.Hex())
+
+		assert.EqualError(t, err, "insufficient workspace permissions")
+	})
+
+	t.Run("the last owner cannot be demoted", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(workspace(), nil).Once()
+		mockUsers.On("GetUser", "owner@example.com").Return(&domain.User{ID: ownerID}, nil).Once()
+
+		_, err := workspaceUsecase.AddMember(workspaceID.Hex(), "owner@example.com", domain.WorkspaceAdmin, ownerID.Hex())
+
+		assert.EqualError(t, err, "a workspace needs at least one owner")
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(workspace(), nil).Once()
+
+		_, err := workspaceUsecase.AddMember(workspaceID.Hex(), "new@example.com", "guest", ownerID.Hex())
+
+		assert.EqualError(t, err, "role must be owner, admin or member")
+	})
+	mockRepo.AssertExpectations(t)
+	mockUsers.AssertExpectations(t)
+}
+
+func TestRemoveWorkspaceMember(t *testing.T) {
+	mockRepo := new(mocks.WorkspaceRepositoryInterface)
+	workspaceUsecase := usecases.NewWorkspaceUsecase(mockRepo, new(mocks.UserRepositoryInterface), new(mocks.JWTService))
+
+	ownerID := primitive.NewObjectID()
+	memberID := primitive.NewObjectID()
+	otherID := primitive.NewObjectID()
+	workspaceID := primitive.NewObjectID()
+
+	workspace := func() *domain.Workspace {
+		return &domain.Workspace{ID: workspaceID, Members: []domain.WorkspaceMembership{
+			{UserID: ownerID, Role: domain.WorkspaceOwner},
+			{UserID: memberID, Role: domain.WorkspaceMember},
+			{UserID: otherID, Role: domain.WorkspaceMember},
+		}}
+	}
+
+	t.Run("members may leave", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(workspace(), nil).Once()
+		mockRepo.On("UpdateWorkspace", workspaceID.Hex(), mock.AnythingOfType("*domain.Workspace")).Return(nil).Once()
+
+		result, err := workspaceUsecase.RemoveMember(workspaceID.Hex(), memberID.Hex(), memberID.Hex())
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Members, 2)
+	})
+
+	t.Run("members cannot remove others", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(workspace(), nil).Once()
+
+		_, err := workspaceUsecase.RemoveMember(workspaceID.Hex(), otherID.Hex(), memberID.Hex())
+
+		assert.EqualError(t, err, "insufficient workspace permissions")
+	})
+
+	t.Run("the last owner cannot leave", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(workspace(), nil).Once()
+
+		_, err := workspaceUsecase.RemoveMember(workspaceID.Hex(), ownerID.Hex(), ownerID.Hex())
+
+		assert.EqualError(t, err, "a workspace needs at least one owner")
+	})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSwitchWorkspace(t *testing.T) {
+	mockRepo := new(mocks.WorkspaceRepositoryInterface)
+	mockUsers := new(mocks.UserRepositoryInterface)
+	mockJWT := new(mocks.JWTService)
+	workspaceUsecase := usecases.NewWorkspaceUsecase(mockRepo, mockUsers, mockJWT)
+
+	userID := primitive.NewObjectID()
+	workspaceID := primitive.NewObjectID()
+	user := &domain.User{ID: userID, Email: "user@example.com", Role: "user"}
+
+	t.Run("issues a token for the workspace", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(&domain.Workspace{ID: workspaceID, Members: []domain.WorkspaceMembership{{UserID: userID, Role: domain.WorkspaceMember}}}, nil).Once()
+		mockUsers.On("GetUserByID", userID.Hex()).Return(user, nil).Once()
+		mockJWT.On("NewToken", userID.Hex(), "user@example.com", "user", workspaceID.Hex()).Return("token", nil).Once()
+
+		token, err := workspaceUsecase.SwitchWorkspace(workspaceID.Hex(), userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "token", token)
+	})
+
+	t.Run("switches back to the personal workspace", func(t *testing.T) {
+		mockUsers.On("GetUserByID", userID.Hex()).Return(user, nil).Once()
+		mockJWT.On("NewToken", userID.Hex(), "user@example.com", "user", "").Return("personal", nil).Once()
+
+		token, err := workspaceUsecase.SwitchWorkspace("", userID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "personal", token)
+	})
+
+	t.Run("non-members cannot switch", func(t *testing.T) {
+		mockRepo.On("GetWorkspace", workspaceID.Hex()).Return(nil, mongo.ErrNoDocuments).Once()
+
+		_, err := workspaceUsecase.SwitchWorkspace(workspaceID.Hex(), userID.Hex())
+
+		assert.EqualError(t, err, "workspace not found")
+	})
+	mockRepo.AssertExpectations(t)
+	mockUsers.AssertExpectations(t)
+	mockJWT.AssertExpectations(t)
+}
+
+// A scoped usecase must only reach the workspace's repositories, so a task
+// of another tenant is not found even by its owner.
+func TestTaskUsecaseInWorkspace(t *testing.T) {
+	mockRepo := new(mocks.TaskRepositoryInterface)
+	scopedRepo := new(mocks.TaskRepositoryInterface)
+	mockProjects := new(mocks.ProjectRepositoryInterface)
+	mockFields := new(mocks.CustomFieldRepositoryInterface)
+	mockEvents := new(mocks.EventPublisher)
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: mockRepo, Projects: mockProjects, Users: new(mocks.UserRepositoryInterface), Attachments: new(mocks.AttachmentRepositoryInterface), Reminders: new(mocks.ReminderRepositoryInterface), Fields: mockFields, Events: mockEvents})
+
+	workspaceID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID().Hex()
+	mockRepo.On("InWorkspace", workspaceID).Return(scopedRepo).Once()
+	mockProjects.On("InWorkspace", workspaceID).Return(new(mocks.ProjectRepositoryInterface)).Once()
+	mockFields.On("InWorkspace", workspaceID).Return(new(mocks.CustomFieldRepositoryInterface)).Once()
+	scopedRepo.On("GetTask", taskID).Return(nil, mongo.ErrNoDocuments).Once()
+
+	scoped, err := taskUsecase.InWorkspace(workspaceID.Hex())
+	assert.NoError(t, err)
+	_, err = scoped.GetTask(taskID, primitive.NewObjectID().Hex())
+
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+	mockRepo.AssertExpectations(t)
+	scopedRepo.AssertExpectations(t)
+	mockProjects.AssertExpectations(t)
+	mockFields.AssertExpectations(t)
+}
+
+// Only the empty ID means personal; anything else must name a workspace.
+func TestTaskUsecaseInWorkspaceMalformed(t *testing.T) {
+	taskUsecase := usecases.NewTaskUsecase(usecases.TaskUsecaseDeps{Tasks: new(mocks.TaskRepositoryInterface)})
+
+	_, err := taskUsecase.InWorkspace("not-an-id")
+
+	assert.EqualError(t, err, "workspace ID is not a valid ObjectID")
+}